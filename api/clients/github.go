@@ -0,0 +1,192 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	OIDCProviderGitHub = "github"
+
+	githubAuthURL = "https://github.com"
+	githubAPIURL  = "https://api.github.com"
+)
+
+type GitHubConfig struct {
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	AuthURL      string
+	APIURL       string
+}
+
+type githubTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	Error       string `json:"error"`
+}
+
+type githubUser struct {
+	ID    int64  `json:"id"`
+	Login string `json:"login"`
+	Name  string `json:"name"`
+}
+
+type githubEmail struct {
+	Email    string `json:"email"`
+	Primary  bool   `json:"primary"`
+	Verified bool   `json:"verified"`
+}
+
+type githubClient struct {
+	config     GitHubConfig
+	httpClient *http.Client
+}
+
+// O GitHub não tem id_token, então Exchange devolve o access token e VerifyIDToken lê a API com ele
+func NewGitHubClient(cfg GitHubConfig, httpClient *http.Client) OIDCClient {
+	if cfg.AuthURL == "" {
+		cfg.AuthURL = githubAuthURL
+	}
+
+	if cfg.APIURL == "" {
+		cfg.APIURL = githubAPIURL
+	}
+
+	return &githubClient{
+		config:     cfg,
+		httpClient: httpClient,
+	}
+}
+
+func (g *githubClient) AuthCodeURL(ctx context.Context, state, nonce, codeVerifier string) (string, error) {
+	authURL, err := url.Parse(strings.TrimRight(g.config.AuthURL, "/") + "/login/oauth/authorize")
+	if err != nil {
+		return "", fmt.Errorf("parse authorization endpoint: %w", err)
+	}
+
+	query := authURL.Query()
+	query.Set("client_id", g.config.ClientID)
+	query.Set("redirect_uri", g.config.RedirectURL)
+	query.Set("scope", "read:user user:email")
+	query.Set("state", state)
+	query.Set("allow_signup", "false")
+	query.Set("code_challenge", PKCEChallenge(codeVerifier))
+	query.Set("code_challenge_method", "S256")
+	authURL.RawQuery = query.Encode()
+
+	return authURL.String(), nil
+}
+
+func (g *githubClient) Exchange(ctx context.Context, code, codeVerifier string) (string, error) {
+	form := url.Values{}
+	form.Set("client_id", g.config.ClientID)
+	form.Set("client_secret", g.config.ClientSecret)
+	form.Set("code", code)
+	form.Set("redirect_uri", g.config.RedirectURL)
+	form.Set("code_verifier", codeVerifier)
+
+	endpoint := strings.TrimRight(g.config.AuthURL, "/") + "/login/oauth/access_token"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("error creating request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("error sending request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 {
+		return "", ErrOIDCExchangeFailed
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token endpoint error with status code: %d", resp.StatusCode)
+	}
+
+	var tokenResp githubTokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
+		return "", fmt.Errorf("error decoding JSON response: %w", err)
+	}
+
+	if tokenResp.Error != "" || tokenResp.AccessToken == "" {
+		return "", ErrOIDCExchangeFailed
+	}
+
+	return tokenResp.AccessToken, nil
+}
+
+func (g *githubClient) VerifyIDToken(ctx context.Context, accessToken, nonce string) (*OIDCIdentity, error) {
+	var user githubUser
+	if err := g.getAPI(ctx, accessToken, "/user", &user); err != nil {
+		return nil, err
+	}
+
+	if user.ID == 0 {
+		return nil, fmt.Errorf("%w: missing user id", ErrOIDCInvalidIDToken)
+	}
+
+	var emails []githubEmail
+	if err := g.getAPI(ctx, accessToken, "/user/emails", &emails); err != nil {
+		return nil, err
+	}
+
+	identity := &OIDCIdentity{
+		Subject: strconv.FormatInt(user.ID, 10),
+		Name:    user.Name,
+	}
+
+	if identity.Name == "" {
+		identity.Name = user.Login
+	}
+
+	// Emails secundários podem ser adicionados sem prova de posse
+	for _, email := range emails {
+		if email.Primary && email.Verified {
+			identity.Email = email.Email
+			identity.EmailVerified = true
+			break
+		}
+	}
+
+	return identity, nil
+}
+
+func (g *githubClient) getAPI(ctx context.Context, accessToken, path string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimRight(g.config.APIURL, "/")+path, nil)
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error sending request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return fmt.Errorf("%w: github api status %d", ErrOIDCInvalidIDToken, resp.StatusCode)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("github api error with status code: %d", resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("error decoding JSON response: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,121 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+const stubGitHubAccessToken = "gho_stub"
+
+func newStubGitHub(t *testing.T, emails []githubEmail) OIDCClient {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/login/oauth/access_token", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.PostForm.Get("code") != "code-1" || r.PostForm.Get("client_secret") != stubClientSecret {
+			json.NewEncoder(w).Encode(githubTokenResponse{Error: "bad_verification_code"})
+			return
+		}
+
+		json.NewEncoder(w).Encode(githubTokenResponse{AccessToken: stubGitHubAccessToken})
+	})
+	mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+stubGitHubAccessToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		json.NewEncoder(w).Encode(githubUser{ID: 42, Login: "merchant"})
+	})
+	mux.HandleFunc("/user/emails", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+stubGitHubAccessToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		json.NewEncoder(w).Encode(emails)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return NewGitHubClient(GitHubConfig{
+		ClientID:     stubClientID,
+		ClientSecret: stubClientSecret,
+		RedirectURL:  stubRedirectURL,
+		AuthURL:      server.URL,
+		APIURL:       server.URL,
+	}, server.Client())
+}
+
+func TestGitHubClientAuthorizationCodeFlow(t *testing.T) {
+	client := newStubGitHub(t, []githubEmail{
+		{Email: "other@example.com", Verified: true},
+		{Email: "merchant@example.com", Primary: true, Verified: true},
+	})
+	ctx := context.Background()
+
+	authURL, err := client.AuthCodeURL(ctx, "state-1", "nonce-1", "verifier")
+	if err != nil {
+		t.Fatalf("auth code url: %v", err)
+	}
+
+	parsed, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatalf("parse auth url: %v", err)
+	}
+
+	query := parsed.Query()
+	if query.Get("state") != "state-1" || query.Get("client_id") != stubClientID || query.Get("redirect_uri") != stubRedirectURL {
+		t.Fatalf("unexpected authorization params: %v", query)
+	}
+
+	token, err := client.Exchange(ctx, "code-1", "verifier")
+	if err != nil {
+		t.Fatalf("exchange: %v", err)
+	}
+
+	identity, err := client.VerifyIDToken(ctx, token, "nonce-1")
+	if err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+
+	if identity.Subject != "42" || identity.Email != "merchant@example.com" || !identity.EmailVerified || identity.Name != "merchant" {
+		t.Fatalf("unexpected identity: %+v", identity)
+	}
+
+	if _, err := client.Exchange(ctx, "code-2", "verifier"); !errors.Is(err, ErrOIDCExchangeFailed) {
+		t.Fatalf("invalid code: expected ErrOIDCExchangeFailed, got %v", err)
+	}
+
+	if _, err := client.VerifyIDToken(ctx, "gho_other", "nonce-1"); !errors.Is(err, ErrOIDCInvalidIDToken) {
+		t.Fatalf("invalid token: expected ErrOIDCInvalidIDToken, got %v", err)
+	}
+}
+
+func TestGitHubClientIgnoresUnverifiedPrimaryEmail(t *testing.T) {
+	client := newStubGitHub(t, []githubEmail{
+		{Email: "merchant@example.com", Primary: true},
+		{Email: "other@example.com", Verified: true},
+	})
+
+	identity, err := client.VerifyIDToken(context.Background(), stubGitHubAccessToken, "")
+	if err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+
+	if identity.Email != "" || identity.EmailVerified {
+		t.Fatalf("expected no verified email, got %+v", identity)
+	}
+}
@@ -0,0 +1,480 @@
+package clients
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/g-villarinho/flash-buy-api/config"
+	"github.com/g-villarinho/flash-buy-api/pkgs"
+	"github.com/g-villarinho/flash-buy-api/utils"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	ErrOIDCProviderNotFound = errors.New("oidc provider not found")
+	ErrOIDCExchangeFailed   = errors.New("oidc code exchange failed")
+	ErrOIDCInvalidIDToken   = errors.New("invalid oidc id token")
+)
+
+const (
+	OIDCProviderGoogle = "google"
+
+	oidcDiscoveryPath = "/.well-known/openid-configuration"
+	oidcClockSkew     = time.Minute
+	pkceVerifierSize  = 32
+
+	// Limita as releituras do JWKS pedidas por kids desconhecidos
+	oidcJWKSRefreshInterval = time.Minute
+)
+
+var oidcSigningMethods = []string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512"}
+
+type OIDCConfig struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+}
+
+type OIDCIdentity struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+type OIDCClient interface {
+	AuthCodeURL(ctx context.Context, state, nonce, codeVerifier string) (string, error)
+	Exchange(ctx context.Context, code, codeVerifier string) (string, error)
+	VerifyIDToken(ctx context.Context, rawIDToken, nonce string) (*OIDCIdentity, error)
+}
+
+type OIDCProviders interface {
+	Provider(name string) (OIDCClient, error)
+}
+
+type oidcProviders struct {
+	di      *pkgs.Di
+	clients map[string]OIDCClient
+}
+
+func NewOIDCProviders(di *pkgs.Di) (OIDCProviders, error) {
+	httpClient := &http.Client{Timeout: config.Env.OIDC.Timeout}
+	callbackURL := strings.TrimRight(config.Env.OIDC.CallbackURL, "/")
+
+	clients := make(map[string]OIDCClient)
+	if config.Env.OIDC.GoogleClientID != "" {
+		clients[OIDCProviderGoogle] = NewOIDCClient(OIDCConfig{
+			Issuer:       config.Env.OIDC.GoogleIssuer,
+			ClientID:     config.Env.OIDC.GoogleClientID,
+			ClientSecret: config.Env.OIDC.GoogleClientSecret,
+			RedirectURL:  fmt.Sprintf("%s/%s/callback", callbackURL, OIDCProviderGoogle),
+		}, httpClient)
+	}
+
+	if config.Env.OIDC.GitHubClientID != "" {
+		clients[OIDCProviderGitHub] = NewGitHubClient(GitHubConfig{
+			ClientID:     config.Env.OIDC.GitHubClientID,
+			ClientSecret: config.Env.OIDC.GitHubClientSecret,
+			RedirectURL:  fmt.Sprintf("%s/%s/callback", callbackURL, OIDCProviderGitHub),
+		}, httpClient)
+	}
+
+	return &oidcProviders{
+		di:      di,
+		clients: clients,
+	}, nil
+}
+
+func (o *oidcProviders) Provider(name string) (OIDCClient, error) {
+	client, ok := o.clients[name]
+	if !ok {
+		return nil, ErrOIDCProviderNotFound
+	}
+
+	return client, nil
+}
+
+type oidcDiscovery struct {
+	Issuer                string   `json:"issuer"`
+	AuthorizationEndpoint string   `json:"authorization_endpoint"`
+	TokenEndpoint         string   `json:"token_endpoint"`
+	JWKSURI               string   `json:"jwks_uri"`
+	TokenAuthMethods      []string `json:"token_endpoint_auth_methods_supported"`
+}
+
+type oidcTokenResponse struct {
+	IDToken string `json:"id_token"`
+}
+
+type oidcJWK struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+type oidcIDTokenClaims struct {
+	Nonce         string `json:"nonce"`
+	Email         string `json:"email"`
+	EmailVerified any    `json:"email_verified"`
+	Name          string `json:"name"`
+	AuthorizedBy  string `json:"azp"`
+	jwt.RegisteredClaims
+}
+
+type oidcClient struct {
+	config     OIDCConfig
+	httpClient *http.Client
+
+	mu          sync.Mutex
+	discovery   *oidcDiscovery
+	keys        map[string]any
+	keysFetched time.Time
+}
+
+func NewOIDCClient(cfg OIDCConfig, httpClient *http.Client) OIDCClient {
+	if len(cfg.Scopes) == 0 {
+		cfg.Scopes = []string{"openid", "email", "profile"}
+	}
+
+	return &oidcClient{
+		config:     cfg,
+		httpClient: httpClient,
+	}
+}
+
+func (o *oidcClient) AuthCodeURL(ctx context.Context, state, nonce, codeVerifier string) (string, error) {
+	discovery, err := o.getDiscovery(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	authURL, err := url.Parse(discovery.AuthorizationEndpoint)
+	if err != nil {
+		return "", fmt.Errorf("parse authorization endpoint: %w", err)
+	}
+
+	query := authURL.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", o.config.ClientID)
+	query.Set("redirect_uri", o.config.RedirectURL)
+	query.Set("scope", strings.Join(o.config.Scopes, " "))
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", PKCEChallenge(codeVerifier))
+	query.Set("code_challenge_method", "S256")
+	authURL.RawQuery = query.Encode()
+
+	return authURL.String(), nil
+}
+
+func (o *oidcClient) Exchange(ctx context.Context, code, codeVerifier string) (string, error) {
+	discovery, err := o.getDiscovery(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", o.config.RedirectURL)
+	form.Set("code_verifier", codeVerifier)
+
+	// client_secret_basic é o padrão da especificação
+	useBasic := len(discovery.TokenAuthMethods) == 0 || slices.Contains(discovery.TokenAuthMethods, "client_secret_basic")
+	if !useBasic {
+		form.Set("client_id", o.config.ClientID)
+		form.Set("client_secret", o.config.ClientSecret)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, discovery.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("error creating request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if useBasic {
+		req.SetBasicAuth(url.QueryEscape(o.config.ClientID), url.QueryEscape(o.config.ClientSecret))
+	}
+
+	resp, err := o.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("error sending request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 {
+		return "", ErrOIDCExchangeFailed
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token endpoint error with status code: %d", resp.StatusCode)
+	}
+
+	var tokenResp oidcTokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
+		return "", fmt.Errorf("error decoding JSON response: %w", err)
+	}
+
+	if tokenResp.IDToken == "" {
+		return "", ErrOIDCExchangeFailed
+	}
+
+	return tokenResp.IDToken, nil
+}
+
+func (o *oidcClient) VerifyIDToken(ctx context.Context, rawIDToken, nonce string) (*OIDCIdentity, error) {
+	discovery, err := o.getDiscovery(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var claims oidcIDTokenClaims
+	_, err = jwt.ParseWithClaims(rawIDToken, &claims,
+		func(token *jwt.Token) (any, error) {
+			kid, _ := token.Header["kid"].(string)
+			return o.getKey(ctx, kid)
+		},
+		jwt.WithValidMethods(oidcSigningMethods),
+		jwt.WithIssuer(discovery.Issuer),
+		jwt.WithAudience(o.config.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(oidcClockSkew),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrOIDCInvalidIDToken, err)
+	}
+
+	if len(claims.Audience) > 1 && claims.AuthorizedBy != o.config.ClientID {
+		return nil, fmt.Errorf("%w: unexpected azp", ErrOIDCInvalidIDToken)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(claims.Nonce), []byte(nonce)) != 1 {
+		return nil, fmt.Errorf("%w: nonce mismatch", ErrOIDCInvalidIDToken)
+	}
+
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("%w: missing sub", ErrOIDCInvalidIDToken)
+	}
+
+	return &OIDCIdentity{
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: isTrueClaim(claims.EmailVerified),
+		Name:          claims.Name,
+	}, nil
+}
+
+func (o *oidcClient) getDiscovery(ctx context.Context) (*oidcDiscovery, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.discovery != nil {
+		return o.discovery, nil
+	}
+
+	var discovery oidcDiscovery
+	if err := o.getJSON(ctx, strings.TrimRight(o.config.Issuer, "/")+oidcDiscoveryPath, &discovery); err != nil {
+		return nil, fmt.Errorf("get discovery document: %w", err)
+	}
+
+	// Um discovery adulterado poderia apontar para chaves de outro emissor
+	if strings.TrimRight(discovery.Issuer, "/") != strings.TrimRight(o.config.Issuer, "/") {
+		return nil, fmt.Errorf("discovery issuer mismatch: %s", discovery.Issuer)
+	}
+
+	if discovery.AuthorizationEndpoint == "" || discovery.TokenEndpoint == "" || discovery.JWKSURI == "" {
+		return nil, errors.New("incomplete discovery document")
+	}
+
+	o.discovery = &discovery
+
+	return o.discovery, nil
+}
+
+func (o *oidcClient) getKey(ctx context.Context, kid string) (any, error) {
+	discovery, err := o.getDiscovery(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if key, ok := o.lookupKey(kid); ok {
+		return key, nil
+	}
+
+	if time.Since(o.keysFetched) < oidcJWKSRefreshInterval {
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+
+	var set struct {
+		Keys []oidcJWK `json:"keys"`
+	}
+	if err := o.getJSON(ctx, discovery.JWKSURI, &set); err != nil {
+		return nil, fmt.Errorf("get jwks: %w", err)
+	}
+
+	keys := make(map[string]any, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		key, err := jwk.publicKey()
+		if err != nil {
+			continue
+		}
+
+		keys[jwk.Kid] = key
+	}
+
+	o.keys = keys
+	o.keysFetched = time.Now()
+
+	if key, ok := o.lookupKey(kid); ok {
+		return key, nil
+	}
+
+	return nil, fmt.Errorf("unknown key id %q", kid)
+}
+
+func (o *oidcClient) lookupKey(kid string) (any, bool) {
+	if kid == "" && len(o.keys) == 1 {
+		for _, key := range o.keys {
+			return key, true
+		}
+	}
+
+	key, ok := o.keys[kid]
+	return key, ok
+}
+
+func (o *oidcClient) getJSON(ctx context.Context, endpoint string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := o.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error sending request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("request error with status code: %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("error reading response: %w", err)
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("error decoding JSON response: %w", err)
+	}
+
+	return nil
+}
+
+func (j oidcJWK) publicKey() (any, error) {
+	switch j.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(j.N)
+		if err != nil {
+			return nil, err
+		}
+
+		e, err := base64.RawURLEncoding.DecodeString(j.E)
+		if err != nil {
+			return nil, err
+		}
+
+		exponent := new(big.Int).SetBytes(e)
+		if !exponent.IsInt64() || exponent.Int64() < 3 {
+			return nil, errors.New("invalid rsa exponent")
+		}
+
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch j.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve: %s", j.Crv)
+		}
+
+		x, err := base64.RawURLEncoding.DecodeString(j.X)
+		if err != nil {
+			return nil, err
+		}
+
+		y, err := base64.RawURLEncoding.DecodeString(j.Y)
+		if err != nil {
+			return nil, err
+		}
+
+		key := &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+
+		if _, err := key.ECDH(); err != nil {
+			return nil, err
+		}
+
+		return key, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type: %s", j.Kty)
+	}
+}
+
+func isTrueClaim(value any) bool {
+	switch v := value.(type) {
+	case bool:
+		return v
+	case string:
+		return v == "true"
+	default:
+		return false
+	}
+}
+
+func NewPKCEVerifier() (string, error) {
+	return utils.GenerateRandomToken(pkceVerifierSize)
+}
+
+func PKCEChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,323 @@
+package clients
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	stubClientID     = "flash-buy"
+	stubClientSecret = "secret"
+	stubRedirectURL  = "http://localhost:8080/v1/oauth/stub/callback"
+)
+
+type stubAuthorization struct {
+	challenge string
+	nonce     string
+}
+
+type stubIdentityProvider struct {
+	server *httptest.Server
+	key    *ecdsa.PrivateKey
+	kid    string
+
+	mu    sync.Mutex
+	codes map[string]stubAuthorization
+}
+
+func newStubIdentityProvider(t *testing.T) *stubIdentityProvider {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+
+	idp := &stubIdentityProvider{
+		key:   key,
+		kid:   "stub-key",
+		codes: make(map[string]stubAuthorization),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", idp.discovery)
+	mux.HandleFunc("/jwks", idp.jwks)
+	mux.HandleFunc("/token", idp.token)
+
+	idp.server = httptest.NewServer(mux)
+	t.Cleanup(idp.server.Close)
+
+	return idp
+}
+
+func (s *stubIdentityProvider) client() OIDCClient {
+	return NewOIDCClient(OIDCConfig{
+		Issuer:       s.server.URL,
+		ClientID:     stubClientID,
+		ClientSecret: stubClientSecret,
+		RedirectURL:  stubRedirectURL,
+	}, s.server.Client())
+}
+
+func (s *stubIdentityProvider) authorize(t *testing.T, authURL string) (string, url.Values) {
+	t.Helper()
+
+	parsed, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatalf("parse authorization url: %v", err)
+	}
+
+	query := parsed.Query()
+	code := "code-" + query.Get("state")
+
+	s.mu.Lock()
+	s.codes[code] = stubAuthorization{
+		challenge: query.Get("code_challenge"),
+		nonce:     query.Get("nonce"),
+	}
+	s.mu.Unlock()
+
+	return code, query
+}
+
+func (s *stubIdentityProvider) discovery(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(map[string]any{
+		"issuer":                                s.server.URL,
+		"authorization_endpoint":                s.server.URL + "/authorize",
+		"token_endpoint":                        s.server.URL + "/token",
+		"jwks_uri":                              s.server.URL + "/jwks",
+		"token_endpoint_auth_methods_supported": []string{"client_secret_basic"},
+	})
+}
+
+func (s *stubIdentityProvider) jwks(w http.ResponseWriter, r *http.Request) {
+	point, _ := s.key.PublicKey.ECDH()
+	xy := point.Bytes()[1:]
+
+	json.NewEncoder(w).Encode(map[string]any{
+		"keys": []map[string]string{{
+			"kid": s.kid,
+			"kty": "EC",
+			"use": "sig",
+			"crv": "P-256",
+			"x":   base64.RawURLEncoding.EncodeToString(xy[:32]),
+			"y":   base64.RawURLEncoding.EncodeToString(xy[32:]),
+		}},
+	})
+}
+
+func (s *stubIdentityProvider) token(w http.ResponseWriter, r *http.Request) {
+	clientID, clientSecret, ok := r.BasicAuth()
+	if !ok || clientID != stubClientID || clientSecret != stubClientSecret {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil || r.PostForm.Get("grant_type") != "authorization_code" || r.PostForm.Get("redirect_uri") != stubRedirectURL {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	authorization, ok := s.codes[r.PostForm.Get("code")]
+	delete(s.codes, r.PostForm.Get("code"))
+	s.mu.Unlock()
+
+	if !ok || PKCEChallenge(r.PostForm.Get("code_verifier")) != authorization.challenge {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	idToken := s.signIDToken(s.claims(authorization.nonce), s.key, s.kid)
+	json.NewEncoder(w).Encode(map[string]string{"id_token": idToken, "token_type": "Bearer"})
+}
+
+func (s *stubIdentityProvider) claims(nonce string) jwt.MapClaims {
+	now := time.Now()
+	return jwt.MapClaims{
+		"iss":            s.server.URL,
+		"sub":            "user-123",
+		"aud":            stubClientID,
+		"iat":            now.Unix(),
+		"exp":            now.Add(time.Hour).Unix(),
+		"nonce":          nonce,
+		"email":          "merchant@example.com",
+		"email_verified": true,
+		"name":           "Merchant",
+	}
+}
+
+func (s *stubIdentityProvider) signIDToken(claims jwt.MapClaims, key *ecdsa.PrivateKey, kid string) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, claims)
+	token.Header["kid"] = kid
+
+	signed, err := token.SignedString(key)
+	if err != nil {
+		panic(err)
+	}
+
+	return signed
+}
+
+func TestOIDCClientAuthorizationCodeFlow(t *testing.T) {
+	idp := newStubIdentityProvider(t)
+	client := idp.client()
+	ctx := context.Background()
+
+	verifier, err := NewPKCEVerifier()
+	if err != nil {
+		t.Fatalf("new pkce verifier: %v", err)
+	}
+
+	authURL, err := client.AuthCodeURL(ctx, "state-1", "nonce-1", verifier)
+	if err != nil {
+		t.Fatalf("auth code url: %v", err)
+	}
+
+	code, query := idp.authorize(t, authURL)
+	if query.Get("code_challenge_method") != "S256" || query.Get("code_challenge") != PKCEChallenge(verifier) {
+		t.Fatalf("unexpected pkce params: %v", query)
+	}
+
+	if query.Get("client_id") != stubClientID || query.Get("redirect_uri") != stubRedirectURL || query.Get("response_type") != "code" {
+		t.Fatalf("unexpected authorization params: %v", query)
+	}
+
+	idToken, err := client.Exchange(ctx, code, verifier)
+	if err != nil {
+		t.Fatalf("exchange: %v", err)
+	}
+
+	identity, err := client.VerifyIDToken(ctx, idToken, "nonce-1")
+	if err != nil {
+		t.Fatalf("verify id token: %v", err)
+	}
+
+	if identity.Subject != "user-123" || identity.Email != "merchant@example.com" || !identity.EmailVerified || identity.Name != "Merchant" {
+		t.Fatalf("unexpected identity: %+v", identity)
+	}
+
+	if _, err := client.Exchange(ctx, code, verifier); !errors.Is(err, ErrOIDCExchangeFailed) {
+		t.Fatalf("reused code: expected ErrOIDCExchangeFailed, got %v", err)
+	}
+}
+
+func TestOIDCClientExchangeRejectsWrongVerifier(t *testing.T) {
+	idp := newStubIdentityProvider(t)
+	client := idp.client()
+	ctx := context.Background()
+
+	verifier, _ := NewPKCEVerifier()
+	authURL, err := client.AuthCodeURL(ctx, "state-1", "nonce-1", verifier)
+	if err != nil {
+		t.Fatalf("auth code url: %v", err)
+	}
+
+	code, _ := idp.authorize(t, authURL)
+
+	otherVerifier, _ := NewPKCEVerifier()
+	if _, err := client.Exchange(ctx, code, otherVerifier); !errors.Is(err, ErrOIDCExchangeFailed) {
+		t.Fatalf("expected ErrOIDCExchangeFailed, got %v", err)
+	}
+}
+
+func TestOIDCClientVerifyIDTokenRejectsInvalidTokens(t *testing.T) {
+	idp := newStubIdentityProvider(t)
+	client := idp.client()
+
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(jwt.MapClaims)
+		key    *ecdsa.PrivateKey
+		kid    string
+		nonce  string
+	}{
+		{name: "nonce mismatch", nonce: "other-nonce"},
+		{name: "wrong audience", mutate: func(c jwt.MapClaims) { c["aud"] = "other-client" }},
+		{name: "wrong issuer", mutate: func(c jwt.MapClaims) { c["iss"] = "https://evil.example.com" }},
+		{name: "expired", mutate: func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Hour).Unix() }},
+		{name: "missing expiration", mutate: func(c jwt.MapClaims) { delete(c, "exp") }},
+		{name: "missing subject", mutate: func(c jwt.MapClaims) { delete(c, "sub") }},
+		{name: "multiple audiences without azp", mutate: func(c jwt.MapClaims) { c["aud"] = []string{stubClientID, "other-client"} }},
+		{name: "unknown signing key", key: otherKey},
+		{name: "unknown kid", kid: "rotated-away"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := idp.claims("nonce-1")
+			if tt.mutate != nil {
+				tt.mutate(claims)
+			}
+
+			key, kid, nonce := idp.key, idp.kid, "nonce-1"
+			if tt.key != nil {
+				key = tt.key
+			}
+			if tt.kid != "" {
+				kid = tt.kid
+			}
+			if tt.nonce != "" {
+				nonce = tt.nonce
+			}
+
+			idToken := idp.signIDToken(claims, key, kid)
+			if _, err := client.VerifyIDToken(context.Background(), idToken, nonce); !errors.Is(err, ErrOIDCInvalidIDToken) {
+				t.Fatalf("expected ErrOIDCInvalidIDToken, got %v", err)
+			}
+		})
+	}
+}
+
+func TestOIDCClientVerifyIDTokenRejectsHMACTokens(t *testing.T) {
+	idp := newStubIdentityProvider(t)
+	client := idp.client()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, idp.claims("nonce-1"))
+	token.Header["kid"] = idp.kid
+
+	idToken, err := token.SignedString([]byte(stubClientSecret))
+	if err != nil {
+		t.Fatalf("sign token: %v", err)
+	}
+
+	if _, err := client.VerifyIDToken(context.Background(), idToken, "nonce-1"); !errors.Is(err, ErrOIDCInvalidIDToken) {
+		t.Fatalf("expected ErrOIDCInvalidIDToken, got %v", err)
+	}
+}
+
+func TestOIDCClientRejectsDiscoveryIssuerMismatch(t *testing.T) {
+	idp := newStubIdentityProvider(t)
+
+	client := NewOIDCClient(OIDCConfig{
+		Issuer:      idp.server.URL + "/other",
+		ClientID:    stubClientID,
+		RedirectURL: stubRedirectURL,
+	}, idp.server.Client())
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/other/.well-known/openid-configuration", idp.discovery)
+	idp.server.Config.Handler = mux
+
+	if _, err := client.AuthCodeURL(context.Background(), "state", "nonce", "verifier"); err == nil {
+		t.Fatal("expected discovery issuer mismatch error")
+	}
+}
@@ -0,0 +1,165 @@
+package clients
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/g-villarinho/flash-buy-api/config"
+	"github.com/g-villarinho/flash-buy-api/pkgs"
+)
+
+var (
+	ErrInvalidWebhookSignature = errors.New("invalid webhook signature")
+	ErrPaymentNotFound         = errors.New("payment not found")
+	ErrInvalidPaymentState     = errors.New("invalid payment state")
+	ErrMissingWebhookSecret    = errors.New("payment webhook secret is required")
+	ErrPaymentUnavailable      = errors.New("payment provider unavailable")
+)
+
+const (
+	PaymentProviderFake = "fake"
+
+	webhookTolerance = 5 * time.Minute
+)
+
+type PaymentEventType string
+
+const (
+	PaymentEventSucceeded PaymentEventType = "payment.succeeded"
+	PaymentEventFailed    PaymentEventType = "payment.failed"
+	PaymentEventRefunded  PaymentEventType = "payment.refunded"
+)
+
+type PaymentIntentRequest struct {
+	OrderID       string
+	AmountInCents int64
+	Currency      string
+	CustomerEmail string
+}
+
+type PaymentIntent struct {
+	ID           string `json:"id"`
+	ClientSecret string `json:"clientSecret"`
+	CheckoutURL  string `json:"checkoutUrl"`
+}
+
+type PaymentEvent struct {
+	ID            string           `json:"id"`
+	Type          PaymentEventType `json:"type"`
+	PaymentID     string           `json:"paymentId"`
+	OrderID       string           `json:"orderId"`
+	AmountInCents int64            `json:"amountInCents"`
+}
+
+type PaymentProvider interface {
+	CreatePaymentIntent(ctx context.Context, req PaymentIntentRequest) (*PaymentIntent, error)
+	CapturePayment(ctx context.Context, paymentID string) error
+	RefundPayment(ctx context.Context, paymentID string, amountInCents int64) error
+	ParseWebhook(payload []byte, signature string) (*PaymentEvent, error)
+}
+
+func NewPaymentProvider(di *pkgs.Di) (PaymentProvider, error) {
+	switch config.Env.Payment.Provider {
+	case PaymentProviderFake:
+		if !config.Env.IsDev() {
+			slog.Warn("payments disabled", "reason", fmt.Sprintf("payment provider %s is only allowed with ENV=%s", PaymentProviderFake, config.EnvDev))
+			return unavailablePaymentProvider{}, nil
+		}
+	default:
+		return nil, fmt.Errorf("unsupported payment provider: %s", config.Env.Payment.Provider)
+	}
+
+	if config.Env.Payment.WebhookSecret == "" {
+		slog.Warn("payments disabled", "reason", ErrMissingWebhookSecret.Error())
+		return unavailablePaymentProvider{}, nil
+	}
+
+	return NewFakePaymentGateway(config.Env.Payment.WebhookSecret), nil
+}
+
+func IsPaymentAvailable(pp PaymentProvider) bool {
+	_, unavailable := pp.(unavailablePaymentProvider)
+	return !unavailable
+}
+
+type unavailablePaymentProvider struct{}
+
+func (unavailablePaymentProvider) CreatePaymentIntent(ctx context.Context, req PaymentIntentRequest) (*PaymentIntent, error) {
+	return nil, ErrPaymentUnavailable
+}
+
+func (unavailablePaymentProvider) CapturePayment(ctx context.Context, paymentID string) error {
+	return ErrPaymentUnavailable
+}
+
+func (unavailablePaymentProvider) RefundPayment(ctx context.Context, paymentID string, amountInCents int64) error {
+	return ErrPaymentUnavailable
+}
+
+func (unavailablePaymentProvider) ParseWebhook(payload []byte, signature string) (*PaymentEvent, error) {
+	return nil, ErrPaymentUnavailable
+}
+
+func SignPaymentWebhook(secret string, payload []byte, timestamp time.Time) string {
+	ts := strconv.FormatInt(timestamp.Unix(), 10)
+	return fmt.Sprintf("t=%s,v1=%s", ts, computeWebhookHMAC(secret, ts, payload))
+}
+
+// Um HMAC com chave vazia pode ser calculado por qualquer um
+func VerifyPaymentWebhook(secret string, payload []byte, signature string, now time.Time) error {
+	if secret == "" {
+		return ErrMissingWebhookSecret
+	}
+
+	var ts, v1 string
+	for _, part := range strings.Split(signature, ",") {
+		key, value, found := strings.Cut(strings.TrimSpace(part), "=")
+		if !found {
+			continue
+		}
+
+		switch key {
+		case "t":
+			ts = value
+		case "v1":
+			v1 = value
+		}
+	}
+
+	if ts == "" || v1 == "" {
+		return ErrInvalidWebhookSignature
+	}
+
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return ErrInvalidWebhookSignature
+	}
+
+	age := now.Sub(time.Unix(unix, 0))
+	if age > webhookTolerance || age < -webhookTolerance {
+		return ErrInvalidWebhookSignature
+	}
+
+	expected := computeWebhookHMAC(secret, ts, payload)
+	if !hmac.Equal([]byte(expected), []byte(v1)) {
+		return ErrInvalidWebhookSignature
+	}
+
+	return nil
+}
+
+func computeWebhookHMAC(secret, ts string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
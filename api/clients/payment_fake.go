@@ -0,0 +1,177 @@
+package clients
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type fakePaymentStatus string
+
+const (
+	fakePaymentCreated  fakePaymentStatus = "created"
+	fakePaymentCaptured fakePaymentStatus = "captured"
+	fakePaymentRefunded fakePaymentStatus = "refunded"
+)
+
+type fakePayment struct {
+	orderID       string
+	amountInCents int64
+	status        fakePaymentStatus
+}
+
+// O ID carrega o pedido e o valor, então um pagamento perdido num restart é reconstruído
+type FakePaymentGateway struct {
+	secret   string
+	mu       sync.Mutex
+	payments map[string]*fakePayment
+}
+
+func NewFakePaymentGateway(secret string) *FakePaymentGateway {
+	return &FakePaymentGateway{
+		secret:   secret,
+		payments: make(map[string]*fakePayment),
+	}
+}
+
+func (f *FakePaymentGateway) CreatePaymentIntent(ctx context.Context, req PaymentIntentRequest) (*PaymentIntent, error) {
+	if req.AmountInCents <= 0 {
+		return nil, fmt.Errorf("invalid amount: %d", req.AmountInCents)
+	}
+
+	paymentID := fmt.Sprintf("pi_fake_%s_%d", req.OrderID, req.AmountInCents)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.payments[paymentID]; !ok {
+		f.payments[paymentID] = &fakePayment{
+			orderID:       req.OrderID,
+			amountInCents: req.AmountInCents,
+			status:        fakePaymentCreated,
+		}
+	}
+
+	return &PaymentIntent{
+		ID:           paymentID,
+		ClientSecret: fakeID("secret", paymentID),
+		CheckoutURL:  fmt.Sprintf("https://fake-gateway.local/checkout/%s", paymentID),
+	}, nil
+}
+
+func (f *FakePaymentGateway) CapturePayment(ctx context.Context, paymentID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	payment, err := f.getPayment(paymentID, fakePaymentCreated)
+	if err != nil {
+		return err
+	}
+
+	if payment.status != fakePaymentCreated {
+		return ErrInvalidPaymentState
+	}
+
+	payment.status = fakePaymentCaptured
+
+	return nil
+}
+
+func (f *FakePaymentGateway) RefundPayment(ctx context.Context, paymentID string, amountInCents int64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	payment, err := f.getPayment(paymentID, fakePaymentCaptured)
+	if err != nil {
+		return err
+	}
+
+	if payment.status != fakePaymentCaptured {
+		return ErrInvalidPaymentState
+	}
+
+	if amountInCents <= 0 || amountInCents > payment.amountInCents {
+		return fmt.Errorf("invalid refund amount: %d", amountInCents)
+	}
+
+	payment.status = fakePaymentRefunded
+
+	return nil
+}
+
+func (f *FakePaymentGateway) ParseWebhook(payload []byte, signature string) (*PaymentEvent, error) {
+	if err := VerifyPaymentWebhook(f.secret, payload, signature, time.Now()); err != nil {
+		return nil, err
+	}
+
+	var event PaymentEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, fmt.Errorf("decode webhook payload: %w", err)
+	}
+
+	return &event, nil
+}
+
+func (f *FakePaymentGateway) BuildWebhook(eventType PaymentEventType, paymentID string) ([]byte, string, error) {
+	f.mu.Lock()
+	payment, err := f.getPayment(paymentID, fakePaymentCreated)
+	f.mu.Unlock()
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	payload, err := json.Marshal(PaymentEvent{
+		ID:            fakeID("evt", string(eventType)+paymentID),
+		Type:          eventType,
+		PaymentID:     paymentID,
+		OrderID:       payment.orderID,
+		AmountInCents: payment.amountInCents,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return payload, SignPaymentWebhook(f.secret, payload, time.Now()), nil
+}
+
+func (f *FakePaymentGateway) getPayment(paymentID string, status fakePaymentStatus) (*fakePayment, error) {
+	if payment, ok := f.payments[paymentID]; ok {
+		return payment, nil
+	}
+
+	rest, ok := strings.CutPrefix(paymentID, "pi_fake_")
+	if !ok {
+		return nil, ErrPaymentNotFound
+	}
+
+	sep := strings.LastIndex(rest, "_")
+	if sep <= 0 {
+		return nil, ErrPaymentNotFound
+	}
+
+	amount, err := strconv.ParseInt(rest[sep+1:], 10, 64)
+	if err != nil || amount <= 0 {
+		return nil, ErrPaymentNotFound
+	}
+
+	payment := &fakePayment{
+		orderID:       rest[:sep],
+		amountInCents: amount,
+		status:        status,
+	}
+	f.payments[paymentID] = payment
+
+	return payment, nil
+}
+
+func fakeID(prefix, seed string) string {
+	sum := sha256.Sum256([]byte(seed))
+	return fmt.Sprintf("%s_fake_%s", prefix, hex.EncodeToString(sum[:12]))
+}
@@ -0,0 +1,180 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/g-villarinho/flash-buy-api/config"
+)
+
+const testWebhookSecret = "whsec_test"
+
+func TestVerifyPaymentWebhook(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	payload := []byte(`{"id":"evt_1","type":"payment.succeeded"}`)
+	signature := SignPaymentWebhook(testWebhookSecret, payload, now)
+
+	tests := []struct {
+		name      string
+		secret    string
+		payload   []byte
+		signature string
+		now       time.Time
+		want      error
+	}{
+		{name: "valid", secret: testWebhookSecret, payload: payload, signature: signature, now: now},
+		{name: "valid within tolerance", secret: testWebhookSecret, payload: payload, signature: signature, now: now.Add(webhookTolerance)},
+		{name: "tampered payload", secret: testWebhookSecret, payload: []byte(`{"id":"evt_1","type":"payment.refunded"}`), signature: signature, now: now, want: ErrInvalidWebhookSignature},
+		{name: "tampered signature", secret: testWebhookSecret, payload: payload, signature: signature[:len(signature)-1] + "0", now: now, want: ErrInvalidWebhookSignature},
+		{name: "wrong secret", secret: "other", payload: payload, signature: signature, now: now, want: ErrInvalidWebhookSignature},
+		{name: "stale timestamp", secret: testWebhookSecret, payload: payload, signature: signature, now: now.Add(webhookTolerance + time.Second), want: ErrInvalidWebhookSignature},
+		{name: "future timestamp", secret: testWebhookSecret, payload: payload, signature: signature, now: now.Add(-webhookTolerance - time.Second), want: ErrInvalidWebhookSignature},
+		{name: "malformed signature", secret: testWebhookSecret, payload: payload, signature: "v1=abc", now: now, want: ErrInvalidWebhookSignature},
+		{name: "empty secret", secret: "", payload: payload, signature: SignPaymentWebhook("", payload, now), now: now, want: ErrMissingWebhookSecret},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyPaymentWebhook(tt.secret, tt.payload, tt.signature, tt.now)
+			if err != tt.want {
+				t.Errorf("VerifyPaymentWebhook() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestFakePaymentGatewayLifecycle(t *testing.T) {
+	ctx := context.Background()
+	gateway := NewFakePaymentGateway(testWebhookSecret)
+
+	intent, err := gateway.CreatePaymentIntent(ctx, PaymentIntentRequest{OrderID: "order-1", AmountInCents: 1500})
+	if err != nil {
+		t.Fatalf("CreatePaymentIntent() error = %v", err)
+	}
+
+	again, err := gateway.CreatePaymentIntent(ctx, PaymentIntentRequest{OrderID: "order-1", AmountInCents: 1500})
+	if err != nil || again.ID != intent.ID {
+		t.Fatalf("CreatePaymentIntent() for the same order = %v, %v, want %s", again, err, intent.ID)
+	}
+
+	if err := gateway.RefundPayment(ctx, intent.ID, 1500); err != ErrInvalidPaymentState {
+		t.Errorf("RefundPayment() before capture = %v, want %v", err, ErrInvalidPaymentState)
+	}
+
+	if err := gateway.CapturePayment(ctx, intent.ID); err != nil {
+		t.Fatalf("CapturePayment() error = %v", err)
+	}
+
+	if err := gateway.CapturePayment(ctx, intent.ID); err != ErrInvalidPaymentState {
+		t.Errorf("CapturePayment() twice = %v, want %v", err, ErrInvalidPaymentState)
+	}
+
+	if err := gateway.RefundPayment(ctx, intent.ID, 1501); err == nil {
+		t.Error("RefundPayment() above the paid amount succeeded")
+	}
+
+	if err := gateway.RefundPayment(ctx, intent.ID, 1500); err != nil {
+		t.Fatalf("RefundPayment() error = %v", err)
+	}
+}
+
+func TestFakePaymentGatewayInvalidAmount(t *testing.T) {
+	gateway := NewFakePaymentGateway(testWebhookSecret)
+
+	if _, err := gateway.CreatePaymentIntent(context.Background(), PaymentIntentRequest{OrderID: "order-1"}); err == nil {
+		t.Error("CreatePaymentIntent() with zero amount succeeded")
+	}
+}
+
+func TestFakePaymentGatewaySurvivesRestart(t *testing.T) {
+	ctx := context.Background()
+
+	intent, err := NewFakePaymentGateway(testWebhookSecret).CreatePaymentIntent(ctx, PaymentIntentRequest{OrderID: "order-1", AmountInCents: 1500})
+	if err != nil {
+		t.Fatalf("CreatePaymentIntent() error = %v", err)
+	}
+
+	restarted := NewFakePaymentGateway(testWebhookSecret)
+	if err := restarted.CapturePayment(ctx, intent.ID); err != nil {
+		t.Fatalf("CapturePayment() after restart = %v", err)
+	}
+
+	restarted = NewFakePaymentGateway(testWebhookSecret)
+	if err := restarted.RefundPayment(ctx, intent.ID, 1500); err != nil {
+		t.Fatalf("RefundPayment() after restart = %v", err)
+	}
+
+	if err := restarted.CapturePayment(ctx, "pi_unknown"); err != ErrPaymentNotFound {
+		t.Errorf("CapturePayment() with unknown id = %v, want %v", err, ErrPaymentNotFound)
+	}
+}
+
+func TestFakePaymentGatewayWebhook(t *testing.T) {
+	gateway := NewFakePaymentGateway(testWebhookSecret)
+
+	intent, err := gateway.CreatePaymentIntent(context.Background(), PaymentIntentRequest{OrderID: "order-1", AmountInCents: 1500})
+	if err != nil {
+		t.Fatalf("CreatePaymentIntent() error = %v", err)
+	}
+
+	payload, signature, err := gateway.BuildWebhook(PaymentEventSucceeded, intent.ID)
+	if err != nil {
+		t.Fatalf("BuildWebhook() error = %v", err)
+	}
+
+	event, err := gateway.ParseWebhook(payload, signature)
+	if err != nil {
+		t.Fatalf("ParseWebhook() error = %v", err)
+	}
+
+	if event.Type != PaymentEventSucceeded || event.PaymentID != intent.ID || event.OrderID != "order-1" || event.AmountInCents != 1500 {
+		t.Errorf("ParseWebhook() = %+v", event)
+	}
+
+	forged := []byte(strings.Replace(string(payload), "1500", "1", 1))
+	if _, err := gateway.ParseWebhook(forged, signature); !errors.Is(err, ErrInvalidWebhookSignature) {
+		t.Errorf("ParseWebhook() with forged payload = %v, want %v", err, ErrInvalidWebhookSignature)
+	}
+
+	if _, err := NewFakePaymentGateway("").ParseWebhook(payload, SignPaymentWebhook("", payload, time.Now())); !errors.Is(err, ErrMissingWebhookSecret) {
+		t.Errorf("ParseWebhook() with empty secret = %v, want %v", err, ErrMissingWebhookSecret)
+	}
+}
+
+func TestNewPaymentProviderUnavailable(t *testing.T) {
+	original := config.Env
+	t.Cleanup(func() { config.Env = original })
+
+	tests := []struct {
+		name   string
+		env    string
+		secret string
+	}{
+		{name: "fake outside dev", env: "production", secret: testWebhookSecret},
+		{name: "missing webhook secret", env: config.EnvDev, secret: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config.Env.Env = tt.env
+			config.Env.Payment.Provider = PaymentProviderFake
+			config.Env.Payment.WebhookSecret = tt.secret
+
+			pp, err := NewPaymentProvider(nil)
+			if err != nil {
+				t.Fatalf("NewPaymentProvider() error = %v", err)
+			}
+
+			if IsPaymentAvailable(pp) {
+				t.Fatal("expected payments to be unavailable")
+			}
+
+			if _, err := pp.CreatePaymentIntent(context.Background(), PaymentIntentRequest{}); err != ErrPaymentUnavailable {
+				t.Fatalf("CreatePaymentIntent() error = %v, want %v", err, ErrPaymentUnavailable)
+			}
+		})
+	}
+}
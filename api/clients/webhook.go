@@ -0,0 +1,115 @@
+package clients
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/g-villarinho/flash-buy-api/config"
+	"github.com/g-villarinho/flash-buy-api/pkgs"
+)
+
+var ErrWebhookAddressNotAllowed = errors.New("webhook address not allowed")
+
+const (
+	WebhookSignatureHeader = "X-Webhook-Signature"
+	WebhookEventHeader     = "X-Webhook-Event"
+	WebhookIDHeader        = "X-Webhook-ID"
+
+	webhookUserAgent       = "FlashBuy-Webhooks/1.0"
+	webhookMaxResponseSize = 64 << 10
+)
+
+type WebhookRequest struct {
+	URL       string
+	Secret    string
+	EventID   string
+	EventType string
+	Payload   []byte
+}
+
+type WebhookClient interface {
+	// O erro só é preenchido quando não houve resposta
+	Send(ctx context.Context, req WebhookRequest) (int, error)
+}
+
+type webhookClient struct {
+	di         *pkgs.Di
+	httpClient *http.Client
+}
+
+func NewWebhookClient(di *pkgs.Di) (WebhookClient, error) {
+	dialer := &net.Dialer{
+		Timeout: config.Env.Webhook.Timeout,
+	}
+
+	// A URL vem do lojista, então endereços internos são recusados depois do DNS
+	if !config.Env.Webhook.AllowPrivateNetworks {
+		dialer.Control = rejectPrivateAddress
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	transport.Proxy = nil
+
+	return &webhookClient{
+		di: di,
+		httpClient: &http.Client{
+			Timeout:   config.Env.Webhook.Timeout,
+			Transport: transport,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}, nil
+}
+
+func (w *webhookClient) Send(ctx context.Context, req WebhookRequest) (int, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, req.URL, bytes.NewReader(req.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("create webhook request: %w", err)
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("User-Agent", webhookUserAgent)
+	httpReq.Header.Set(WebhookIDHeader, req.EventID)
+	httpReq.Header.Set(WebhookEventHeader, req.EventType)
+	httpReq.Header.Set(WebhookSignatureHeader, SignWebhook(req.Secret, req.Payload, time.Now()))
+
+	resp, err := w.httpClient.Do(httpReq)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, webhookMaxResponseSize))
+
+	return resp.StatusCode, nil
+}
+
+func SignWebhook(secret string, payload []byte, timestamp time.Time) string {
+	ts := strconv.FormatInt(timestamp.Unix(), 10)
+	return fmt.Sprintf("t=%s,v1=%s", ts, computeWebhookHMAC(secret, ts, payload))
+}
+
+func rejectPrivateAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsMulticast() {
+		return ErrWebhookAddressNotAllowed
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -20,14 +21,15 @@ func LoadEnv() error {
 		return fmt.Errorf("init env: %w", err)
 	}
 
+	// Os arquivos legados são opcionais quando KEY_RING_DIR já tem uma chave ativa
 	if Env.Key.PrivateKey == "" || Env.Key.PublicKey == "" {
 		privateKey, err := LoadKeyFromFile("ecdsa_private.pem")
-		if err != nil {
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
 			return fmt.Errorf("load private key: %w", err)
 		}
 
 		publicKey, err := LoadKeyFromFile("ecdsa_public.pem")
-		if err != nil {
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
 			return fmt.Errorf("load public key: %w", err)
 		}
 
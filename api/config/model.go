@@ -2,15 +2,30 @@ package config
 
 import "time"
 
+const EnvDev = "dev"
+
 type Environment struct {
-	Env             string `env:"ENV,default=dev"`
-	Log             Log
-	Key             Key
-	Postgres        Postgres
-	API             API
-	SMTP            SMTP
-	Cookie          Cookie
-	CloudFlareImage CloudFlareImage
+	Env      string `env:"ENV,default=dev"`
+	Log      Log
+	Key      Key
+	Postgres Postgres
+	API      API
+	SMTP     SMTP
+	Cookie   Cookie
+	Storage  Storage
+	Images   Images
+	Payment  Payment
+	Frontend Frontend
+	OIDC     OIDC
+	Webhook  Webhook
+	Jobs     Jobs
+	Outbox   Outbox
+	Orders   Orders
+	Carts    Carts
+}
+
+func (e Environment) IsDev() bool {
+	return e.Env == EnvDev
 }
 
 type Postgres struct {
@@ -33,6 +48,7 @@ type Log struct {
 type Key struct {
 	PrivateKey string `env:"KEY_ECDSA_PRIVATE"`
 	PublicKey  string `env:"KEY_ECDSA_PUBLIC"`
+	Dir        string `env:"KEY_RING_DIR,default=keys"`
 }
 
 type API struct {
@@ -50,8 +66,84 @@ type Cookie struct {
 	Name string `env:"COOKIE_NAME,default=XPLife_id"`
 }
 
-type CloudFlareImage struct {
-	Token   string        `env:"CLOUD_FLARE_IMAGE_API_TOKEN"`
-	URL     string        `env:"CLOUD_FLARE_IMAGE_API_URL"`
-	Timeout time.Duration `env:"CLOUD_FLARE_IMAGE_TIMEOUT,default=30s"`
+type Storage struct {
+	Driver     string        `env:"STORAGE_DRIVER"`
+	PublicURL  string        `env:"STORAGE_PUBLIC_URL,default=http://localhost:8080/uploads"`
+	LocalDir   string        `env:"STORAGE_LOCAL_DIR,default=./uploads"`
+	Timeout    time.Duration `env:"STORAGE_TIMEOUT,default=30s"`
+	S3         S3
+	Cloudflare Cloudflare
+}
+
+type S3 struct {
+	Endpoint        string `env:"STORAGE_S3_ENDPOINT"`
+	Region          string `env:"STORAGE_S3_REGION,default=us-east-1"`
+	Bucket          string `env:"STORAGE_S3_BUCKET"`
+	AccessKeyID     string `env:"STORAGE_S3_ACCESS_KEY_ID"`
+	SecretAccessKey string `env:"STORAGE_S3_SECRET_ACCESS_KEY"`
+	PathStyle       bool   `env:"STORAGE_S3_PATH_STYLE,default=true"`
+}
+
+type Cloudflare struct {
+	APIURL  string `env:"CLOUD_FLARE_IMAGE_API_URL"`
+	Token   string `env:"CLOUD_FLARE_IMAGE_API_TOKEN"`
+	Variant string `env:"CLOUD_FLARE_IMAGE_VARIANT,default=public"`
+}
+
+type Images struct {
+	MaxFileSize int64  `env:"IMAGES_MAX_FILE_SIZE,default=10485760"`
+	MaxPixels   int    `env:"IMAGES_MAX_PIXELS,default=40000000"`
+	Format      string `env:"IMAGES_FORMAT,default=webp"`
+	Quality     int    `env:"IMAGES_QUALITY,default=85"`
+}
+
+type Payment struct {
+	Provider      string `env:"PAYMENT_PROVIDER,default=fake"`
+	WebhookSecret string `env:"PAYMENT_WEBHOOK_SECRET"`
+	Currency      string `env:"PAYMENT_CURRENCY,default=BRL"`
+}
+
+type Frontend struct {
+	URL string `env:"FRONTEND_URL,default=http://localhost:3000"`
+}
+
+type OIDC struct {
+	CallbackURL        string        `env:"OIDC_CALLBACK_URL,default=http://localhost:8080/v1/oauth"`
+	Timeout            time.Duration `env:"OIDC_TIMEOUT,default=10s"`
+	GoogleIssuer       string        `env:"OIDC_GOOGLE_ISSUER,default=https://accounts.google.com"`
+	GoogleClientID     string        `env:"OIDC_GOOGLE_CLIENT_ID"`
+	GoogleClientSecret string        `env:"OIDC_GOOGLE_CLIENT_SECRET"`
+	GitHubClientID     string        `env:"OIDC_GITHUB_CLIENT_ID"`
+	GitHubClientSecret string        `env:"OIDC_GITHUB_CLIENT_SECRET"`
+}
+
+type Webhook struct {
+	Timeout              time.Duration `env:"WEBHOOK_TIMEOUT,default=10s"`
+	PollInterval         time.Duration `env:"WEBHOOK_POLL_INTERVAL,default=5s"`
+	BatchSize            int           `env:"WEBHOOK_BATCH_SIZE,default=20"`
+	AllowPrivateNetworks bool          `env:"WEBHOOK_ALLOW_PRIVATE_NETWORKS,default=false"`
+}
+
+type Jobs struct {
+	Concurrency     int           `env:"JOBS_CONCURRENCY,default=4"`
+	PollInterval    time.Duration `env:"JOBS_POLL_INTERVAL,default=1s"`
+	Timeout         time.Duration `env:"JOBS_TIMEOUT,default=2m"`
+	MaxAttempts     int           `env:"JOBS_MAX_ATTEMPTS,default=5"`
+	ShutdownTimeout time.Duration `env:"JOBS_SHUTDOWN_TIMEOUT,default=30s"`
+}
+
+type Outbox struct {
+	PollInterval time.Duration `env:"OUTBOX_POLL_INTERVAL,default=1s"`
+	BatchSize    int           `env:"OUTBOX_BATCH_SIZE,default=50"`
+	Timeout      time.Duration `env:"OUTBOX_TIMEOUT,default=30s"`
+}
+
+type Orders struct {
+	PendingTTL   time.Duration `env:"ORDERS_PENDING_TTL,default=30m"`
+	PollInterval time.Duration `env:"ORDERS_POLL_INTERVAL,default=1m"`
+	BatchSize    int           `env:"ORDERS_BATCH_SIZE,default=50"`
+}
+
+type Carts struct {
+	CleanupInterval time.Duration `env:"CARTS_CLEANUP_INTERVAL,default=1h"`
 }
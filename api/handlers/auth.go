@@ -14,8 +14,10 @@ import (
 type AuthHandler interface {
 	Login(ectx echo.Context) error
 	VerifyCode(ectx echo.Context) error
+	VerifyMagicLink(ectx echo.Context) error
 	ResendCode(ectx echo.Context) error
 	CheckCode(ectx echo.Context) error
+	VerifySecondFactor(ectx echo.Context) error
 }
 
 type authHandler struct {
@@ -59,13 +61,23 @@ func (a *authHandler) Login(ectx echo.Context) error {
 		UserAgent: ectx.Request().UserAgent(),
 	}
 
-	session, err := a.as.Login(ectx.Request().Context(), payload.Email, ssi)
+	session, err := a.as.Login(ectx.Request().Context(), payload.Email, payload.Method, ssi)
 	if err != nil {
+		if err == models.ErrInvalidLoginMethod {
+			logger.Warn("invalid login method", "method", payload.Method)
+			return ectx.NoContent(http.StatusBadRequest)
+		}
+
 		if err == models.ErrUserNotFound {
 			logger.Warn("user not found", "error", err)
 			return ectx.NoContent(http.StatusNotFound)
 		}
 
+		if err == models.ErrOTPSendLimit {
+			logger.Warn("otp send limit reached", "error", err)
+			return ectx.NoContent(http.StatusTooManyRequests)
+		}
+
 		logger.Error("error to login", "error", err)
 		return ectx.NoContent(http.StatusInternalServerError)
 	}
@@ -92,8 +104,18 @@ func (a *authHandler) VerifyCode(ectx echo.Context) error {
 		return ectx.NoContent(http.StatusBadRequest)
 	}
 
-	session, err := a.as.VerifyCode(ectx.Request().Context(), payload.Code, userToken)
+	ssi := models.SessionSecurityInfo{
+		IP:        ectx.RealIP(),
+		UserAgent: ectx.Request().UserAgent(),
+	}
+
+	session, err := a.as.VerifyCode(ectx.Request().Context(), payload.Code, userToken, ssi)
 	if err != nil {
+		if err == models.ErrOTPLocked {
+			logger.Warn("otp locked", "error", err)
+			return ectx.NoContent(http.StatusTooManyRequests)
+		}
+
 		if err == models.ErrOTPNotFound {
 			logger.Warn("otp not found", "error", err)
 			DelCookieSession(ectx)
@@ -121,8 +143,53 @@ func (a *authHandler) VerifyCode(ectx echo.Context) error {
 		return ectx.NoContent(http.StatusInternalServerError)
 	}
 
-	SetCookieSession(ectx, *session)
-	return ectx.NoContent(http.StatusOK)
+	return respondSession(ectx, session)
+}
+
+func (a *authHandler) VerifyMagicLink(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "auth",
+		"method", "VerifyMagicLink",
+	)
+
+	var payload models.VerifyMagicLinkPayload
+	if err := jsoniter.NewDecoder(ectx.Request().Body).Decode(&payload); err != nil {
+		logger.Error("error to bind payload", "error", err)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	if payload.Token == "" {
+		logger.Warn("token is empty")
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	ssi := models.SessionSecurityInfo{
+		IP:        ectx.RealIP(),
+		UserAgent: ectx.Request().UserAgent(),
+	}
+
+	session, err := a.as.VerifyMagicLink(ectx.Request().Context(), payload.Token, ssi)
+	if err != nil {
+		if err == models.ErrMagicLinkInvalid || err == models.ErrOTPInvalid {
+			logger.Warn("magic link invalid", "error", err)
+			return ectx.NoContent(http.StatusBadRequest)
+		}
+
+		if err == models.ErrOTPLocked {
+			logger.Warn("otp locked", "error", err)
+			return ectx.NoContent(http.StatusTooManyRequests)
+		}
+
+		if err == models.ErrOTPNotFound || err == models.ErrOTPExpired || err == models.ErrSessionNotFoundOrExpired {
+			logger.Warn("magic link expired or already used", "error", err)
+			return ectx.NoContent(http.StatusForbidden)
+		}
+
+		logger.Error("failed to verify magic link", "error", err)
+		return ectx.NoContent(http.StatusInternalServerError)
+	}
+
+	return respondSession(ectx, session)
 }
 
 func (a *authHandler) ResendCode(ectx echo.Context) error {
@@ -143,7 +210,17 @@ func (a *authHandler) ResendCode(ectx echo.Context) error {
 		return ectx.NoContent(http.StatusBadRequest)
 	}
 
-	if err := a.as.ResendCode(ectx.Request().Context(), email, userToken); err != nil {
+	ssi := models.SessionSecurityInfo{
+		IP:        ectx.RealIP(),
+		UserAgent: ectx.Request().UserAgent(),
+	}
+
+	if err := a.as.ResendCode(ectx.Request().Context(), email, userToken, ssi); err != nil {
+		if err == models.ErrOTPResendCooldown || err == models.ErrOTPSendLimit {
+			logger.Warn("otp resend throttled", "error", err)
+			return ectx.NoContent(http.StatusTooManyRequests)
+		}
+
 		if err == models.ErrOTPNotFound {
 			logger.Warn("otp not found", "error", err)
 			DelCookieSession(ectx)
@@ -160,3 +237,67 @@ func (a *authHandler) ResendCode(ectx echo.Context) error {
 func (a *authHandler) CheckCode(ectx echo.Context) error {
 	return ectx.NoContent(http.StatusOK)
 }
+
+func (a *authHandler) VerifySecondFactor(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "auth",
+		"method", "VerifySecondFactor",
+	)
+
+	var payload models.VerifySecondFactorPayload
+	if err := jsoniter.NewDecoder(ectx.Request().Body).Decode(&payload); err != nil {
+		logger.Error("error to bind payload", "error", err)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	if payload.Code == "" && payload.RecoveryCode == "" {
+		logger.Warn("code and recovery code are empty")
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	userToken, ok := a.rdp.GetToken(ectx.Request().Context())
+	if !ok {
+		logger.Error("failed to get token from context")
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	session, err := a.as.VerifySecondFactor(ectx.Request().Context(), userToken, payload)
+	if err != nil {
+		if err == models.ErrTOTPInvalid || err == models.ErrRecoveryCodeInvalid || err == models.ErrTOTPNotEnabled {
+			logger.Warn("second factor invalid", "error", err)
+			return ectx.NoContent(http.StatusBadRequest)
+		}
+
+		if err == models.ErrTOTPLocked {
+			logger.Warn("totp locked", "error", err)
+			return ectx.NoContent(http.StatusTooManyRequests)
+		}
+
+		if err == models.ErrFirstFactorRequired {
+			logger.Warn("first factor required", "error", err)
+			return ectx.NoContent(http.StatusForbidden)
+		}
+
+		if err == models.ErrSessionNotFoundOrExpired {
+			logger.Warn("session not found or expired", "error", err)
+			DelCookieSession(ectx)
+			return ectx.NoContent(http.StatusForbidden)
+		}
+
+		logger.Error("failed to verify second factor", "error", err)
+		return ectx.NoContent(http.StatusInternalServerError)
+	}
+
+	SetCookieSession(ectx, *session)
+	return ectx.NoContent(http.StatusOK)
+}
+
+func respondSession(ectx echo.Context, session *models.Session) error {
+	SetCookieSession(ectx, *session)
+
+	if session.IsPendingMFA() {
+		return ectx.JSON(http.StatusAccepted, models.MFARequiredResponse{MFARequired: true})
+	}
+
+	return ectx.NoContent(http.StatusOK)
+}
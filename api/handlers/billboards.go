@@ -1,8 +1,12 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/g-villarinho/flash-buy-api/models"
 	"github.com/g-villarinho/flash-buy-api/pkgs"
@@ -14,6 +18,7 @@ import (
 
 type BillboardHandler interface {
 	CreateBillboard(ectx echo.Context) error
+	UpdateBillboard(ectx echo.Context) error
 	GetBillboards(ectx echo.Context) error
 	DeleteBillboard(ectx echo.Context) error
 	GetBillboardByID(ectx echo.Context) error
@@ -73,23 +78,38 @@ func (b *billboardHandler) CreateBillboard(ectx echo.Context) error {
 		return ectx.NoContent(http.StatusBadRequest)
 	}
 
-	label := ectx.FormValue("label")
-	if label == "" {
-		logger.Warn("label is empty")
+	payload, err := parseBillboardPayload(ectx)
+	if err != nil {
+		logger.Warn("invalid billboard payload", "error", err)
 		return ectx.NoContent(http.StatusBadRequest)
 	}
 
-	if err := b.bs.CreateBillboard(ectx.Request().Context(), storeID, userID, file, label); err != nil {
+	if err := b.bs.CreateBillboard(ectx.Request().Context(), storeID, userID, file, payload); err != nil {
 		if err == models.ErrStoreNotFound {
 			logger.Warn("store not found", "storeID", storeID)
 			return ectx.NoContent(http.StatusNotFound)
 		}
 
-		if err == models.ErrStoreNotPertenence {
+		if err == models.ErrStoreNotPertenence || err == models.ErrStorePermissionDenied {
 			logger.Warn("store not pertenence to user", "storeID", storeID)
 			return ectx.NoContent(http.StatusForbidden)
 		}
 
+		if err == models.ErrInvalidImage || err == models.ErrInvalidBillboardWindow {
+			logger.Warn("invalid billboard", "error", err)
+			return ectx.NoContent(http.StatusBadRequest)
+		}
+
+		if err == models.ErrImageTooLarge {
+			logger.Warn("image too large")
+			return ectx.NoContent(http.StatusRequestEntityTooLarge)
+		}
+
+		if err == models.ErrUnsupportedImageType {
+			logger.Warn("unsupported image type")
+			return ectx.NoContent(http.StatusUnsupportedMediaType)
+		}
+
 		logger.Error("create billboard", "error", err)
 		return ectx.NoContent(http.StatusInternalServerError)
 	}
@@ -97,6 +117,77 @@ func (b *billboardHandler) CreateBillboard(ectx echo.Context) error {
 	return ectx.NoContent(http.StatusCreated)
 }
 
+func (b *billboardHandler) UpdateBillboard(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "billboard",
+		"method", "UpdateBillboard",
+	)
+
+	storeID := ectx.Param("storeId")
+	if _, err := uuid.Parse(storeID); err != nil {
+		logger.Warn("invalid storeID format", "storeID", storeID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	billboardID := ectx.Param("billboardId")
+	if _, err := uuid.Parse(billboardID); err != nil {
+		logger.Warn("invalid billboardID format", "billboardID", billboardID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	userID, ok := b.rdp.GetUserID(ectx.Request().Context())
+	if !ok {
+		logger.Error("get user id from context")
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	file, err := ectx.FormFile("image")
+	if err != nil && err != http.ErrMissingFile {
+		logger.Warn("error to bind payload", "error", err)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	payload, err := parseBillboardPayload(ectx)
+	if err != nil {
+		logger.Warn("invalid billboard payload", "error", err)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	resp, err := b.bs.UpdateBillboard(ectx.Request().Context(), storeID, userID, billboardID, file, payload)
+	if err != nil {
+		if err == models.ErrStoreNotFound || err == models.ErrBillboardNotFound {
+			logger.Warn("billboard not found", "storeID", storeID, "billboardID", billboardID)
+			return ectx.NoContent(http.StatusNotFound)
+		}
+
+		if err == models.ErrStoreNotPertenence || err == models.ErrStorePermissionDenied {
+			logger.Warn("store not pertenence to user", "storeID", storeID)
+			return ectx.NoContent(http.StatusForbidden)
+		}
+
+		if err == models.ErrInvalidImage || err == models.ErrInvalidBillboardWindow {
+			logger.Warn("invalid billboard", "error", err)
+			return ectx.NoContent(http.StatusBadRequest)
+		}
+
+		if err == models.ErrImageTooLarge {
+			logger.Warn("image too large")
+			return ectx.NoContent(http.StatusRequestEntityTooLarge)
+		}
+
+		if err == models.ErrUnsupportedImageType {
+			logger.Warn("unsupported image type")
+			return ectx.NoContent(http.StatusUnsupportedMediaType)
+		}
+
+		logger.Error("update billboard", "error", err)
+		return ectx.NoContent(http.StatusInternalServerError)
+	}
+
+	return ectx.JSON(http.StatusOK, resp)
+}
+
 func (b *billboardHandler) GetBillboards(ectx echo.Context) error {
 	logger := slog.With(
 		"handler", "billboard",
@@ -120,8 +211,25 @@ func (b *billboardHandler) GetBillboards(ectx echo.Context) error {
 		utils.GetQueryStringPointer(ectx.QueryParam("label")),
 	)
 
-	resp, err := b.bs.GetBillboardsPagedList(ectx.Request().Context(), storeID, *pag)
+	userID, ok := b.rdp.GetUserID(ectx.Request().Context())
+	if !ok {
+		logger.Error("get user id from context")
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	resp, err := b.bs.GetBillboardsPagedList(ectx.Request().Context(), userID, storeID, *pag)
 	if err != nil {
+		if err == models.ErrStoreNotFound {
+			logger.Warn("store not found", "storeID", storeID)
+			return ectx.NoContent(http.StatusNotFound)
+		}
+
+		if err == models.ErrStoreNotPertenence || err == models.ErrStorePermissionDenied {
+			logger.Warn("store not pertenence to user", "storeID", storeID)
+			return ectx.NoContent(http.StatusForbidden)
+		}
+
 		logger.Error("failed to fetch billboards", "error", err)
 		return ectx.NoContent(http.StatusInternalServerError)
 	}
@@ -170,7 +278,7 @@ func (b *billboardHandler) DeleteBillboard(ectx echo.Context) error {
 			return ectx.NoContent(http.StatusNotFound)
 		}
 
-		if err == models.ErrStoreNotPertenence {
+		if err == models.ErrStoreNotPertenence || err == models.ErrStorePermissionDenied {
 			logger.Warn("store not pertenence to user", "storeID", storeID)
 			return ectx.NoContent(http.StatusForbidden)
 		}
@@ -215,8 +323,25 @@ func (b *billboardHandler) GetBillboardByID(ectx echo.Context) error {
 		return ectx.NoContent(http.StatusBadRequest)
 	}
 
-	resp, err := b.bs.GetBillboardByID(ectx.Request().Context(), storeID, billboardID)
+	userID, ok := b.rdp.GetUserID(ectx.Request().Context())
+	if !ok {
+		logger.Error("get user id from context")
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	resp, err := b.bs.GetBillboardByID(ectx.Request().Context(), userID, storeID, billboardID)
 	if err != nil {
+		if err == models.ErrStoreNotFound {
+			logger.Warn("store not found", "storeID", storeID)
+			return ectx.NoContent(http.StatusNotFound)
+		}
+
+		if err == models.ErrStoreNotPertenence || err == models.ErrStorePermissionDenied {
+			logger.Warn("store not pertenence to user", "storeID", storeID)
+			return ectx.NoContent(http.StatusForbidden)
+		}
+
 		if err == models.ErrBillboardNotFound {
 			logger.Warn("billboard not found", "billboardID", billboardID)
 			return ectx.NoContent(http.StatusNotFound)
@@ -228,3 +353,38 @@ func (b *billboardHandler) GetBillboardByID(ectx echo.Context) error {
 
 	return ectx.JSON(http.StatusOK, resp)
 }
+
+func parseBillboardPayload(ectx echo.Context) (models.BillboardPayload, error) {
+	payload := models.BillboardPayload{
+		Label: strings.TrimSpace(ectx.FormValue("label")),
+	}
+
+	if payload.Label == "" {
+		return payload, errors.New("label is empty")
+	}
+
+	var err error
+	if payload.StartsAt, err = parseFormTime(ectx, "startsAt"); err != nil {
+		return payload, err
+	}
+
+	if payload.EndsAt, err = parseFormTime(ectx, "endsAt"); err != nil {
+		return payload, err
+	}
+
+	return payload, nil
+}
+
+func parseFormTime(ectx echo.Context, field string) (*time.Time, error) {
+	value := ectx.FormValue(field)
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", field, err)
+	}
+
+	return &t, nil
+}
@@ -0,0 +1,234 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/g-villarinho/flash-buy-api/models"
+	"github.com/g-villarinho/flash-buy-api/pkgs"
+	"github.com/g-villarinho/flash-buy-api/services"
+	"github.com/google/uuid"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/labstack/echo/v4"
+)
+
+const CartTokenHeader = "X-Cart-Token"
+
+type CartHandler interface {
+	CreateCart(ectx echo.Context) error
+	GetCart(ectx echo.Context) error
+	AddItem(ectx echo.Context) error
+	UpdateItem(ectx echo.Context) error
+	RemoveItem(ectx echo.Context) error
+}
+
+type cartHandler struct {
+	di  *pkgs.Di
+	cs  services.CartService
+	rdp pkgs.RequestDataCtx
+}
+
+func NewCartHandler(di *pkgs.Di) (CartHandler, error) {
+	cs, err := pkgs.Invoke[services.CartService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	ctxData, err := pkgs.Invoke[pkgs.RequestDataCtx](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cartHandler{
+		di:  di,
+		cs:  cs,
+		rdp: ctxData,
+	}, nil
+}
+
+func (c *cartHandler) CreateCart(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "cart",
+		"method", "CreateCart",
+	)
+
+	storeID := ectx.Param("storeId")
+	if _, err := uuid.Parse(storeID); err != nil {
+		logger.Warn("invalid storeID format", "storeID", storeID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	customerID, _ := c.rdp.GetUserID(ectx.Request().Context())
+
+	resp, err := c.cs.CreateCart(ectx.Request().Context(), storeID, customerID)
+	if err != nil {
+		if err == models.ErrStoreNotFound {
+			logger.Warn("store not found", "storeID", storeID)
+			return ectx.NoContent(http.StatusNotFound)
+		}
+
+		logger.Error("create cart", "error", err)
+		return ectx.NoContent(http.StatusInternalServerError)
+	}
+
+	return ectx.JSON(http.StatusCreated, resp)
+}
+
+func (c *cartHandler) GetCart(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "cart",
+		"method", "GetCart",
+	)
+
+	storeID := ectx.Param("storeId")
+	if _, err := uuid.Parse(storeID); err != nil {
+		logger.Warn("invalid storeID format", "storeID", storeID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	resp, err := c.cs.GetCart(ectx.Request().Context(), storeID, c.getCartIdentity(ectx))
+	if err != nil {
+		if err == models.ErrCartNotFound {
+			logger.Warn("cart not found", "storeID", storeID)
+			return ectx.NoContent(http.StatusNotFound)
+		}
+
+		logger.Error("get cart", "error", err)
+		return ectx.NoContent(http.StatusInternalServerError)
+	}
+
+	return ectx.JSON(http.StatusOK, resp)
+}
+
+func (c *cartHandler) AddItem(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "cart",
+		"method", "AddItem",
+	)
+
+	storeID := ectx.Param("storeId")
+	if _, err := uuid.Parse(storeID); err != nil {
+		logger.Warn("invalid storeID format", "storeID", storeID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	var payload models.AddCartItemPayload
+	if err := jsoniter.NewDecoder(ectx.Request().Body).Decode(&payload); err != nil {
+		logger.Error("error to bind payload", "error", err)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	resp, err := c.cs.AddItem(ectx.Request().Context(), storeID, c.getCartIdentity(ectx), payload)
+	if err != nil {
+		return c.handleCartItemError(ectx, logger, err)
+	}
+
+	return ectx.JSON(http.StatusOK, resp)
+}
+
+func (c *cartHandler) UpdateItem(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "cart",
+		"method", "UpdateItem",
+	)
+
+	storeID := ectx.Param("storeId")
+	if _, err := uuid.Parse(storeID); err != nil {
+		logger.Warn("invalid storeID format", "storeID", storeID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	itemID := ectx.Param("itemId")
+	if _, err := uuid.Parse(itemID); err != nil {
+		logger.Warn("invalid itemID format", "itemID", itemID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	var payload models.UpdateCartItemPayload
+	if err := jsoniter.NewDecoder(ectx.Request().Body).Decode(&payload); err != nil {
+		logger.Error("error to bind payload", "error", err)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	resp, err := c.cs.UpdateItem(ectx.Request().Context(), storeID, c.getCartIdentity(ectx), itemID, payload.Quantity)
+	if err != nil {
+		return c.handleCartItemError(ectx, logger, err)
+	}
+
+	return ectx.JSON(http.StatusOK, resp)
+}
+
+func (c *cartHandler) RemoveItem(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "cart",
+		"method", "RemoveItem",
+	)
+
+	storeID := ectx.Param("storeId")
+	if _, err := uuid.Parse(storeID); err != nil {
+		logger.Warn("invalid storeID format", "storeID", storeID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	itemID := ectx.Param("itemId")
+	if _, err := uuid.Parse(itemID); err != nil {
+		logger.Warn("invalid itemID format", "itemID", itemID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	resp, err := c.cs.RemoveItem(ectx.Request().Context(), storeID, c.getCartIdentity(ectx), itemID)
+	if err != nil {
+		return c.handleCartItemError(ectx, logger, err)
+	}
+
+	return ectx.JSON(http.StatusOK, resp)
+}
+
+func (c *cartHandler) getCartIdentity(ectx echo.Context) models.CartIdentity {
+	customerID, _ := c.rdp.GetUserID(ectx.Request().Context())
+
+	return models.CartIdentity{
+		Token:      ectx.Request().Header.Get(CartTokenHeader),
+		CustomerID: customerID,
+	}
+}
+
+func (c *cartHandler) handleCartItemError(ectx echo.Context, logger *slog.Logger, err error) error {
+	if err == models.ErrCartNotFound {
+		logger.Warn("cart not found", "error", err)
+		return ectx.NoContent(http.StatusNotFound)
+	}
+
+	if err == models.ErrCartItemNotFound {
+		logger.Warn("cart item not found", "error", err)
+		return ectx.NoContent(http.StatusNotFound)
+	}
+
+	if err == models.ErrProductNotFound {
+		logger.Warn("product not found", "error", err)
+		return ectx.NoContent(http.StatusNotFound)
+	}
+
+	if err == models.ErrProductArchived {
+		logger.Warn("product archived", "error", err)
+		return ectx.NoContent(http.StatusUnprocessableEntity)
+	}
+
+	if err == models.ErrProductVariantNotFound {
+		logger.Warn("product variant not found", "error", err)
+		return ectx.NoContent(http.StatusNotFound)
+	}
+
+	if err == models.ErrProductVariantRequired {
+		logger.Warn("product variant required", "error", err)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	if err == models.ErrInvalidCartQuantity {
+		logger.Warn("invalid cart quantity", "error", err)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	logger.Error("cart operation", "error", err)
+	return ectx.NoContent(http.StatusInternalServerError)
+}
@@ -18,6 +18,9 @@ type CategoryHandler interface {
 	GetCategoriesPagedList(ectx echo.Context) error
 	DeleteCategory(ectx echo.Context) error
 	GetCategoryByID(ectx echo.Context) error
+	GetCategoryTree(ectx echo.Context) error
+	MoveCategory(ectx echo.Context) error
+	ReorderCategories(ectx echo.Context) error
 }
 
 type categoryHandler struct {
@@ -91,7 +94,12 @@ func (c *categoryHandler) CreateCategory(ectx echo.Context) error {
 			return ectx.NoContent(http.StatusNotFound)
 		}
 
-		if err == models.ErrStoreNotPertenence {
+		if err == models.ErrInvalidCategoryParent {
+			logger.Warn("invalid category parent", "error", err)
+			return ectx.NoContent(http.StatusBadRequest)
+		}
+
+		if err == models.ErrStoreNotPertenence || err == models.ErrStorePermissionDenied {
 			logger.Warn("store not pertenence to user", "error", err)
 			return ectx.NoContent(http.StatusForbidden)
 		}
@@ -127,14 +135,21 @@ func (c *categoryHandler) GetCategoriesPagedList(ectx echo.Context) error {
 		utils.GetQueryStringPointer(ectx.QueryParam("billboardId")),
 	)
 
-	resp, err := c.cs.GetCategoriesPagedList(ectx.Request().Context(), storeID, *pag)
+	userID, ok := c.rdp.GetUserID(ectx.Request().Context())
+	if !ok {
+		logger.Error("get user id from context")
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	resp, err := c.cs.GetCategoriesPagedList(ectx.Request().Context(), userID, storeID, *pag)
 	if err != nil {
 		if err == models.ErrStoreNotFound {
 			logger.Warn("store not found", "error", err)
 			return ectx.NoContent(http.StatusNotFound)
 		}
 
-		if err == models.ErrStoreNotPertenence {
+		if err == models.ErrStoreNotPertenence || err == models.ErrStorePermissionDenied {
 			logger.Warn("store not pertenence to user", "error", err)
 			return ectx.NoContent(http.StatusForbidden)
 		}
@@ -174,8 +189,25 @@ func (c *categoryHandler) GetCategoryByID(ectx echo.Context) error {
 		return ectx.NoContent(http.StatusBadRequest)
 	}
 
-	resp, err := c.cs.GetCategoryByID(ectx.Request().Context(), storeID, categoryID)
+	userID, ok := c.rdp.GetUserID(ectx.Request().Context())
+	if !ok {
+		logger.Error("get user id from context")
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	resp, err := c.cs.GetCategoryByID(ectx.Request().Context(), userID, storeID, categoryID)
 	if err != nil {
+		if err == models.ErrStoreNotFound {
+			logger.Warn("store not found", "storeID", storeID)
+			return ectx.NoContent(http.StatusNotFound)
+		}
+
+		if err == models.ErrStoreNotPertenence || err == models.ErrStorePermissionDenied {
+			logger.Warn("store not pertenence to user", "storeID", storeID)
+			return ectx.NoContent(http.StatusForbidden)
+		}
+
 		if err == models.ErrCategoryNotFound {
 			logger.Warn("category not found", "error", err)
 			return ectx.NoContent(http.StatusNotFound)
@@ -229,7 +261,7 @@ func (c *categoryHandler) DeleteCategory(ectx echo.Context) error {
 			return ectx.NoContent(http.StatusNotFound)
 		}
 
-		if err == models.ErrStoreNotPertenence {
+		if err == models.ErrStoreNotPertenence || err == models.ErrStorePermissionDenied {
 			logger.Warn("store not pertenence to user", "error", err)
 			return ectx.NoContent(http.StatusForbidden)
 		}
@@ -239,9 +271,144 @@ func (c *categoryHandler) DeleteCategory(ectx echo.Context) error {
 			return ectx.NoContent(http.StatusNotFound)
 		}
 
+		if err == models.ErrCategoryHasChildren {
+			logger.Warn("category has children", "error", err)
+			return ectx.NoContent(http.StatusConflict)
+		}
+
 		logger.Error("error to delete category", "error", err)
 		return ectx.NoContent(http.StatusInternalServerError)
 	}
 
 	return ectx.NoContent(http.StatusOK)
 }
+
+func (c *categoryHandler) GetCategoryTree(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "category",
+		"method", "GetCategoryTree",
+	)
+
+	storeID := ectx.Param("storeId")
+	if _, err := uuid.Parse(storeID); err != nil {
+		logger.Warn("invalid storeID format", "storeID", storeID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	userID, ok := c.rdp.GetUserID(ectx.Request().Context())
+	if !ok {
+		logger.Error("get user id from context")
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	resp, err := c.cs.GetCategoryTree(ectx.Request().Context(), userID, storeID)
+	if err != nil {
+		if err == models.ErrStoreNotFound {
+			logger.Warn("store not found", "storeID", storeID)
+			return ectx.NoContent(http.StatusNotFound)
+		}
+
+		if err == models.ErrStoreNotPertenence || err == models.ErrStorePermissionDenied {
+			logger.Warn("store not pertenence to user", "storeID", storeID)
+			return ectx.NoContent(http.StatusForbidden)
+		}
+
+		logger.Error("error to get category tree", "error", err)
+		return ectx.NoContent(http.StatusInternalServerError)
+	}
+
+	return ectx.JSON(http.StatusOK, resp)
+}
+
+func (c *categoryHandler) MoveCategory(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "category",
+		"method", "MoveCategory",
+	)
+
+	storeID := ectx.Param("storeId")
+	if _, err := uuid.Parse(storeID); err != nil {
+		logger.Warn("invalid storeID format", "storeID", storeID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	categoryID := ectx.Param("categoryId")
+	if _, err := uuid.Parse(categoryID); err != nil {
+		logger.Warn("invalid categoryID format", "categoryID", categoryID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	var payload models.MoveCategoryPayload
+	if err := jsoniter.NewDecoder(ectx.Request().Body).Decode(&payload); err != nil {
+		logger.Error("error to bind payload", "error", err)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	userID, ok := c.rdp.GetUserID(ectx.Request().Context())
+	if !ok {
+		logger.Error("get user id from context")
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	resp, err := c.cs.MoveCategory(ectx.Request().Context(), userID, storeID, categoryID, payload)
+	if err != nil {
+		return c.handleCategoryError(ectx, logger, err)
+	}
+
+	return ectx.JSON(http.StatusOK, resp)
+}
+
+func (c *categoryHandler) ReorderCategories(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "category",
+		"method", "ReorderCategories",
+	)
+
+	storeID := ectx.Param("storeId")
+	if _, err := uuid.Parse(storeID); err != nil {
+		logger.Warn("invalid storeID format", "storeID", storeID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	var payload models.ReorderCategoriesPayload
+	if err := jsoniter.NewDecoder(ectx.Request().Body).Decode(&payload); err != nil {
+		logger.Error("error to bind payload", "error", err)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	userID, ok := c.rdp.GetUserID(ectx.Request().Context())
+	if !ok {
+		logger.Error("get user id from context")
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	resp, err := c.cs.ReorderCategories(ectx.Request().Context(), userID, storeID, payload)
+	if err != nil {
+		return c.handleCategoryError(ectx, logger, err)
+	}
+
+	return ectx.JSON(http.StatusOK, resp)
+}
+
+func (c *categoryHandler) handleCategoryError(ectx echo.Context, logger *slog.Logger, err error) error {
+	switch err {
+	case models.ErrStoreNotFound, models.ErrCategoryNotFound:
+		logger.Warn("resource not found", "error", err)
+		return ectx.NoContent(http.StatusNotFound)
+	case models.ErrStoreNotPertenence, models.ErrStorePermissionDenied:
+		logger.Warn("store not pertenence to user", "error", err)
+		return ectx.NoContent(http.StatusForbidden)
+	case models.ErrInvalidCategoryParent, models.ErrInvalidCategoryOrder:
+		logger.Warn("invalid category payload", "error", err)
+		return ectx.NoContent(http.StatusBadRequest)
+	case models.ErrCategoryCycle:
+		logger.Warn("category cycle", "error", err)
+		return ectx.NoContent(http.StatusConflict)
+	}
+
+	logger.Error("error to update category tree", "error", err)
+	return ectx.NoContent(http.StatusInternalServerError)
+}
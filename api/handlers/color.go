@@ -85,7 +85,7 @@ func (c *colorHandler) CreateColor(ectx echo.Context) error {
 			return ectx.NoContent(http.StatusNotFound)
 		}
 
-		if err == models.ErrStoreNotPertenence {
+		if err == models.ErrStoreNotPertenence || err == models.ErrStorePermissionDenied {
 			logger.Warn("store not pertenence to user", "error", err)
 			return ectx.NoContent(http.StatusForbidden)
 		}
@@ -125,8 +125,25 @@ func (c *colorHandler) GetColors(ectx echo.Context) error {
 		utils.GetQueryStringPointer(ectx.QueryParam("name")),
 	)
 
-	resp, err := c.cs.GetColorsPagedList(ectx.Request().Context(), storeID, *pag)
+	userID, ok := c.rdp.GetUserID(ectx.Request().Context())
+	if !ok {
+		logger.Error("get user id from context")
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	resp, err := c.cs.GetColorsPagedList(ectx.Request().Context(), userID, storeID, *pag)
 	if err != nil {
+		if err == models.ErrStoreNotFound {
+			logger.Warn("store not found", "storeID", storeID)
+			return ectx.NoContent(http.StatusNotFound)
+		}
+
+		if err == models.ErrStoreNotPertenence || err == models.ErrStorePermissionDenied {
+			logger.Warn("store not pertenence to user", "storeID", storeID)
+			return ectx.NoContent(http.StatusForbidden)
+		}
+
 		logger.Error("failed to fetch billboards", "error", err)
 		return ectx.NoContent(http.StatusInternalServerError)
 	}
@@ -162,8 +179,25 @@ func (c *colorHandler) GetColorByID(ectx echo.Context) error {
 		return ectx.NoContent(http.StatusBadRequest)
 	}
 
-	resp, err := c.cs.GetColorByID(ectx.Request().Context(), storeID, colorID)
+	userID, ok := c.rdp.GetUserID(ectx.Request().Context())
+	if !ok {
+		logger.Error("get user id from context")
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	resp, err := c.cs.GetColorByID(ectx.Request().Context(), userID, storeID, colorID)
 	if err != nil {
+		if err == models.ErrStoreNotFound {
+			logger.Warn("store not found", "storeID", storeID)
+			return ectx.NoContent(http.StatusNotFound)
+		}
+
+		if err == models.ErrStoreNotPertenence || err == models.ErrStorePermissionDenied {
+			logger.Warn("store not pertenence to user", "storeID", storeID)
+			return ectx.NoContent(http.StatusForbidden)
+		}
+
 		if err == models.ErrColorNotFound {
 			logger.Warn("color not found", "error", err)
 			return ectx.NoContent(http.StatusNotFound)
@@ -216,7 +250,7 @@ func (c *colorHandler) DeleteColor(ectx echo.Context) error {
 			return ectx.NoContent(http.StatusNotFound)
 		}
 
-		if err == models.ErrStoreNotPertenence {
+		if err == models.ErrStoreNotPertenence || err == models.ErrStorePermissionDenied {
 			logger.Warn("store not pertenence to user", "error", err)
 			return ectx.NoContent(http.StatusForbidden)
 		}
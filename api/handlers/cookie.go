@@ -33,3 +33,30 @@ func DelCookieSession(ectx echo.Context) {
 		MaxAge:   -1,
 	})
 }
+
+const socialLoginStateCookie = "social_login_state"
+
+// Lax porque o callback chega por um redirecionamento do provedor
+func SetCookieSocialLoginState(ectx echo.Context, state string, maxAge time.Duration) {
+	ectx.SetCookie(&http.Cookie{
+		Name:     socialLoginStateCookie,
+		Value:    state,
+		Path:     "/v1/oauth",
+		HttpOnly: true,
+		Secure:   false,
+		SameSite: http.SameSiteLaxMode,
+		Expires:  time.Now().Add(maxAge),
+		MaxAge:   int(maxAge.Seconds()),
+	})
+}
+
+func DelCookieSocialLoginState(ectx echo.Context) {
+	ectx.SetCookie(&http.Cookie{
+		Name:     socialLoginStateCookie,
+		Value:    "",
+		Path:     "/v1/oauth",
+		HttpOnly: true,
+		Expires:  time.Unix(0, 0),
+		MaxAge:   -1,
+	})
+}
@@ -0,0 +1,325 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/g-villarinho/flash-buy-api/models"
+	"github.com/g-villarinho/flash-buy-api/pkgs"
+	"github.com/g-villarinho/flash-buy-api/services"
+	"github.com/g-villarinho/flash-buy-api/utils"
+	"github.com/google/uuid"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/labstack/echo/v4"
+)
+
+type FlashSaleHandler interface {
+	CreateFlashSale(ectx echo.Context) error
+	GetFlashSales(ectx echo.Context) error
+	GetFlashSaleByID(ectx echo.Context) error
+	DeleteFlashSale(ectx echo.Context) error
+	GetPublicFlashSales(ectx echo.Context) error
+	GetPublicFlashSaleByID(ectx echo.Context) error
+}
+
+type flashSaleHandler struct {
+	di  *pkgs.Di
+	rdp pkgs.RequestDataCtx
+	fs  services.FlashSaleService
+}
+
+func NewFlashSaleHandler(di *pkgs.Di) (FlashSaleHandler, error) {
+	ctxData, err := pkgs.Invoke[pkgs.RequestDataCtx](di)
+	if err != nil {
+		return nil, err
+	}
+
+	fs, err := pkgs.Invoke[services.FlashSaleService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &flashSaleHandler{
+		di:  di,
+		rdp: ctxData,
+		fs:  fs,
+	}, nil
+}
+
+func (f *flashSaleHandler) CreateFlashSale(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "flash_sale",
+		"method", "CreateFlashSale",
+	)
+
+	storeID := ectx.Param("storeId")
+	if storeID == "" {
+		logger.Warn("storeID is empty")
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	if _, err := uuid.Parse(storeID); err != nil {
+		logger.Warn("invalid storeID format", "storeID", storeID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	var payload models.CreateFlashSalePayload
+	if err := jsoniter.NewDecoder(ectx.Request().Body).Decode(&payload); err != nil {
+		logger.Error("error to bind payload", "error", err)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	userID, ok := f.rdp.GetUserID(ectx.Request().Context())
+	if !ok {
+		logger.Error("get user id from context")
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	resp, err := f.fs.CreateFlashSale(ectx.Request().Context(), userID, storeID, payload)
+	if err != nil {
+		if err == models.ErrStoreNotFound {
+			logger.Warn("store not found", "storeID", storeID)
+			return ectx.NoContent(http.StatusNotFound)
+		}
+
+		if err == models.ErrStoreNotPertenence || err == models.ErrStorePermissionDenied {
+			logger.Warn("store not pertenence to user", "storeID", storeID)
+			return ectx.NoContent(http.StatusForbidden)
+		}
+
+		if err == models.ErrInvalidFlashSale || err == models.ErrFlashSaleProductDuplicate {
+			logger.Warn("invalid flash sale", "error", err)
+			return ectx.NoContent(http.StatusBadRequest)
+		}
+
+		if err == models.ErrProductNotFound {
+			logger.Warn("product not found", "storeID", storeID)
+			return ectx.NoContent(http.StatusNotFound)
+		}
+
+		if err == models.ErrProductArchived {
+			logger.Warn("flash sale with archived product", "storeID", storeID)
+			return ectx.NoContent(http.StatusUnprocessableEntity)
+		}
+
+		logger.Error("create flash sale", "error", err)
+		return ectx.NoContent(http.StatusInternalServerError)
+	}
+
+	return ectx.JSON(http.StatusCreated, resp)
+}
+
+func (f *flashSaleHandler) GetFlashSales(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "flash_sale",
+		"method", "GetFlashSales",
+	)
+
+	storeID := ectx.Param("storeId")
+	if storeID == "" {
+		logger.Warn("storeID is empty")
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	if _, err := uuid.Parse(storeID); err != nil {
+		logger.Warn("invalid storeID format", "storeID", storeID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	userID, ok := f.rdp.GetUserID(ectx.Request().Context())
+	if !ok {
+		logger.Error("get user id from context")
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	pag := models.NewFlashSalePagination(
+		ectx.QueryParam("page"),
+		ectx.QueryParam("limit"),
+		utils.GetQueryStringPointer(ectx.QueryParam("name")),
+	)
+
+	resp, err := f.fs.GetFlashSalesPagedList(ectx.Request().Context(), userID, storeID, *pag)
+	if err != nil {
+		if err == models.ErrStoreNotFound {
+			logger.Warn("store not found", "storeID", storeID)
+			return ectx.NoContent(http.StatusNotFound)
+		}
+
+		if err == models.ErrStoreNotPertenence || err == models.ErrStorePermissionDenied {
+			logger.Warn("store not pertenence to user", "storeID", storeID)
+			return ectx.NoContent(http.StatusForbidden)
+		}
+
+		logger.Error("get flash sales", "error", err)
+		return ectx.NoContent(http.StatusInternalServerError)
+	}
+
+	return ectx.JSON(http.StatusOK, resp)
+}
+
+func (f *flashSaleHandler) GetFlashSaleByID(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "flash_sale",
+		"method", "GetFlashSaleByID",
+	)
+
+	storeID := ectx.Param("storeId")
+	if storeID == "" {
+		logger.Warn("storeID is empty")
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	if _, err := uuid.Parse(storeID); err != nil {
+		logger.Warn("invalid storeID format", "storeID", storeID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	flashSaleID := ectx.Param("flashSaleId")
+	if _, err := uuid.Parse(flashSaleID); err != nil {
+		logger.Warn("invalid flashSaleID format", "flashSaleID", flashSaleID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	userID, ok := f.rdp.GetUserID(ectx.Request().Context())
+	if !ok {
+		logger.Error("get user id from context")
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	resp, err := f.fs.GetFlashSaleByID(ectx.Request().Context(), userID, storeID, flashSaleID)
+	if err != nil {
+		if err == models.ErrStoreNotFound {
+			logger.Warn("store not found", "storeID", storeID)
+			return ectx.NoContent(http.StatusNotFound)
+		}
+
+		if err == models.ErrStoreNotPertenence || err == models.ErrStorePermissionDenied {
+			logger.Warn("store not pertenence to user", "storeID", storeID)
+			return ectx.NoContent(http.StatusForbidden)
+		}
+
+		if err == models.ErrFlashSaleNotFound {
+			logger.Warn("flash sale not found", "flashSaleID", flashSaleID)
+			return ectx.NoContent(http.StatusNotFound)
+		}
+
+		logger.Error("get flash sale by id", "error", err)
+		return ectx.NoContent(http.StatusInternalServerError)
+	}
+
+	return ectx.JSON(http.StatusOK, resp)
+}
+
+func (f *flashSaleHandler) DeleteFlashSale(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "flash_sale",
+		"method", "DeleteFlashSale",
+	)
+
+	storeID := ectx.Param("storeId")
+	if storeID == "" {
+		logger.Warn("storeID is empty")
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	if _, err := uuid.Parse(storeID); err != nil {
+		logger.Warn("invalid storeID format", "storeID", storeID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	flashSaleID := ectx.Param("flashSaleId")
+	if _, err := uuid.Parse(flashSaleID); err != nil {
+		logger.Warn("invalid flashSaleID format", "flashSaleID", flashSaleID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	userID, ok := f.rdp.GetUserID(ectx.Request().Context())
+	if !ok {
+		logger.Error("get user id from context")
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	if err := f.fs.DeleteFlashSale(ectx.Request().Context(), userID, storeID, flashSaleID); err != nil {
+		if err == models.ErrStoreNotFound {
+			logger.Warn("store not found", "storeID", storeID)
+			return ectx.NoContent(http.StatusNotFound)
+		}
+
+		if err == models.ErrStoreNotPertenence || err == models.ErrStorePermissionDenied {
+			logger.Warn("store not pertenence to user", "storeID", storeID)
+			return ectx.NoContent(http.StatusForbidden)
+		}
+
+		if err == models.ErrFlashSaleNotFound {
+			logger.Warn("flash sale not found", "flashSaleID", flashSaleID)
+			return ectx.NoContent(http.StatusNotFound)
+		}
+
+		if err == models.ErrFlashSaleAlreadyStarted {
+			logger.Warn("flash sale already started", "flashSaleID", flashSaleID)
+			return ectx.NoContent(http.StatusConflict)
+		}
+
+		logger.Error("delete flash sale", "error", err)
+		return ectx.NoContent(http.StatusInternalServerError)
+	}
+
+	return ectx.NoContent(http.StatusNoContent)
+}
+
+func (f *flashSaleHandler) GetPublicFlashSales(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "flash_sale",
+		"method", "GetPublicFlashSales",
+	)
+
+	storeID := ectx.Param("storeId")
+	if _, err := uuid.Parse(storeID); err != nil {
+		logger.Warn("invalid storeID format", "storeID", storeID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	resp, err := f.fs.GetPublicFlashSales(ectx.Request().Context(), storeID)
+	if err != nil {
+		logger.Error("get public flash sales", "error", err)
+		return ectx.NoContent(http.StatusInternalServerError)
+	}
+
+	return ectx.JSON(http.StatusOK, resp)
+}
+
+func (f *flashSaleHandler) GetPublicFlashSaleByID(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "flash_sale",
+		"method", "GetPublicFlashSaleByID",
+	)
+
+	storeID := ectx.Param("storeId")
+	if _, err := uuid.Parse(storeID); err != nil {
+		logger.Warn("invalid storeID format", "storeID", storeID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	flashSaleID := ectx.Param("flashSaleId")
+	if _, err := uuid.Parse(flashSaleID); err != nil {
+		logger.Warn("invalid flashSaleID format", "flashSaleID", flashSaleID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	resp, err := f.fs.GetPublicFlashSaleByID(ectx.Request().Context(), storeID, flashSaleID)
+	if err != nil {
+		if err == models.ErrFlashSaleNotFound {
+			logger.Warn("flash sale not found", "flashSaleID", flashSaleID)
+			return ectx.NoContent(http.StatusNotFound)
+		}
+
+		logger.Error("get public flash sale by id", "error", err)
+		return ectx.NoContent(http.StatusInternalServerError)
+	}
+
+	return ectx.JSON(http.StatusOK, resp)
+}
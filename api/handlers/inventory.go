@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/g-villarinho/flash-buy-api/models"
+	"github.com/g-villarinho/flash-buy-api/pkgs"
+	"github.com/g-villarinho/flash-buy-api/services"
+	"github.com/google/uuid"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/labstack/echo/v4"
+)
+
+type InventoryHandler interface {
+	AdjustStock(ectx echo.Context) error
+	GetStockAdjustments(ectx echo.Context) error
+}
+
+type inventoryHandler struct {
+	di  *pkgs.Di
+	rdp pkgs.RequestDataCtx
+	is  services.InventoryService
+}
+
+func NewInventoryHandler(di *pkgs.Di) (InventoryHandler, error) {
+	ctxData, err := pkgs.Invoke[pkgs.RequestDataCtx](di)
+	if err != nil {
+		return nil, err
+	}
+
+	is, err := pkgs.Invoke[services.InventoryService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &inventoryHandler{
+		di:  di,
+		rdp: ctxData,
+		is:  is,
+	}, nil
+}
+
+func (i *inventoryHandler) AdjustStock(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "inventory",
+		"method", "AdjustStock",
+	)
+
+	storeID := ectx.Param("storeId")
+	if storeID == "" {
+		logger.Warn("storeID is empty")
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	if _, err := uuid.Parse(storeID); err != nil {
+		logger.Warn("invalid storeID format", "storeID", storeID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	productID := ectx.Param("productId")
+	if _, err := uuid.Parse(productID); err != nil {
+		logger.Warn("invalid productID format", "productID", productID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	var payload models.AdjustStockPayload
+	if err := jsoniter.NewDecoder(ectx.Request().Body).Decode(&payload); err != nil {
+		logger.Error("error to bind payload", "error", err)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	userID, ok := i.rdp.GetUserID(ectx.Request().Context())
+	if !ok {
+		logger.Error("get user id from context")
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	resp, err := i.is.AdjustStock(ectx.Request().Context(), userID, storeID, productID, payload)
+	if err != nil {
+		if err == models.ErrInvalidStockAdjustment {
+			logger.Warn("invalid stock adjustment", "delta", payload.Delta)
+			return ectx.NoContent(http.StatusBadRequest)
+		}
+
+		if err == models.ErrStoreNotFound {
+			logger.Warn("store not found", "storeID", storeID)
+			return ectx.NoContent(http.StatusNotFound)
+		}
+
+		if err == models.ErrStoreNotPertenence || err == models.ErrStorePermissionDenied {
+			logger.Warn("store not pertenence to user", "storeID", storeID)
+			return ectx.NoContent(http.StatusForbidden)
+		}
+
+		if err == models.ErrProductNotFound {
+			logger.Warn("product not found", "productID", productID)
+			return ectx.NoContent(http.StatusNotFound)
+		}
+
+		if err == models.ErrInsufficientStock {
+			logger.Warn("insufficient stock", "productID", productID, "delta", payload.Delta)
+			return ectx.NoContent(http.StatusConflict)
+		}
+
+		if err == models.ErrProductVariantNotFound {
+			logger.Warn("product variant not found", "variantID", payload.VariantID)
+			return ectx.NoContent(http.StatusNotFound)
+		}
+
+		if err == models.ErrProductVariantRequired {
+			logger.Warn("product variant required", "productID", productID)
+			return ectx.NoContent(http.StatusBadRequest)
+		}
+
+		logger.Error("adjust stock", "error", err)
+		return ectx.NoContent(http.StatusInternalServerError)
+	}
+
+	return ectx.JSON(http.StatusCreated, resp)
+}
+
+func (i *inventoryHandler) GetStockAdjustments(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "inventory",
+		"method", "GetStockAdjustments",
+	)
+
+	storeID := ectx.Param("storeId")
+	if storeID == "" {
+		logger.Warn("storeID is empty")
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	if _, err := uuid.Parse(storeID); err != nil {
+		logger.Warn("invalid storeID format", "storeID", storeID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	productID := ectx.Param("productId")
+	if _, err := uuid.Parse(productID); err != nil {
+		logger.Warn("invalid productID format", "productID", productID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	userID, ok := i.rdp.GetUserID(ectx.Request().Context())
+	if !ok {
+		logger.Error("get user id from context")
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	pag := models.NewPagination(ectx.QueryParam("page"), ectx.QueryParam("limit"))
+
+	resp, err := i.is.GetStockAdjustments(ectx.Request().Context(), userID, storeID, productID, *pag)
+	if err != nil {
+		if err == models.ErrStoreNotFound {
+			logger.Warn("store not found", "storeID", storeID)
+			return ectx.NoContent(http.StatusNotFound)
+		}
+
+		if err == models.ErrStoreNotPertenence || err == models.ErrStorePermissionDenied {
+			logger.Warn("store not pertenence to user", "storeID", storeID)
+			return ectx.NoContent(http.StatusForbidden)
+		}
+
+		if err == models.ErrProductNotFound {
+			logger.Warn("product not found", "productID", productID)
+			return ectx.NoContent(http.StatusNotFound)
+		}
+
+		logger.Error("get stock adjustments", "error", err)
+		return ectx.NoContent(http.StatusInternalServerError)
+	}
+
+	return ectx.JSON(http.StatusOK, resp)
+}
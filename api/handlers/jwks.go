@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/g-villarinho/flash-buy-api/models"
+	"github.com/g-villarinho/flash-buy-api/pkgs"
+	"github.com/labstack/echo/v4"
+)
+
+type JWKSHandler interface {
+	GetJWKS(ectx echo.Context) error
+}
+
+type jwksHandler struct {
+	di *pkgs.Di
+	kr pkgs.KeyRing
+}
+
+func NewJWKSHandler(di *pkgs.Di) (JWKSHandler, error) {
+	kr, err := pkgs.Invoke[pkgs.KeyRing](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &jwksHandler{
+		di: di,
+		kr: kr,
+	}, nil
+}
+
+func (j *jwksHandler) GetJWKS(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "jwks",
+		"method", "GetJWKS",
+	)
+
+	keys := j.kr.PublicKeys()
+	resp := models.JWKSResponse{Keys: make([]models.JWK, 0, len(keys))}
+
+	for _, key := range keys {
+		x, y, err := pkgs.JWKCoordinates(key.PublicKey)
+		if err != nil {
+			logger.Error("encode public key", "kid", key.ID, "error", err)
+			return ectx.NoContent(http.StatusInternalServerError)
+		}
+
+		resp.Keys = append(resp.Keys, models.JWK{
+			Kty: "EC",
+			Crv: "P-256",
+			X:   x,
+			Y:   y,
+			Kid: key.ID,
+			Use: "sig",
+			Alg: "ES256",
+		})
+	}
+
+	ectx.Response().Header().Set("Cache-Control", "public, max-age=300")
+	return ectx.JSON(http.StatusOK, resp)
+}
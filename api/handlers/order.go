@@ -0,0 +1,313 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/g-villarinho/flash-buy-api/models"
+	"github.com/g-villarinho/flash-buy-api/pkgs"
+	"github.com/g-villarinho/flash-buy-api/services"
+	"github.com/g-villarinho/flash-buy-api/utils"
+	"github.com/google/uuid"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/labstack/echo/v4"
+)
+
+type OrderHandler interface {
+	Checkout(ectx echo.Context) error
+	GetOrders(ectx echo.Context) error
+	GetOrderByID(ectx echo.Context) error
+	UpdateOrderStatus(ectx echo.Context) error
+}
+
+type orderHandler struct {
+	di  *pkgs.Di
+	rdp pkgs.RequestDataCtx
+	cs  services.CheckoutService
+	os  services.OrderService
+}
+
+func NewOrderHandler(di *pkgs.Di) (OrderHandler, error) {
+	ctxData, err := pkgs.Invoke[pkgs.RequestDataCtx](di)
+	if err != nil {
+		return nil, err
+	}
+
+	cs, err := pkgs.Invoke[services.CheckoutService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	os, err := pkgs.Invoke[services.OrderService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &orderHandler{
+		di:  di,
+		rdp: ctxData,
+		cs:  cs,
+		os:  os,
+	}, nil
+}
+
+func (o *orderHandler) Checkout(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "order",
+		"method", "Checkout",
+	)
+
+	storeID := ectx.Param("storeId")
+	if _, err := uuid.Parse(storeID); err != nil {
+		logger.Warn("invalid storeID format", "storeID", storeID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	var payload models.CheckoutPayload
+	if err := jsoniter.NewDecoder(ectx.Request().Body).Decode(&payload); err != nil {
+		logger.Error("error to bind payload", "error", err)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	if payload.CustomerName == "" || payload.CustomerEmail == "" || payload.Phone == "" || payload.Address == "" {
+		logger.Warn("missing checkout fields")
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	customerID, _ := o.rdp.GetUserID(ectx.Request().Context())
+	identity := models.CartIdentity{
+		Token:      ectx.Request().Header.Get(CartTokenHeader),
+		CustomerID: customerID,
+	}
+
+	resp, err := o.cs.Checkout(ectx.Request().Context(), storeID, identity, payload)
+	if err != nil {
+		if err == models.ErrPaymentUnavailable {
+			logger.Warn("payments unavailable", "storeID", storeID)
+			return ectx.NoContent(http.StatusServiceUnavailable)
+		}
+
+		if err == models.ErrCartNotFound {
+			logger.Warn("cart not found", "storeID", storeID)
+			return ectx.NoContent(http.StatusNotFound)
+		}
+
+		if err == models.ErrCartEmpty {
+			logger.Warn("cart empty", "storeID", storeID)
+			return ectx.NoContent(http.StatusUnprocessableEntity)
+		}
+
+		if err == models.ErrProductArchived {
+			logger.Warn("cart has archived products", "storeID", storeID)
+			return ectx.NoContent(http.StatusUnprocessableEntity)
+		}
+
+		if err == models.ErrInsufficientStock {
+			logger.Warn("insufficient stock", "storeID", storeID)
+			return ectx.NoContent(http.StatusConflict)
+		}
+
+		if err == models.ErrFlashSaleUnavailable {
+			logger.Warn("flash sale sold out or ended", "storeID", storeID)
+			return ectx.NoContent(http.StatusConflict)
+		}
+
+		if err == models.ErrFlashSaleCustomerLimit {
+			logger.Warn("flash sale customer limit exceeded", "storeID", storeID)
+			return ectx.NoContent(http.StatusUnprocessableEntity)
+		}
+
+		if err == models.ErrFlashSaleLoginRequired {
+			logger.Warn("flash sale requires authentication", "storeID", storeID)
+			return ectx.NoContent(http.StatusUnauthorized)
+		}
+
+		logger.Error("checkout", "error", err)
+		return ectx.NoContent(http.StatusInternalServerError)
+	}
+
+	return ectx.JSON(http.StatusCreated, resp)
+}
+
+func (o *orderHandler) GetOrders(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "order",
+		"method", "GetOrders",
+	)
+
+	storeID := ectx.Param("storeId")
+	if storeID == "" {
+		logger.Warn("storeID is empty")
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	if _, err := uuid.Parse(storeID); err != nil {
+		logger.Warn("invalid storeID format", "storeID", storeID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	userID, ok := o.rdp.GetUserID(ectx.Request().Context())
+	if !ok {
+		logger.Error("get user id from context")
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	pag := models.NewOrderPagination(
+		ectx.QueryParam("page"),
+		ectx.QueryParam("limit"),
+		utils.GetQueryStringPointer(ectx.QueryParam("status")),
+		utils.GetQueryStringPointer(ectx.QueryParam("customerEmail")),
+	)
+
+	resp, err := o.os.GetOrdersPagedList(ectx.Request().Context(), userID, storeID, *pag)
+	if err != nil {
+		if err == models.ErrStoreNotFound {
+			logger.Warn("store not found", "storeID", storeID)
+			return ectx.NoContent(http.StatusNotFound)
+		}
+
+		if err == models.ErrStoreNotPertenence || err == models.ErrStorePermissionDenied {
+			logger.Warn("store not pertenence to user", "storeID", storeID)
+			return ectx.NoContent(http.StatusForbidden)
+		}
+
+		if err == models.ErrInvalidOrderStatus {
+			logger.Warn("invalid order status filter", "status", ectx.QueryParam("status"))
+			return ectx.NoContent(http.StatusBadRequest)
+		}
+
+		logger.Error("get orders", "error", err)
+		return ectx.NoContent(http.StatusInternalServerError)
+	}
+
+	return ectx.JSON(http.StatusOK, resp)
+}
+
+func (o *orderHandler) GetOrderByID(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "order",
+		"method", "GetOrderByID",
+	)
+
+	storeID := ectx.Param("storeId")
+	if storeID == "" {
+		logger.Warn("storeID is empty")
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	if _, err := uuid.Parse(storeID); err != nil {
+		logger.Warn("invalid storeID format", "storeID", storeID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	orderID := ectx.Param("orderId")
+	if _, err := uuid.Parse(orderID); err != nil {
+		logger.Warn("invalid orderID format", "orderID", orderID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	userID, ok := o.rdp.GetUserID(ectx.Request().Context())
+	if !ok {
+		logger.Error("get user id from context")
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	resp, err := o.os.GetOrderByID(ectx.Request().Context(), userID, storeID, orderID)
+	if err != nil {
+		if err == models.ErrStoreNotFound {
+			logger.Warn("store not found", "storeID", storeID)
+			return ectx.NoContent(http.StatusNotFound)
+		}
+
+		if err == models.ErrStoreNotPertenence || err == models.ErrStorePermissionDenied {
+			logger.Warn("store not pertenence to user", "storeID", storeID)
+			return ectx.NoContent(http.StatusForbidden)
+		}
+
+		if err == models.ErrOrderNotFound {
+			logger.Warn("order not found", "orderID", orderID)
+			return ectx.NoContent(http.StatusNotFound)
+		}
+
+		logger.Error("get order by id", "error", err)
+		return ectx.NoContent(http.StatusInternalServerError)
+	}
+
+	return ectx.JSON(http.StatusOK, resp)
+}
+
+func (o *orderHandler) UpdateOrderStatus(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "order",
+		"method", "UpdateOrderStatus",
+	)
+
+	storeID := ectx.Param("storeId")
+	if storeID == "" {
+		logger.Warn("storeID is empty")
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	if _, err := uuid.Parse(storeID); err != nil {
+		logger.Warn("invalid storeID format", "storeID", storeID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	orderID := ectx.Param("orderId")
+	if _, err := uuid.Parse(orderID); err != nil {
+		logger.Warn("invalid orderID format", "orderID", orderID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	var payload models.UpdateOrderStatusPayload
+	if err := jsoniter.NewDecoder(ectx.Request().Body).Decode(&payload); err != nil {
+		logger.Error("error to bind payload", "error", err)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	userID, ok := o.rdp.GetUserID(ectx.Request().Context())
+	if !ok {
+		logger.Error("get user id from context")
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	if err := o.os.UpdateOrderStatus(ectx.Request().Context(), userID, storeID, orderID, payload.Status); err != nil {
+		if err == models.ErrStoreNotFound {
+			logger.Warn("store not found", "storeID", storeID)
+			return ectx.NoContent(http.StatusNotFound)
+		}
+
+		if err == models.ErrStoreNotPertenence || err == models.ErrStorePermissionDenied {
+			logger.Warn("store not pertenence to user", "storeID", storeID)
+			return ectx.NoContent(http.StatusForbidden)
+		}
+
+		if err == models.ErrOrderNotFound {
+			logger.Warn("order not found", "orderID", orderID)
+			return ectx.NoContent(http.StatusNotFound)
+		}
+
+		if err == models.ErrInvalidOrderStatus {
+			logger.Warn("invalid order status", "status", payload.Status)
+			return ectx.NoContent(http.StatusBadRequest)
+		}
+
+		if err == models.ErrInvalidStatusTransition {
+			logger.Warn("invalid order status transition", "orderID", orderID, "status", payload.Status)
+			return ectx.NoContent(http.StatusConflict)
+		}
+
+		if err == models.ErrStatusManagedByPayment {
+			logger.Warn("order status managed by payment provider", "orderID", orderID, "status", payload.Status)
+			return ectx.NoContent(http.StatusUnprocessableEntity)
+		}
+
+		logger.Error("update order status", "error", err)
+		return ectx.NoContent(http.StatusInternalServerError)
+	}
+
+	return ectx.NoContent(http.StatusOK)
+}
@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/g-villarinho/flash-buy-api/clients"
+	"github.com/g-villarinho/flash-buy-api/models"
+	"github.com/g-villarinho/flash-buy-api/pkgs"
+	"github.com/g-villarinho/flash-buy-api/services"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+const PaymentSignatureHeader = "X-Payment-Signature"
+
+type PaymentHandler interface {
+	HandleWebhook(ectx echo.Context) error
+	CapturePayment(ectx echo.Context) error
+	RefundPayment(ectx echo.Context) error
+}
+
+type paymentHandler struct {
+	di  *pkgs.Di
+	rdp pkgs.RequestDataCtx
+	ps  services.PaymentService
+}
+
+func NewPaymentHandler(di *pkgs.Di) (PaymentHandler, error) {
+	ctxData, err := pkgs.Invoke[pkgs.RequestDataCtx](di)
+	if err != nil {
+		return nil, err
+	}
+
+	ps, err := pkgs.Invoke[services.PaymentService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &paymentHandler{
+		di:  di,
+		rdp: ctxData,
+		ps:  ps,
+	}, nil
+}
+
+func (p *paymentHandler) HandleWebhook(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "payment",
+		"method", "HandleWebhook",
+	)
+
+	payload, err := io.ReadAll(ectx.Request().Body)
+	if err != nil {
+		logger.Error("read webhook body", "error", err)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	signature := ectx.Request().Header.Get(PaymentSignatureHeader)
+	if signature == "" {
+		logger.Warn("missing webhook signature")
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	if err := p.ps.HandleWebhook(ectx.Request().Context(), payload, signature); err != nil {
+		if err == models.ErrPaymentUnavailable {
+			logger.Warn("payments unavailable")
+			return ectx.NoContent(http.StatusServiceUnavailable)
+		}
+
+		if err == clients.ErrInvalidWebhookSignature {
+			logger.Warn("invalid webhook signature")
+			return ectx.NoContent(http.StatusBadRequest)
+		}
+
+		if err == models.ErrOrderNotFound {
+			logger.Warn("order not found for payment")
+			return ectx.NoContent(http.StatusNotFound)
+		}
+
+		if err == models.ErrPaymentAmountMismatch {
+			logger.Warn("payment amount mismatch")
+			return ectx.NoContent(http.StatusUnprocessableEntity)
+		}
+
+		if err == models.ErrInvalidStatusTransition {
+			logger.Warn("invalid order status transition from webhook")
+			return ectx.NoContent(http.StatusConflict)
+		}
+
+		logger.Error("handle payment webhook", "error", err)
+		return ectx.NoContent(http.StatusInternalServerError)
+	}
+
+	return ectx.NoContent(http.StatusOK)
+}
+
+func (p *paymentHandler) CapturePayment(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "payment",
+		"method", "CapturePayment",
+	)
+
+	return p.handleOrderPayment(ectx, logger, p.ps.CapturePayment)
+}
+
+func (p *paymentHandler) RefundPayment(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "payment",
+		"method", "RefundPayment",
+	)
+
+	return p.handleOrderPayment(ectx, logger, p.ps.RefundPayment)
+}
+
+func (p *paymentHandler) handleOrderPayment(
+	ectx echo.Context,
+	logger *slog.Logger,
+	action func(ctx context.Context, userID, storeID, orderID string) error,
+) error {
+	storeID := ectx.Param("storeId")
+	if storeID == "" {
+		logger.Warn("storeID is empty")
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	if _, err := uuid.Parse(storeID); err != nil {
+		logger.Warn("invalid storeID format", "storeID", storeID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	orderID := ectx.Param("orderId")
+	if _, err := uuid.Parse(orderID); err != nil {
+		logger.Warn("invalid orderID format", "orderID", orderID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	userID, ok := p.rdp.GetUserID(ectx.Request().Context())
+	if !ok {
+		logger.Error("get user id from context")
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	if err := action(ectx.Request().Context(), userID, storeID, orderID); err != nil {
+		if err == models.ErrPaymentUnavailable {
+			logger.Warn("payments unavailable", "orderID", orderID)
+			return ectx.NoContent(http.StatusServiceUnavailable)
+		}
+
+		if err == models.ErrStoreNotFound {
+			logger.Warn("store not found", "storeID", storeID)
+			return ectx.NoContent(http.StatusNotFound)
+		}
+
+		if err == models.ErrStoreNotPertenence || err == models.ErrStorePermissionDenied {
+			logger.Warn("store not pertenence to user", "storeID", storeID)
+			return ectx.NoContent(http.StatusForbidden)
+		}
+
+		if err == models.ErrOrderNotFound {
+			logger.Warn("order not found", "orderID", orderID)
+			return ectx.NoContent(http.StatusNotFound)
+		}
+
+		if err == models.ErrOrderWithoutPayment {
+			logger.Warn("order without payment", "orderID", orderID)
+			return ectx.NoContent(http.StatusUnprocessableEntity)
+		}
+
+		if err == models.ErrInvalidStatusTransition {
+			logger.Warn("invalid order status for payment operation", "orderID", orderID)
+			return ectx.NoContent(http.StatusConflict)
+		}
+
+		logger.Error("payment operation", "error", err)
+		return ectx.NoContent(http.StatusInternalServerError)
+	}
+
+	return ectx.NoContent(http.StatusAccepted)
+}
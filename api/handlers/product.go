@@ -7,13 +7,20 @@ import (
 	"github.com/g-villarinho/flash-buy-api/models"
 	"github.com/g-villarinho/flash-buy-api/pkgs"
 	"github.com/g-villarinho/flash-buy-api/services"
+	"github.com/g-villarinho/flash-buy-api/utils"
 	"github.com/go-playground/form/v4"
 	"github.com/google/uuid"
+	jsoniter "github.com/json-iterator/go"
 	"github.com/labstack/echo/v4"
 )
 
 type ProductHandler interface {
 	CreateProduct(ectx echo.Context) error
+	GetProducts(ectx echo.Context) error
+	GetProductByID(ectx echo.Context) error
+	UpdateProduct(ectx echo.Context) error
+	ArchiveProduct(ectx echo.Context) error
+	DeleteProduct(ectx echo.Context) error
 }
 
 type productHandler struct {
@@ -95,7 +102,7 @@ func (p *productHandler) CreateProduct(ectx echo.Context) error {
 			return ectx.NoContent(http.StatusNotFound)
 		}
 
-		if err == models.ErrStoreNotPertenence {
+		if err == models.ErrStoreNotPertenence || err == models.ErrStorePermissionDenied {
 			logger.Warn("store not pertenence to user", "storeID", storeID)
 			return ectx.NoContent(http.StatusForbidden)
 		}
@@ -115,9 +122,354 @@ func (p *productHandler) CreateProduct(ectx echo.Context) error {
 			return ectx.NoContent(http.StatusNotFound)
 		}
 
+		if err == models.ErrSKUAlreadyExists {
+			logger.Warn("sku already exists", "storeID", storeID)
+			return ectx.NoContent(http.StatusConflict)
+		}
+
+		if err == models.ErrInvalidImage {
+			logger.Warn("invalid image")
+			return ectx.NoContent(http.StatusBadRequest)
+		}
+
+		if err == models.ErrImageTooLarge {
+			logger.Warn("image too large")
+			return ectx.NoContent(http.StatusRequestEntityTooLarge)
+		}
+
+		if err == models.ErrUnsupportedImageType {
+			logger.Warn("unsupported image type")
+			return ectx.NoContent(http.StatusUnsupportedMediaType)
+		}
+
 		logger.Error("failed to create product", "error", err)
 		return ectx.NoContent(http.StatusInternalServerError)
 	}
 
 	return ectx.NoContent(http.StatusOK)
 }
+
+func (p *productHandler) GetProducts(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "product",
+		"method", "GetProducts",
+	)
+
+	storeID := ectx.Param("storeId")
+	if storeID == "" {
+		logger.Warn("storeID is empty")
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	if _, err := uuid.Parse(storeID); err != nil {
+		logger.Warn("invalid storeID format", "storeID", storeID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	pag := models.NewProductPagination(
+		ectx.QueryParam("page"),
+		ectx.QueryParam("limit"),
+		utils.GetQueryStringPointer(ectx.QueryParam("name")),
+		utils.GetQueryStringPointer(ectx.QueryParam("categoryId")),
+		utils.GetQueryStringPointer(ectx.QueryParam("colorId")),
+		utils.GetQueryStringPointer(ectx.QueryParam("sizeId")),
+		utils.GetQueryStringPointer(ectx.QueryParam("isFeatured")),
+		utils.GetQueryStringPointer(ectx.QueryParam("isArchived")),
+		utils.GetQueryStringPointer(ectx.QueryParam("includeSubcategories")),
+	)
+
+	userID, ok := p.rdp.GetUserID(ectx.Request().Context())
+	if !ok {
+		logger.Error("get user id from context")
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	resp, err := p.ps.GetProductsPagedList(ectx.Request().Context(), userID, storeID, *pag)
+	if err != nil {
+		if err == models.ErrStoreNotFound {
+			logger.Warn("store not found", "storeID", storeID)
+			return ectx.NoContent(http.StatusNotFound)
+		}
+
+		if err == models.ErrStoreNotPertenence || err == models.ErrStorePermissionDenied {
+			logger.Warn("store not pertenence to user", "storeID", storeID)
+			return ectx.NoContent(http.StatusForbidden)
+		}
+
+		logger.Error("failed to fetch products", "error", err)
+		return ectx.NoContent(http.StatusInternalServerError)
+	}
+
+	return ectx.JSON(http.StatusOK, resp)
+}
+
+func (p *productHandler) GetProductByID(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "product",
+		"method", "GetProductByID",
+	)
+
+	storeID := ectx.Param("storeId")
+	if storeID == "" {
+		logger.Warn("storeID is empty")
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	if _, err := uuid.Parse(storeID); err != nil {
+		logger.Warn("invalid storeID format", "storeID", storeID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	productID := ectx.Param("productId")
+	if productID == "" {
+		logger.Warn("productID is empty")
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	if _, err := uuid.Parse(productID); err != nil {
+		logger.Warn("invalid productID format", "productID", productID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	userID, ok := p.rdp.GetUserID(ectx.Request().Context())
+	if !ok {
+		logger.Error("get user id from context")
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	resp, err := p.ps.GetProductByID(ectx.Request().Context(), userID, storeID, productID)
+	if err != nil {
+		if err == models.ErrStoreNotFound {
+			logger.Warn("store not found", "storeID", storeID)
+			return ectx.NoContent(http.StatusNotFound)
+		}
+
+		if err == models.ErrStoreNotPertenence || err == models.ErrStorePermissionDenied {
+			logger.Warn("store not pertenence to user", "storeID", storeID)
+			return ectx.NoContent(http.StatusForbidden)
+		}
+
+		if err == models.ErrProductNotFound {
+			logger.Warn("product not found", "productID", productID)
+			return ectx.NoContent(http.StatusNotFound)
+		}
+
+		logger.Error("get product by id", "error", err)
+		return ectx.NoContent(http.StatusInternalServerError)
+	}
+
+	return ectx.JSON(http.StatusOK, resp)
+}
+
+func (p *productHandler) UpdateProduct(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "product",
+		"method", "UpdateProduct",
+	)
+
+	storeID := ectx.Param("storeId")
+	if storeID == "" {
+		logger.Warn("storeID is empty")
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	if _, err := uuid.Parse(storeID); err != nil {
+		logger.Warn("invalid storeID format", "storeID", storeID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	productID := ectx.Param("productId")
+	if productID == "" {
+		logger.Warn("productID is empty")
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	if _, err := uuid.Parse(productID); err != nil {
+		logger.Warn("invalid productID format", "productID", productID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	var payload models.UpdateProductPayload
+	if err := jsoniter.NewDecoder(ectx.Request().Body).Decode(&payload); err != nil {
+		logger.Error("error to bind payload", "error", err)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	userID, ok := p.rdp.GetUserID(ectx.Request().Context())
+	if !ok {
+		logger.Error("get user id from context")
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	if err := p.ps.UpdateProduct(ectx.Request().Context(), userID, storeID, productID, payload); err != nil {
+		if err == models.ErrInvalidProductReference {
+			logger.Warn("invalid product reference", "categoryID", payload.CategoryID, "colorID", payload.ColorID, "sizeID", payload.SizeID)
+			return ectx.NoContent(http.StatusBadRequest)
+		}
+
+		if err == models.ErrProductOptionsOnVariants {
+			logger.Warn("color or size on product with variants", "productID", productID)
+			return ectx.NoContent(http.StatusBadRequest)
+		}
+
+		if err == models.ErrStoreNotFound {
+			logger.Warn("store not found", "storeID", storeID)
+			return ectx.NoContent(http.StatusNotFound)
+		}
+
+		if err == models.ErrStoreNotPertenence || err == models.ErrStorePermissionDenied {
+			logger.Warn("store not pertenence to user", "storeID", storeID)
+			return ectx.NoContent(http.StatusForbidden)
+		}
+
+		if err == models.ErrProductNotFound {
+			logger.Warn("product not found", "productID", productID)
+			return ectx.NoContent(http.StatusNotFound)
+		}
+
+		if err == models.ErrCategoryNotFound {
+			logger.Warn("category not found", "categoryID", payload.CategoryID)
+			return ectx.NoContent(http.StatusNotFound)
+		}
+
+		if err == models.ErrSizeNotFound {
+			logger.Warn("size not found", "sizeID", payload.SizeID)
+			return ectx.NoContent(http.StatusNotFound)
+		}
+
+		if err == models.ErrColorNotFound {
+			logger.Warn("color not found", "colorID", payload.ColorID)
+			return ectx.NoContent(http.StatusNotFound)
+		}
+
+		logger.Error("failed to update product", "error", err)
+		return ectx.NoContent(http.StatusInternalServerError)
+	}
+
+	return ectx.NoContent(http.StatusOK)
+}
+
+func (p *productHandler) ArchiveProduct(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "product",
+		"method", "ArchiveProduct",
+	)
+
+	storeID := ectx.Param("storeId")
+	if storeID == "" {
+		logger.Warn("storeID is empty")
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	if _, err := uuid.Parse(storeID); err != nil {
+		logger.Warn("invalid storeID format", "storeID", storeID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	productID := ectx.Param("productId")
+	if productID == "" {
+		logger.Warn("productID is empty")
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	if _, err := uuid.Parse(productID); err != nil {
+		logger.Warn("invalid productID format", "productID", productID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	var payload models.ArchiveProductPayload
+	if err := jsoniter.NewDecoder(ectx.Request().Body).Decode(&payload); err != nil {
+		logger.Error("error to bind payload", "error", err)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	userID, ok := p.rdp.GetUserID(ectx.Request().Context())
+	if !ok {
+		logger.Error("get user id from context")
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	if err := p.ps.ArchiveProduct(ectx.Request().Context(), userID, storeID, productID, payload.IsArchived); err != nil {
+		if err == models.ErrStoreNotFound {
+			logger.Warn("store not found", "storeID", storeID)
+			return ectx.NoContent(http.StatusNotFound)
+		}
+
+		if err == models.ErrStoreNotPertenence || err == models.ErrStorePermissionDenied {
+			logger.Warn("store not pertenence to user", "storeID", storeID)
+			return ectx.NoContent(http.StatusForbidden)
+		}
+
+		if err == models.ErrProductNotFound {
+			logger.Warn("product not found", "productID", productID)
+			return ectx.NoContent(http.StatusNotFound)
+		}
+
+		logger.Error("failed to archive product", "error", err)
+		return ectx.NoContent(http.StatusInternalServerError)
+	}
+
+	return ectx.NoContent(http.StatusOK)
+}
+
+func (p *productHandler) DeleteProduct(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "product",
+		"method", "DeleteProduct",
+	)
+
+	storeID := ectx.Param("storeId")
+	if storeID == "" {
+		logger.Warn("storeID is empty")
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	if _, err := uuid.Parse(storeID); err != nil {
+		logger.Warn("invalid storeID format", "storeID", storeID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	productID := ectx.Param("productId")
+	if productID == "" {
+		logger.Warn("productID is empty")
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	if _, err := uuid.Parse(productID); err != nil {
+		logger.Warn("invalid productID format", "productID", productID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	userID, ok := p.rdp.GetUserID(ectx.Request().Context())
+	if !ok {
+		logger.Error("get user id from context")
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	if err := p.ps.DeleteProduct(ectx.Request().Context(), userID, storeID, productID); err != nil {
+		if err == models.ErrStoreNotFound {
+			logger.Warn("store not found", "storeID", storeID)
+			return ectx.NoContent(http.StatusNotFound)
+		}
+
+		if err == models.ErrStoreNotPertenence || err == models.ErrStorePermissionDenied {
+			logger.Warn("store not pertenence to user", "storeID", storeID)
+			return ectx.NoContent(http.StatusForbidden)
+		}
+
+		if err == models.ErrProductNotFound {
+			logger.Warn("product not found", "productID", productID)
+			return ectx.NoContent(http.StatusNotFound)
+		}
+
+		logger.Error("failed to delete product", "error", err)
+		return ectx.NoContent(http.StatusInternalServerError)
+	}
+
+	return ectx.NoContent(http.StatusNoContent)
+}
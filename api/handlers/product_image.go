@@ -0,0 +1,288 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/g-villarinho/flash-buy-api/models"
+	"github.com/g-villarinho/flash-buy-api/pkgs"
+	"github.com/g-villarinho/flash-buy-api/services"
+	"github.com/google/uuid"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/labstack/echo/v4"
+)
+
+type ProductImageHandler interface {
+	AddProductImages(ectx echo.Context) error
+	GetProductImages(ectx echo.Context) error
+	ReorderProductImages(ectx echo.Context) error
+	SetPrimaryProductImage(ectx echo.Context) error
+	RetryProductImage(ectx echo.Context) error
+	DeleteProductImage(ectx echo.Context) error
+}
+
+type productImageHandler struct {
+	di  *pkgs.Di
+	rdp pkgs.RequestDataCtx
+	pis services.ProductImageService
+}
+
+func NewProductImageHandler(di *pkgs.Di) (ProductImageHandler, error) {
+	ctxData, err := pkgs.Invoke[pkgs.RequestDataCtx](di)
+	if err != nil {
+		return nil, err
+	}
+
+	pis, err := pkgs.Invoke[services.ProductImageService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &productImageHandler{
+		di:  di,
+		rdp: ctxData,
+		pis: pis,
+	}, nil
+}
+
+func (p *productImageHandler) AddProductImages(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "product_image",
+		"method", "AddProductImages",
+	)
+
+	storeID, productID, ok := p.getProductParams(ectx, logger)
+	if !ok {
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	form, err := ectx.MultipartForm()
+	if err != nil {
+		logger.Warn("failed to parse multipart form", "error", err)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	images := form.File["images"]
+	if len(images) == 0 {
+		logger.Warn("no images uploaded")
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	userID, ok := p.rdp.GetUserID(ectx.Request().Context())
+	if !ok {
+		logger.Error("get user id from context")
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	resp, err := p.pis.AddProductImages(ectx.Request().Context(), userID, storeID, productID, images)
+	if err != nil {
+		return p.handleProductImageError(ectx, logger, err)
+	}
+
+	return ectx.JSON(http.StatusAccepted, resp)
+}
+
+func (p *productImageHandler) GetProductImages(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "product_image",
+		"method", "GetProductImages",
+	)
+
+	storeID, productID, ok := p.getProductParams(ectx, logger)
+	if !ok {
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	userID, ok := p.rdp.GetUserID(ectx.Request().Context())
+	if !ok {
+		logger.Error("get user id from context")
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	resp, err := p.pis.GetProductImages(ectx.Request().Context(), userID, storeID, productID)
+	if err != nil {
+		return p.handleProductImageError(ectx, logger, err)
+	}
+
+	return ectx.JSON(http.StatusOK, resp)
+}
+
+func (p *productImageHandler) ReorderProductImages(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "product_image",
+		"method", "ReorderProductImages",
+	)
+
+	storeID, productID, ok := p.getProductParams(ectx, logger)
+	if !ok {
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	var payload models.ReorderProductImagesPayload
+	if err := jsoniter.NewDecoder(ectx.Request().Body).Decode(&payload); err != nil {
+		logger.Error("error to bind payload", "error", err)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	userID, ok := p.rdp.GetUserID(ectx.Request().Context())
+	if !ok {
+		logger.Error("get user id from context")
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	resp, err := p.pis.ReorderProductImages(ectx.Request().Context(), userID, storeID, productID, payload)
+	if err != nil {
+		return p.handleProductImageError(ectx, logger, err)
+	}
+
+	return ectx.JSON(http.StatusOK, resp)
+}
+
+func (p *productImageHandler) SetPrimaryProductImage(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "product_image",
+		"method", "SetPrimaryProductImage",
+	)
+
+	storeID, productID, imageID, ok := p.getProductImageParams(ectx, logger)
+	if !ok {
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	userID, ok := p.rdp.GetUserID(ectx.Request().Context())
+	if !ok {
+		logger.Error("get user id from context")
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	if err := p.pis.SetPrimaryProductImage(ectx.Request().Context(), userID, storeID, productID, imageID); err != nil {
+		return p.handleProductImageError(ectx, logger, err)
+	}
+
+	return ectx.NoContent(http.StatusOK)
+}
+
+func (p *productImageHandler) RetryProductImage(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "product_image",
+		"method", "RetryProductImage",
+	)
+
+	storeID, productID, imageID, ok := p.getProductImageParams(ectx, logger)
+	if !ok {
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	userID, ok := p.rdp.GetUserID(ectx.Request().Context())
+	if !ok {
+		logger.Error("get user id from context")
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	if err := p.pis.RetryProductImage(ectx.Request().Context(), userID, storeID, productID, imageID); err != nil {
+		return p.handleProductImageError(ectx, logger, err)
+	}
+
+	return ectx.NoContent(http.StatusAccepted)
+}
+
+func (p *productImageHandler) DeleteProductImage(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "product_image",
+		"method", "DeleteProductImage",
+	)
+
+	storeID, productID, imageID, ok := p.getProductImageParams(ectx, logger)
+	if !ok {
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	userID, ok := p.rdp.GetUserID(ectx.Request().Context())
+	if !ok {
+		logger.Error("get user id from context")
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	if err := p.pis.DeleteProductImage(ectx.Request().Context(), userID, storeID, productID, imageID); err != nil {
+		return p.handleProductImageError(ectx, logger, err)
+	}
+
+	return ectx.NoContent(http.StatusNoContent)
+}
+
+func (p *productImageHandler) getProductParams(ectx echo.Context, logger *slog.Logger) (string, string, bool) {
+	storeID := ectx.Param("storeId")
+	if _, err := uuid.Parse(storeID); err != nil {
+		logger.Warn("invalid storeID format", "storeID", storeID)
+		return "", "", false
+	}
+
+	productID := ectx.Param("productId")
+	if _, err := uuid.Parse(productID); err != nil {
+		logger.Warn("invalid productID format", "productID", productID)
+		return "", "", false
+	}
+
+	return storeID, productID, true
+}
+
+func (p *productImageHandler) getProductImageParams(ectx echo.Context, logger *slog.Logger) (string, string, string, bool) {
+	storeID, productID, ok := p.getProductParams(ectx, logger)
+	if !ok {
+		return "", "", "", false
+	}
+
+	imageID := ectx.Param("imageId")
+	if _, err := uuid.Parse(imageID); err != nil {
+		logger.Warn("invalid imageID format", "imageID", imageID)
+		return "", "", "", false
+	}
+
+	return storeID, productID, imageID, true
+}
+
+func (p *productImageHandler) handleProductImageError(ectx echo.Context, logger *slog.Logger, err error) error {
+	if err == models.ErrStoreNotFound {
+		logger.Warn("store not found", "error", err)
+		return ectx.NoContent(http.StatusNotFound)
+	}
+
+	if err == models.ErrStoreNotPertenence || err == models.ErrStorePermissionDenied {
+		logger.Warn("store not pertenence to user", "error", err)
+		return ectx.NoContent(http.StatusForbidden)
+	}
+
+	if err == models.ErrProductNotFound || err == models.ErrProductImageNotFound {
+		logger.Warn("product image not found", "error", err)
+		return ectx.NoContent(http.StatusNotFound)
+	}
+
+	if err == models.ErrInvalidProductImageOrder || err == models.ErrInvalidImage {
+		logger.Warn("invalid product image", "error", err)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	if err == models.ErrImageTooLarge {
+		logger.Warn("image too large", "error", err)
+		return ectx.NoContent(http.StatusRequestEntityTooLarge)
+	}
+
+	if err == models.ErrUnsupportedImageType {
+		logger.Warn("unsupported image type", "error", err)
+		return ectx.NoContent(http.StatusUnsupportedMediaType)
+	}
+
+	if err == models.ErrProductImageNotFailed || err == models.ErrProductImageNotRetryable {
+		logger.Warn("product image can not be retried", "error", err)
+		return ectx.NoContent(http.StatusConflict)
+	}
+
+	logger.Error("product image operation", "error", err)
+	return ectx.NoContent(http.StatusInternalServerError)
+}
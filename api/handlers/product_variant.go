@@ -0,0 +1,231 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/g-villarinho/flash-buy-api/models"
+	"github.com/g-villarinho/flash-buy-api/pkgs"
+	"github.com/g-villarinho/flash-buy-api/services"
+	"github.com/google/uuid"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/labstack/echo/v4"
+)
+
+type ProductVariantHandler interface {
+	CreateProductVariants(ectx echo.Context) error
+	GetProductVariants(ectx echo.Context) error
+	UpdateProductVariant(ectx echo.Context) error
+	DeleteProductVariant(ectx echo.Context) error
+}
+
+type productVariantHandler struct {
+	di  *pkgs.Di
+	rdp pkgs.RequestDataCtx
+	pvs services.ProductVariantService
+}
+
+func NewProductVariantHandler(di *pkgs.Di) (ProductVariantHandler, error) {
+	ctxData, err := pkgs.Invoke[pkgs.RequestDataCtx](di)
+	if err != nil {
+		return nil, err
+	}
+
+	pvs, err := pkgs.Invoke[services.ProductVariantService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &productVariantHandler{
+		di:  di,
+		rdp: ctxData,
+		pvs: pvs,
+	}, nil
+}
+
+func (p *productVariantHandler) CreateProductVariants(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "product_variant",
+		"method", "CreateProductVariants",
+	)
+
+	storeID, productID, ok := p.getProductParams(ectx, logger)
+	if !ok {
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	var payload models.ProductVariantMatrixPayload
+	if err := jsoniter.NewDecoder(ectx.Request().Body).Decode(&payload); err != nil {
+		logger.Error("error to bind payload", "error", err)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	userID, ok := p.rdp.GetUserID(ectx.Request().Context())
+	if !ok {
+		logger.Error("get user id from context")
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	resp, err := p.pvs.CreateProductVariants(ectx.Request().Context(), userID, storeID, productID, payload)
+	if err != nil {
+		return p.handleProductVariantError(ectx, logger, err)
+	}
+
+	return ectx.JSON(http.StatusCreated, resp)
+}
+
+func (p *productVariantHandler) GetProductVariants(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "product_variant",
+		"method", "GetProductVariants",
+	)
+
+	storeID, productID, ok := p.getProductParams(ectx, logger)
+	if !ok {
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	userID, ok := p.rdp.GetUserID(ectx.Request().Context())
+	if !ok {
+		logger.Error("get user id from context")
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	resp, err := p.pvs.GetProductVariants(ectx.Request().Context(), userID, storeID, productID)
+	if err != nil {
+		return p.handleProductVariantError(ectx, logger, err)
+	}
+
+	return ectx.JSON(http.StatusOK, resp)
+}
+
+func (p *productVariantHandler) UpdateProductVariant(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "product_variant",
+		"method", "UpdateProductVariant",
+	)
+
+	storeID, productID, ok := p.getProductParams(ectx, logger)
+	if !ok {
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	variantID := ectx.Param("variantId")
+	if _, err := uuid.Parse(variantID); err != nil {
+		logger.Warn("invalid variantID format", "variantID", variantID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	var payload models.UpdateProductVariantPayload
+	if err := jsoniter.NewDecoder(ectx.Request().Body).Decode(&payload); err != nil {
+		logger.Error("error to bind payload", "error", err)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	userID, ok := p.rdp.GetUserID(ectx.Request().Context())
+	if !ok {
+		logger.Error("get user id from context")
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	if err := p.pvs.UpdateProductVariant(ectx.Request().Context(), userID, storeID, productID, variantID, payload); err != nil {
+		return p.handleProductVariantError(ectx, logger, err)
+	}
+
+	return ectx.NoContent(http.StatusOK)
+}
+
+func (p *productVariantHandler) DeleteProductVariant(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "product_variant",
+		"method", "DeleteProductVariant",
+	)
+
+	storeID, productID, ok := p.getProductParams(ectx, logger)
+	if !ok {
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	variantID := ectx.Param("variantId")
+	if _, err := uuid.Parse(variantID); err != nil {
+		logger.Warn("invalid variantID format", "variantID", variantID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	userID, ok := p.rdp.GetUserID(ectx.Request().Context())
+	if !ok {
+		logger.Error("get user id from context")
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	if err := p.pvs.DeleteProductVariant(ectx.Request().Context(), userID, storeID, productID, variantID); err != nil {
+		return p.handleProductVariantError(ectx, logger, err)
+	}
+
+	return ectx.NoContent(http.StatusNoContent)
+}
+
+func (p *productVariantHandler) getProductParams(ectx echo.Context, logger *slog.Logger) (string, string, bool) {
+	storeID := ectx.Param("storeId")
+	if _, err := uuid.Parse(storeID); err != nil {
+		logger.Warn("invalid storeID format", "storeID", storeID)
+		return "", "", false
+	}
+
+	productID := ectx.Param("productId")
+	if _, err := uuid.Parse(productID); err != nil {
+		logger.Warn("invalid productID format", "productID", productID)
+		return "", "", false
+	}
+
+	return storeID, productID, true
+}
+
+func (p *productVariantHandler) handleProductVariantError(ectx echo.Context, logger *slog.Logger, err error) error {
+	if err == models.ErrStoreNotFound {
+		logger.Warn("store not found", "error", err)
+		return ectx.NoContent(http.StatusNotFound)
+	}
+
+	if err == models.ErrStoreNotPertenence || err == models.ErrStorePermissionDenied {
+		logger.Warn("store not pertenence to user", "error", err)
+		return ectx.NoContent(http.StatusForbidden)
+	}
+
+	if err == models.ErrProductNotFound {
+		logger.Warn("product not found", "error", err)
+		return ectx.NoContent(http.StatusNotFound)
+	}
+
+	if err == models.ErrProductVariantNotFound {
+		logger.Warn("product variant not found", "error", err)
+		return ectx.NoContent(http.StatusNotFound)
+	}
+
+	if err == models.ErrSizeNotFound {
+		logger.Warn("size not found", "error", err)
+		return ectx.NoContent(http.StatusNotFound)
+	}
+
+	if err == models.ErrColorNotFound {
+		logger.Warn("color not found", "error", err)
+		return ectx.NoContent(http.StatusNotFound)
+	}
+
+	if err == models.ErrInvalidProductVariant {
+		logger.Warn("invalid product variant", "error", err)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	if err == models.ErrProductVariantDuplicate || err == models.ErrSKUAlreadyExists {
+		logger.Warn("product variant conflict", "error", err)
+		return ectx.NoContent(http.StatusConflict)
+	}
+
+	logger.Error("product variant operation", "error", err)
+	return ectx.NoContent(http.StatusInternalServerError)
+}
@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/g-villarinho/flash-buy-api/models"
+	"github.com/g-villarinho/flash-buy-api/pkgs"
+	"github.com/g-villarinho/flash-buy-api/services"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type SessionHandler interface {
+	Logout(ectx echo.Context) error
+	GetSessions(ectx echo.Context) error
+	RevokeSession(ectx echo.Context) error
+}
+
+type sessionHandler struct {
+	di  *pkgs.Di
+	ss  services.SessionService
+	rdp pkgs.RequestDataCtx
+}
+
+func NewSessionHandler(di *pkgs.Di) (SessionHandler, error) {
+	ss, err := pkgs.Invoke[services.SessionService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	ctxData, err := pkgs.Invoke[pkgs.RequestDataCtx](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sessionHandler{
+		di:  di,
+		ss:  ss,
+		rdp: ctxData,
+	}, nil
+}
+
+func (s *sessionHandler) Logout(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "session",
+		"method", "Logout",
+	)
+
+	userID, ok := s.rdp.GetUserID(ectx.Request().Context())
+	if !ok {
+		logger.Error("get user id from context")
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	sessionID, ok := s.rdp.GetSessionID(ectx.Request().Context())
+	if !ok {
+		logger.Error("get session id from context")
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	if err := s.ss.RevokeSession(ectx.Request().Context(), userID, sessionID); err != nil && err != models.ErrSessionNotFound {
+		logger.Error("revoke session", "error", err)
+		return ectx.NoContent(http.StatusInternalServerError)
+	}
+
+	DelCookieSession(ectx)
+	return ectx.NoContent(http.StatusNoContent)
+}
+
+func (s *sessionHandler) GetSessions(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "session",
+		"method", "GetSessions",
+	)
+
+	userID, ok := s.rdp.GetUserID(ectx.Request().Context())
+	if !ok {
+		logger.Error("get user id from context")
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	sessionID, _ := s.rdp.GetSessionID(ectx.Request().Context())
+
+	resp, err := s.ss.GetSessions(ectx.Request().Context(), userID, sessionID)
+	if err != nil {
+		logger.Error("get sessions", "error", err)
+		return ectx.NoContent(http.StatusInternalServerError)
+	}
+
+	return ectx.JSON(http.StatusOK, resp)
+}
+
+func (s *sessionHandler) RevokeSession(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "session",
+		"method", "RevokeSession",
+	)
+
+	targetSessionID := ectx.Param("sessionId")
+	if _, err := uuid.Parse(targetSessionID); err != nil {
+		logger.Warn("invalid sessionID format", "sessionID", targetSessionID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	userID, ok := s.rdp.GetUserID(ectx.Request().Context())
+	if !ok {
+		logger.Error("get user id from context")
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	if err := s.ss.RevokeSession(ectx.Request().Context(), userID, targetSessionID); err != nil {
+		if err == models.ErrSessionNotFound {
+			logger.Warn("session not found", "sessionID", targetSessionID)
+			return ectx.NoContent(http.StatusNotFound)
+		}
+
+		logger.Error("revoke session", "error", err)
+		return ectx.NoContent(http.StatusInternalServerError)
+	}
+
+	if currentSessionID, _ := s.rdp.GetSessionID(ectx.Request().Context()); currentSessionID == targetSessionID {
+		DelCookieSession(ectx)
+	}
+
+	return ectx.NoContent(http.StatusNoContent)
+}
@@ -85,7 +85,7 @@ func (s *sizeHandler) CreateSize(ectx echo.Context) error {
 			return ectx.NoContent(http.StatusNotFound)
 		}
 
-		if err == models.ErrStoreNotPertenence {
+		if err == models.ErrStoreNotPertenence || err == models.ErrStorePermissionDenied {
 			logger.Warn("store not pertenence to user", "storeID", storeID)
 			return ectx.NoContent(http.StatusForbidden)
 		}
@@ -120,8 +120,25 @@ func (s *sizeHandler) GetSizesPagedList(ectx echo.Context) error {
 		utils.GetQueryStringPointer(ectx.QueryParam("name")),
 	)
 
-	resp, err := s.ss.GetSizesPagedList(ectx.Request().Context(), storeID, *pag)
+	userID, ok := s.rdp.GetUserID(ectx.Request().Context())
+	if !ok {
+		logger.Error("get user id from context")
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	resp, err := s.ss.GetSizesPagedList(ectx.Request().Context(), userID, storeID, *pag)
 	if err != nil {
+		if err == models.ErrStoreNotFound {
+			logger.Warn("store not found", "storeID", storeID)
+			return ectx.NoContent(http.StatusNotFound)
+		}
+
+		if err == models.ErrStoreNotPertenence || err == models.ErrStorePermissionDenied {
+			logger.Warn("store not pertenence to user", "storeID", storeID)
+			return ectx.NoContent(http.StatusForbidden)
+		}
+
 		logger.Error("get sizes paged list", "error", err)
 		return ectx.NoContent(http.StatusInternalServerError)
 	}
@@ -157,8 +174,25 @@ func (s *sizeHandler) GetSizeByID(ectx echo.Context) error {
 		return ectx.NoContent(http.StatusBadRequest)
 	}
 
-	resp, err := s.ss.GetSizeByID(ectx.Request().Context(), sizeID, storeID)
+	userID, ok := s.rdp.GetUserID(ectx.Request().Context())
+	if !ok {
+		logger.Error("get user id from context")
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	resp, err := s.ss.GetSizeByID(ectx.Request().Context(), userID, sizeID, storeID)
 	if err != nil {
+		if err == models.ErrStoreNotFound {
+			logger.Warn("store not found", "storeID", storeID)
+			return ectx.NoContent(http.StatusNotFound)
+		}
+
+		if err == models.ErrStoreNotPertenence || err == models.ErrStorePermissionDenied {
+			logger.Warn("store not pertenence to user", "storeID", storeID)
+			return ectx.NoContent(http.StatusForbidden)
+		}
+
 		if err == models.ErrSizeNotFound {
 			logger.Warn("size not found", "sizeID", sizeID)
 			return ectx.NoContent(http.StatusNotFound)
@@ -216,7 +250,7 @@ func (s *sizeHandler) DeleteSize(ectx echo.Context) error {
 			return ectx.NoContent(http.StatusNotFound)
 		}
 
-		if err == models.ErrStoreNotPertenence {
+		if err == models.ErrStoreNotPertenence || err == models.ErrStorePermissionDenied {
 			logger.Warn("store not pertenence to user", "storeID", storeID)
 			return ectx.NoContent(http.StatusForbidden)
 		}
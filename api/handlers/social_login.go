@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/g-villarinho/flash-buy-api/config"
+	"github.com/g-villarinho/flash-buy-api/models"
+	"github.com/g-villarinho/flash-buy-api/pkgs"
+	"github.com/g-villarinho/flash-buy-api/services"
+	"github.com/labstack/echo/v4"
+)
+
+type SocialLoginHandler interface {
+	StartLogin(ectx echo.Context) error
+	Callback(ectx echo.Context) error
+}
+
+type socialLoginHandler struct {
+	di  *pkgs.Di
+	sls services.SocialLoginService
+}
+
+func NewSocialLoginHandler(di *pkgs.Di) (SocialLoginHandler, error) {
+	sls, err := pkgs.Invoke[services.SocialLoginService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &socialLoginHandler{
+		di:  di,
+		sls: sls,
+	}, nil
+}
+
+func (s *socialLoginHandler) StartLogin(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "social_login",
+		"method", "StartLogin",
+	)
+
+	provider := ectx.Param("provider")
+
+	authorization, err := s.sls.StartLogin(ectx.Request().Context(), provider)
+	if err != nil {
+		if err == models.ErrSocialLoginProviderNotFound {
+			logger.Warn("provider not found", "provider", provider)
+			return ectx.NoContent(http.StatusNotFound)
+		}
+
+		logger.Error("start social login", "provider", provider, "error", err)
+		return ectx.NoContent(http.StatusInternalServerError)
+	}
+
+	SetCookieSocialLoginState(ectx, authorization.State, services.SocialLoginStateExpiration)
+	return ectx.Redirect(http.StatusFound, authorization.URL)
+}
+
+func (s *socialLoginHandler) Callback(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "social_login",
+		"method", "Callback",
+	)
+
+	provider := ectx.Param("provider")
+	DelCookieSocialLoginState(ectx)
+
+	if providerErr := ectx.QueryParam("error"); providerErr != "" {
+		logger.Warn("provider returned error", "provider", provider, "error", providerErr)
+		return s.redirectWithError(ectx, "access_denied")
+	}
+
+	code := ectx.QueryParam("code")
+	state := ectx.QueryParam("state")
+	if code == "" || state == "" {
+		logger.Warn("code or state is empty", "provider", provider)
+		return s.redirectWithError(ectx, "invalid_request")
+	}
+
+	// Impede que um callback iniciado por terceiros autentique este navegador
+	cookie, err := ectx.Cookie(socialLoginStateCookie)
+	if err != nil || subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(state)) != 1 {
+		logger.Warn("state cookie mismatch", "provider", provider)
+		return s.redirectWithError(ectx, "invalid_state")
+	}
+
+	ssi := models.SessionSecurityInfo{
+		IP:        ectx.RealIP(),
+		UserAgent: ectx.Request().UserAgent(),
+	}
+
+	session, err := s.sls.HandleCallback(ectx.Request().Context(), provider, code, state, ssi)
+	if err != nil {
+		if err == models.ErrSocialLoginProviderNotFound {
+			logger.Warn("provider not found", "provider", provider)
+			return ectx.NoContent(http.StatusNotFound)
+		}
+
+		if err == models.ErrSocialLoginStateInvalid {
+			logger.Warn("social login state invalid", "error", err)
+			return s.redirectWithError(ectx, "invalid_state")
+		}
+
+		if err == models.ErrSocialLoginCodeInvalid || err == models.ErrSocialLoginIDTokenInvalid {
+			logger.Warn("social login rejected", "provider", provider, "error", err)
+			return s.redirectWithError(ectx, "invalid_grant")
+		}
+
+		if err == models.ErrSocialLoginEmailNotVerified {
+			logger.Warn("social login email not verified", "provider", provider)
+			return s.redirectWithError(ectx, "email_not_verified")
+		}
+
+		logger.Error("social login callback", "provider", provider, "error", err)
+		return s.redirectWithError(ectx, "server_error")
+	}
+
+	SetCookieSession(ectx, *session)
+
+	if session.IsPendingMFA() {
+		return ectx.Redirect(http.StatusFound, strings.TrimRight(config.Env.Frontend.URL, "/")+"/login/two-factor")
+	}
+
+	return ectx.Redirect(http.StatusFound, strings.TrimRight(config.Env.Frontend.URL, "/"))
+}
+
+func (s *socialLoginHandler) redirectWithError(ectx echo.Context, reason string) error {
+	location := fmt.Sprintf("%s/login?error=%s", strings.TrimRight(config.Env.Frontend.URL, "/"), url.QueryEscape(reason))
+	return ectx.Redirect(http.StatusFound, location)
+}
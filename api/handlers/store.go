@@ -143,7 +143,7 @@ func (s *storeHandler) GetStoreByID(ectx echo.Context) error {
 			return ectx.NoContent(http.StatusNotFound)
 		}
 
-		if err == models.ErrStoreNotPertenence {
+		if err == models.ErrStoreNotPertenence || err == models.ErrStorePermissionDenied {
 			logger.Warn("store not pertenence to user", "storeID", storeID)
 			return ectx.NoContent(http.StatusForbidden)
 		}
@@ -213,7 +213,7 @@ func (s *storeHandler) UpdateStore(ectx echo.Context) error {
 			return ectx.NoContent(http.StatusNotFound)
 		}
 
-		if err == models.ErrStoreNotPertenence {
+		if err == models.ErrStoreNotPertenence || err == models.ErrStorePermissionDenied {
 			logger.Warn("store not pertenence to user", "slug", storeID)
 			return ectx.NoContent(http.StatusForbidden)
 		}
@@ -255,7 +255,7 @@ func (s *storeHandler) DeleteStore(ectx echo.Context) error {
 			return ectx.NoContent(http.StatusNotFound)
 		}
 
-		if err == models.ErrStoreNotPertenence {
+		if err == models.ErrStoreNotPertenence || err == models.ErrStorePermissionDenied {
 			logger.Warn("store not pertenence to user", "slug", storeID)
 			return ectx.NoContent(http.StatusForbidden)
 		}
@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/g-villarinho/flash-buy-api/models"
+	"github.com/g-villarinho/flash-buy-api/pkgs"
+	"github.com/g-villarinho/flash-buy-api/services"
+	"github.com/google/uuid"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/labstack/echo/v4"
+)
+
+type StoreAPIKeyHandler interface {
+	CreateAPIKey(ectx echo.Context) error
+	GetAPIKeys(ectx echo.Context) error
+	RevokeAPIKey(ectx echo.Context) error
+}
+
+type storeAPIKeyHandler struct {
+	di  *pkgs.Di
+	rdp pkgs.RequestDataCtx
+	aks services.StoreAPIKeyService
+}
+
+func NewStoreAPIKeyHandler(di *pkgs.Di) (StoreAPIKeyHandler, error) {
+	ctxData, err := pkgs.Invoke[pkgs.RequestDataCtx](di)
+	if err != nil {
+		return nil, err
+	}
+
+	aks, err := pkgs.Invoke[services.StoreAPIKeyService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &storeAPIKeyHandler{
+		di:  di,
+		rdp: ctxData,
+		aks: aks,
+	}, nil
+}
+
+func (s *storeAPIKeyHandler) CreateAPIKey(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "store_api_key",
+		"method", "CreateAPIKey",
+	)
+
+	storeID := ectx.Param("storeId")
+	if _, err := uuid.Parse(storeID); err != nil {
+		logger.Warn("invalid storeID format", "storeID", storeID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	var payload models.CreateStoreAPIKeyPayload
+	if err := jsoniter.NewDecoder(ectx.Request().Body).Decode(&payload); err != nil {
+		logger.Error("error to bind payload", "error", err)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	if strings.TrimSpace(payload.Name) == "" {
+		logger.Warn("name is empty")
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	userID, ok := s.rdp.GetUserID(ectx.Request().Context())
+	if !ok {
+		logger.Error("get user id from context")
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	resp, err := s.aks.CreateAPIKey(ectx.Request().Context(), userID, storeID, payload)
+	if err != nil {
+		return s.handleStoreAPIKeyError(ectx, logger, err)
+	}
+
+	return ectx.JSON(http.StatusCreated, resp)
+}
+
+func (s *storeAPIKeyHandler) GetAPIKeys(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "store_api_key",
+		"method", "GetAPIKeys",
+	)
+
+	storeID := ectx.Param("storeId")
+	if _, err := uuid.Parse(storeID); err != nil {
+		logger.Warn("invalid storeID format", "storeID", storeID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	userID, ok := s.rdp.GetUserID(ectx.Request().Context())
+	if !ok {
+		logger.Error("get user id from context")
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	resp, err := s.aks.GetAPIKeys(ectx.Request().Context(), userID, storeID)
+	if err != nil {
+		return s.handleStoreAPIKeyError(ectx, logger, err)
+	}
+
+	return ectx.JSON(http.StatusOK, resp)
+}
+
+func (s *storeAPIKeyHandler) RevokeAPIKey(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "store_api_key",
+		"method", "RevokeAPIKey",
+	)
+
+	storeID := ectx.Param("storeId")
+	if _, err := uuid.Parse(storeID); err != nil {
+		logger.Warn("invalid storeID format", "storeID", storeID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	apiKeyID := ectx.Param("apiKeyId")
+	if _, err := uuid.Parse(apiKeyID); err != nil {
+		logger.Warn("invalid apiKeyID format", "apiKeyID", apiKeyID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	userID, ok := s.rdp.GetUserID(ectx.Request().Context())
+	if !ok {
+		logger.Error("get user id from context")
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	if err := s.aks.RevokeAPIKey(ectx.Request().Context(), userID, storeID, apiKeyID); err != nil {
+		return s.handleStoreAPIKeyError(ectx, logger, err)
+	}
+
+	return ectx.NoContent(http.StatusNoContent)
+}
+
+func (s *storeAPIKeyHandler) handleStoreAPIKeyError(ectx echo.Context, logger *slog.Logger, err error) error {
+	if err == models.ErrStoreNotFound {
+		logger.Warn("store not found", "error", err)
+		return ectx.NoContent(http.StatusNotFound)
+	}
+
+	if err == models.ErrStoreNotPertenence || err == models.ErrStorePermissionDenied {
+		logger.Warn("store not pertenence to user", "error", err)
+		return ectx.NoContent(http.StatusForbidden)
+	}
+
+	if err == models.ErrInvalidAPIKeyScope || err == models.ErrInvalidAPIKeyExpiration {
+		logger.Warn("invalid api key payload", "error", err)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	if err == models.ErrAPIKeyNotFound {
+		logger.Warn("api key not found", "error", err)
+		return ectx.NoContent(http.StatusNotFound)
+	}
+
+	logger.Error("store api key operation", "error", err)
+	return ectx.NoContent(http.StatusInternalServerError)
+}
@@ -0,0 +1,330 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/g-villarinho/flash-buy-api/models"
+	"github.com/g-villarinho/flash-buy-api/pkgs"
+	"github.com/g-villarinho/flash-buy-api/services"
+	"github.com/google/uuid"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/labstack/echo/v4"
+)
+
+type StoreMemberHandler interface {
+	GetMembers(ectx echo.Context) error
+	UpdateMemberRole(ectx echo.Context) error
+	RemoveMember(ectx echo.Context) error
+	InviteMember(ectx echo.Context) error
+	GetInvitations(ectx echo.Context) error
+	RevokeInvitation(ectx echo.Context) error
+	AcceptInvitation(ectx echo.Context) error
+}
+
+type storeMemberHandler struct {
+	di  *pkgs.Di
+	rdp pkgs.RequestDataCtx
+	sms services.StoreMemberService
+}
+
+func NewStoreMemberHandler(di *pkgs.Di) (StoreMemberHandler, error) {
+	ctxData, err := pkgs.Invoke[pkgs.RequestDataCtx](di)
+	if err != nil {
+		return nil, err
+	}
+
+	sms, err := pkgs.Invoke[services.StoreMemberService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &storeMemberHandler{
+		di:  di,
+		rdp: ctxData,
+		sms: sms,
+	}, nil
+}
+
+func (s *storeMemberHandler) GetMembers(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "store_member",
+		"method", "GetMembers",
+	)
+
+	storeID := ectx.Param("storeId")
+	if _, err := uuid.Parse(storeID); err != nil {
+		logger.Warn("invalid storeID format", "storeID", storeID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	userID, ok := s.rdp.GetUserID(ectx.Request().Context())
+	if !ok {
+		logger.Error("get user id from context")
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	resp, err := s.sms.GetMembers(ectx.Request().Context(), userID, storeID)
+	if err != nil {
+		return s.handleStoreMemberError(ectx, logger, err)
+	}
+
+	return ectx.JSON(http.StatusOK, resp)
+}
+
+func (s *storeMemberHandler) UpdateMemberRole(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "store_member",
+		"method", "UpdateMemberRole",
+	)
+
+	storeID := ectx.Param("storeId")
+	if _, err := uuid.Parse(storeID); err != nil {
+		logger.Warn("invalid storeID format", "storeID", storeID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	memberID := ectx.Param("memberId")
+	if _, err := uuid.Parse(memberID); err != nil {
+		logger.Warn("invalid memberID format", "memberID", memberID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	var payload models.UpdateStoreMemberPayload
+	if err := jsoniter.NewDecoder(ectx.Request().Body).Decode(&payload); err != nil {
+		logger.Error("error to bind payload", "error", err)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	userID, ok := s.rdp.GetUserID(ectx.Request().Context())
+	if !ok {
+		logger.Error("get user id from context")
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	resp, err := s.sms.UpdateMemberRole(ectx.Request().Context(), userID, storeID, memberID, payload.Role)
+	if err != nil {
+		return s.handleStoreMemberError(ectx, logger, err)
+	}
+
+	return ectx.JSON(http.StatusOK, resp)
+}
+
+func (s *storeMemberHandler) RemoveMember(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "store_member",
+		"method", "RemoveMember",
+	)
+
+	storeID := ectx.Param("storeId")
+	if _, err := uuid.Parse(storeID); err != nil {
+		logger.Warn("invalid storeID format", "storeID", storeID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	memberID := ectx.Param("memberId")
+	if _, err := uuid.Parse(memberID); err != nil {
+		logger.Warn("invalid memberID format", "memberID", memberID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	userID, ok := s.rdp.GetUserID(ectx.Request().Context())
+	if !ok {
+		logger.Error("get user id from context")
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	if err := s.sms.RemoveMember(ectx.Request().Context(), userID, storeID, memberID); err != nil {
+		return s.handleStoreMemberError(ectx, logger, err)
+	}
+
+	return ectx.NoContent(http.StatusNoContent)
+}
+
+func (s *storeMemberHandler) InviteMember(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "store_member",
+		"method", "InviteMember",
+	)
+
+	storeID := ectx.Param("storeId")
+	if _, err := uuid.Parse(storeID); err != nil {
+		logger.Warn("invalid storeID format", "storeID", storeID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	var payload models.InviteStoreMemberPayload
+	if err := jsoniter.NewDecoder(ectx.Request().Body).Decode(&payload); err != nil {
+		logger.Error("error to bind payload", "error", err)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	if payload.Email == "" {
+		logger.Warn("email is empty")
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	userID, ok := s.rdp.GetUserID(ectx.Request().Context())
+	if !ok {
+		logger.Error("get user id from context")
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	resp, err := s.sms.InviteMember(ectx.Request().Context(), userID, storeID, payload)
+	if err != nil {
+		return s.handleStoreMemberError(ectx, logger, err)
+	}
+
+	return ectx.JSON(http.StatusCreated, resp)
+}
+
+func (s *storeMemberHandler) GetInvitations(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "store_member",
+		"method", "GetInvitations",
+	)
+
+	storeID := ectx.Param("storeId")
+	if _, err := uuid.Parse(storeID); err != nil {
+		logger.Warn("invalid storeID format", "storeID", storeID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	userID, ok := s.rdp.GetUserID(ectx.Request().Context())
+	if !ok {
+		logger.Error("get user id from context")
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	resp, err := s.sms.GetInvitations(ectx.Request().Context(), userID, storeID)
+	if err != nil {
+		return s.handleStoreMemberError(ectx, logger, err)
+	}
+
+	return ectx.JSON(http.StatusOK, resp)
+}
+
+func (s *storeMemberHandler) RevokeInvitation(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "store_member",
+		"method", "RevokeInvitation",
+	)
+
+	storeID := ectx.Param("storeId")
+	if _, err := uuid.Parse(storeID); err != nil {
+		logger.Warn("invalid storeID format", "storeID", storeID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	invitationID := ectx.Param("invitationId")
+	if _, err := uuid.Parse(invitationID); err != nil {
+		logger.Warn("invalid invitationID format", "invitationID", invitationID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	userID, ok := s.rdp.GetUserID(ectx.Request().Context())
+	if !ok {
+		logger.Error("get user id from context")
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	if err := s.sms.RevokeInvitation(ectx.Request().Context(), userID, storeID, invitationID); err != nil {
+		return s.handleStoreMemberError(ectx, logger, err)
+	}
+
+	return ectx.NoContent(http.StatusNoContent)
+}
+
+func (s *storeMemberHandler) AcceptInvitation(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "store_member",
+		"method", "AcceptInvitation",
+	)
+
+	var payload models.AcceptInvitationPayload
+	if err := jsoniter.NewDecoder(ectx.Request().Body).Decode(&payload); err != nil {
+		logger.Error("error to bind payload", "error", err)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	if payload.Token == "" {
+		logger.Warn("token is empty")
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	userID, ok := s.rdp.GetUserID(ectx.Request().Context())
+	if !ok {
+		logger.Error("get user id from context")
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	resp, err := s.sms.AcceptInvitation(ectx.Request().Context(), userID, payload.Token)
+	if err != nil {
+		return s.handleStoreMemberError(ectx, logger, err)
+	}
+
+	return ectx.JSON(http.StatusOK, resp)
+}
+
+func (s *storeMemberHandler) handleStoreMemberError(ectx echo.Context, logger *slog.Logger, err error) error {
+	if err == models.ErrStoreNotFound {
+		logger.Warn("store not found", "error", err)
+		return ectx.NoContent(http.StatusNotFound)
+	}
+
+	if err == models.ErrStoreNotPertenence || err == models.ErrStorePermissionDenied {
+		logger.Warn("store not pertenence to user", "error", err)
+		return ectx.NoContent(http.StatusForbidden)
+	}
+
+	if err == models.ErrStoreOwnerImmutable {
+		logger.Warn("store owner immutable", "error", err)
+		return ectx.NoContent(http.StatusForbidden)
+	}
+
+	if err == models.ErrInvalidStoreRole {
+		logger.Warn("invalid store role", "error", err)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	if err == models.ErrStoreMemberNotFound {
+		logger.Warn("store member not found", "error", err)
+		return ectx.NoContent(http.StatusNotFound)
+	}
+
+	if err == models.ErrStoreMemberAlreadyExists {
+		logger.Warn("store member already exists", "error", err)
+		return ectx.NoContent(http.StatusConflict)
+	}
+
+	if err == models.ErrInvitationNotFound {
+		logger.Warn("invitation not found", "error", err)
+		return ectx.NoContent(http.StatusNotFound)
+	}
+
+	if err == models.ErrInvitationExpired {
+		logger.Warn("invitation expired", "error", err)
+		return ectx.NoContent(http.StatusGone)
+	}
+
+	if err == models.ErrInvitationEmailMismatch {
+		logger.Warn("invitation email mismatch", "error", err)
+		return ectx.NoContent(http.StatusForbidden)
+	}
+
+	if err == models.ErrUserNotFound {
+		logger.Warn("user not found", "error", err)
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	logger.Error("store member operation", "error", err)
+	return ectx.NoContent(http.StatusInternalServerError)
+}
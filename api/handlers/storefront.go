@@ -0,0 +1,248 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/g-villarinho/flash-buy-api/models"
+	"github.com/g-villarinho/flash-buy-api/pkgs"
+	"github.com/g-villarinho/flash-buy-api/services"
+	"github.com/g-villarinho/flash-buy-api/utils"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type StorefrontHandler interface {
+	GetStore(ectx echo.Context) error
+	GetBillboards(ectx echo.Context) error
+	GetActiveBillboard(ectx echo.Context) error
+	GetCategories(ectx echo.Context) error
+	GetCategoryTree(ectx echo.Context) error
+	GetProducts(ectx echo.Context) error
+	GetProductByID(ectx echo.Context) error
+}
+
+type storefrontHandler struct {
+	di *pkgs.Di
+	ss services.StorefrontService
+}
+
+func NewStorefrontHandler(di *pkgs.Di) (StorefrontHandler, error) {
+	ss, err := pkgs.Invoke[services.StorefrontService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &storefrontHandler{
+		di: di,
+		ss: ss,
+	}, nil
+}
+
+func (s *storefrontHandler) GetStore(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "storefront",
+		"method", "GetStore",
+	)
+
+	storeID := ectx.Param("storeId")
+	if _, err := uuid.Parse(storeID); err != nil {
+		logger.Warn("invalid storeID format", "storeID", storeID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	resp, err := s.ss.GetStore(ectx.Request().Context(), storeID)
+	if err != nil {
+		if err == models.ErrStoreNotFound {
+			logger.Warn("store not found", "storeID", storeID)
+			return ectx.NoContent(http.StatusNotFound)
+		}
+
+		logger.Error("get store", "error", err)
+		return ectx.NoContent(http.StatusInternalServerError)
+	}
+
+	return ectx.JSON(http.StatusOK, resp)
+}
+
+func (s *storefrontHandler) GetBillboards(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "storefront",
+		"method", "GetBillboards",
+	)
+
+	storeID := ectx.Param("storeId")
+	if _, err := uuid.Parse(storeID); err != nil {
+		logger.Warn("invalid storeID format", "storeID", storeID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	resp, err := s.ss.GetBillboards(ectx.Request().Context(), storeID)
+	if err != nil {
+		if err == models.ErrStoreNotFound {
+			logger.Warn("store not found", "storeID", storeID)
+			return ectx.NoContent(http.StatusNotFound)
+		}
+
+		logger.Error("get billboards", "error", err)
+		return ectx.NoContent(http.StatusInternalServerError)
+	}
+
+	return ectx.JSON(http.StatusOK, resp)
+}
+
+func (s *storefrontHandler) GetActiveBillboard(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "storefront",
+		"method", "GetActiveBillboard",
+	)
+
+	storeID := ectx.Param("storeId")
+	if _, err := uuid.Parse(storeID); err != nil {
+		logger.Warn("invalid storeID format", "storeID", storeID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	categoryID := ectx.QueryParam("categoryId")
+	if categoryID != "" {
+		if _, err := uuid.Parse(categoryID); err != nil {
+			logger.Warn("invalid categoryID format", "categoryID", categoryID)
+			return ectx.NoContent(http.StatusBadRequest)
+		}
+	}
+
+	resp, err := s.ss.GetActiveBillboard(ectx.Request().Context(), storeID, categoryID)
+	if err != nil {
+		if err == models.ErrStoreNotFound || err == models.ErrCategoryNotFound || err == models.ErrBillboardNotFound {
+			logger.Warn("active billboard not found", "storeID", storeID, "categoryID", categoryID, "error", err)
+			return ectx.NoContent(http.StatusNotFound)
+		}
+
+		logger.Error("get active billboard", "error", err)
+		return ectx.NoContent(http.StatusInternalServerError)
+	}
+
+	return ectx.JSON(http.StatusOK, resp)
+}
+
+func (s *storefrontHandler) GetCategories(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "storefront",
+		"method", "GetCategories",
+	)
+
+	storeID := ectx.Param("storeId")
+	if _, err := uuid.Parse(storeID); err != nil {
+		logger.Warn("invalid storeID format", "storeID", storeID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	resp, err := s.ss.GetCategories(ectx.Request().Context(), storeID)
+	if err != nil {
+		if err == models.ErrStoreNotFound {
+			logger.Warn("store not found", "storeID", storeID)
+			return ectx.NoContent(http.StatusNotFound)
+		}
+
+		logger.Error("get categories", "error", err)
+		return ectx.NoContent(http.StatusInternalServerError)
+	}
+
+	return ectx.JSON(http.StatusOK, resp)
+}
+
+func (s *storefrontHandler) GetCategoryTree(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "storefront",
+		"method", "GetCategoryTree",
+	)
+
+	storeID := ectx.Param("storeId")
+	if _, err := uuid.Parse(storeID); err != nil {
+		logger.Warn("invalid storeID format", "storeID", storeID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	resp, err := s.ss.GetCategoryTree(ectx.Request().Context(), storeID)
+	if err != nil {
+		if err == models.ErrStoreNotFound {
+			logger.Warn("store not found", "storeID", storeID)
+			return ectx.NoContent(http.StatusNotFound)
+		}
+
+		logger.Error("get category tree", "error", err)
+		return ectx.NoContent(http.StatusInternalServerError)
+	}
+
+	return ectx.JSON(http.StatusOK, resp)
+}
+
+func (s *storefrontHandler) GetProducts(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "storefront",
+		"method", "GetProducts",
+	)
+
+	storeID := ectx.Param("storeId")
+	if _, err := uuid.Parse(storeID); err != nil {
+		logger.Warn("invalid storeID format", "storeID", storeID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	pag := models.NewProductPagination(
+		ectx.QueryParam("page"),
+		ectx.QueryParam("limit"),
+		utils.GetQueryStringPointer(ectx.QueryParam("name")),
+		utils.GetQueryStringPointer(ectx.QueryParam("categoryId")),
+		utils.GetQueryStringPointer(ectx.QueryParam("colorId")),
+		utils.GetQueryStringPointer(ectx.QueryParam("sizeId")),
+		utils.GetQueryStringPointer(ectx.QueryParam("isFeatured")),
+		nil,
+		utils.GetQueryStringPointer(ectx.QueryParam("includeSubcategories")),
+	)
+
+	resp, err := s.ss.GetProductsPagedList(ectx.Request().Context(), storeID, *pag)
+	if err != nil {
+		if err == models.ErrStoreNotFound {
+			logger.Warn("store not found", "storeID", storeID)
+			return ectx.NoContent(http.StatusNotFound)
+		}
+
+		logger.Error("get products", "error", err)
+		return ectx.NoContent(http.StatusInternalServerError)
+	}
+
+	return ectx.JSON(http.StatusOK, resp)
+}
+
+func (s *storefrontHandler) GetProductByID(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "storefront",
+		"method", "GetProductByID",
+	)
+
+	storeID := ectx.Param("storeId")
+	if _, err := uuid.Parse(storeID); err != nil {
+		logger.Warn("invalid storeID format", "storeID", storeID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	productID := ectx.Param("productId")
+	if _, err := uuid.Parse(productID); err != nil {
+		logger.Warn("invalid productID format", "productID", productID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	resp, err := s.ss.GetProductByID(ectx.Request().Context(), storeID, productID)
+	if err != nil {
+		if err == models.ErrProductNotFound {
+			logger.Warn("product not found", "productID", productID)
+			return ectx.NoContent(http.StatusNotFound)
+		}
+
+		logger.Error("get product by id", "error", err)
+		return ectx.NoContent(http.StatusInternalServerError)
+	}
+
+	return ectx.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,244 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/g-villarinho/flash-buy-api/models"
+	"github.com/g-villarinho/flash-buy-api/pkgs"
+	"github.com/g-villarinho/flash-buy-api/services"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/labstack/echo/v4"
+)
+
+type TwoFactorHandler interface {
+	GetStatus(ectx echo.Context) error
+	StartEnrollment(ectx echo.Context) error
+	ConfirmEnrollment(ectx echo.Context) error
+	Disable(ectx echo.Context) error
+	RegenerateRecoveryCodes(ectx echo.Context) error
+	StepUp(ectx echo.Context) error
+}
+
+type twoFactorHandler struct {
+	di  *pkgs.Di
+	rdp pkgs.RequestDataCtx
+	tfs services.TwoFactorService
+	as  services.AuthService
+}
+
+func NewTwoFactorHandler(di *pkgs.Di) (TwoFactorHandler, error) {
+	ctxData, err := pkgs.Invoke[pkgs.RequestDataCtx](di)
+	if err != nil {
+		return nil, err
+	}
+
+	tfs, err := pkgs.Invoke[services.TwoFactorService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	as, err := pkgs.Invoke[services.AuthService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &twoFactorHandler{
+		di:  di,
+		rdp: ctxData,
+		tfs: tfs,
+		as:  as,
+	}, nil
+}
+
+func (t *twoFactorHandler) GetStatus(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "two_factor",
+		"method", "GetStatus",
+	)
+
+	userID, ok := t.rdp.GetUserID(ectx.Request().Context())
+	if !ok {
+		logger.Error("get user id from context")
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	resp, err := t.tfs.GetStatus(ectx.Request().Context(), userID)
+	if err != nil {
+		return t.handleTwoFactorError(ectx, logger, err)
+	}
+
+	return ectx.JSON(http.StatusOK, resp)
+}
+
+func (t *twoFactorHandler) StartEnrollment(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "two_factor",
+		"method", "StartEnrollment",
+	)
+
+	userID, ok := t.rdp.GetUserID(ectx.Request().Context())
+	if !ok {
+		logger.Error("get user id from context")
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	resp, err := t.tfs.StartEnrollment(ectx.Request().Context(), userID)
+	if err != nil {
+		return t.handleTwoFactorError(ectx, logger, err)
+	}
+
+	return ectx.JSON(http.StatusCreated, resp)
+}
+
+func (t *twoFactorHandler) ConfirmEnrollment(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "two_factor",
+		"method", "ConfirmEnrollment",
+	)
+
+	var payload models.ConfirmTOTPPayload
+	if err := jsoniter.NewDecoder(ectx.Request().Body).Decode(&payload); err != nil {
+		logger.Error("error to bind payload", "error", err)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	if payload.Code == "" {
+		logger.Warn("code is empty")
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	userID, ok := t.rdp.GetUserID(ectx.Request().Context())
+	if !ok {
+		logger.Error("get user id from context")
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	resp, err := t.tfs.ConfirmEnrollment(ectx.Request().Context(), userID, payload.Code)
+	if err != nil {
+		return t.handleTwoFactorError(ectx, logger, err)
+	}
+
+	return ectx.JSON(http.StatusOK, resp)
+}
+
+func (t *twoFactorHandler) Disable(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "two_factor",
+		"method", "Disable",
+	)
+
+	userID, ok := t.rdp.GetUserID(ectx.Request().Context())
+	if !ok {
+		logger.Error("get user id from context")
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	if err := t.tfs.Disable(ectx.Request().Context(), userID); err != nil {
+		return t.handleTwoFactorError(ectx, logger, err)
+	}
+
+	return ectx.NoContent(http.StatusNoContent)
+}
+
+func (t *twoFactorHandler) RegenerateRecoveryCodes(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "two_factor",
+		"method", "RegenerateRecoveryCodes",
+	)
+
+	userID, ok := t.rdp.GetUserID(ectx.Request().Context())
+	if !ok {
+		logger.Error("get user id from context")
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	resp, err := t.tfs.RegenerateRecoveryCodes(ectx.Request().Context(), userID)
+	if err != nil {
+		return t.handleTwoFactorError(ectx, logger, err)
+	}
+
+	return ectx.JSON(http.StatusOK, resp)
+}
+
+func (t *twoFactorHandler) StepUp(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "two_factor",
+		"method", "StepUp",
+	)
+
+	var payload models.VerifySecondFactorPayload
+	if err := jsoniter.NewDecoder(ectx.Request().Body).Decode(&payload); err != nil {
+		logger.Error("error to bind payload", "error", err)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	if payload.Code == "" && payload.RecoveryCode == "" {
+		logger.Warn("code and recovery code are empty")
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	userID, ok := t.rdp.GetUserID(ectx.Request().Context())
+	if !ok {
+		logger.Error("get user id from context")
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	sessionID, ok := t.rdp.GetSessionID(ectx.Request().Context())
+	if !ok {
+		logger.Error("get session id from context")
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	session, err := t.as.StepUp(ectx.Request().Context(), userID, sessionID, payload)
+	if err != nil {
+		if err == models.ErrSessionNotFoundOrExpired {
+			logger.Warn("session not found or expired", "error", err)
+			DelCookieSession(ectx)
+			return ectx.NoContent(http.StatusUnauthorized)
+		}
+
+		return t.handleTwoFactorError(ectx, logger, err)
+	}
+
+	SetCookieSession(ectx, *session)
+	return ectx.NoContent(http.StatusOK)
+}
+
+func (t *twoFactorHandler) handleTwoFactorError(ectx echo.Context, logger *slog.Logger, err error) error {
+	if err == models.ErrTOTPNotEnabled {
+		logger.Warn("totp not enabled", "error", err)
+		return ectx.NoContent(http.StatusNotFound)
+	}
+
+	if err == models.ErrTOTPAlreadyEnabled {
+		logger.Warn("totp already enabled", "error", err)
+		return ectx.NoContent(http.StatusConflict)
+	}
+
+	if err == models.ErrTOTPInvalid || err == models.ErrRecoveryCodeInvalid {
+		logger.Warn("second factor invalid", "error", err)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	if err == models.ErrTOTPLocked {
+		logger.Warn("totp locked", "error", err)
+		return ectx.NoContent(http.StatusTooManyRequests)
+	}
+
+	if err == models.ErrUserNotFound {
+		logger.Warn("user not found", "error", err)
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	logger.Error("two factor operation", "error", err)
+	return ectx.NoContent(http.StatusInternalServerError)
+}
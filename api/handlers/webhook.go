@@ -0,0 +1,241 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/g-villarinho/flash-buy-api/models"
+	"github.com/g-villarinho/flash-buy-api/pkgs"
+	"github.com/g-villarinho/flash-buy-api/services"
+	"github.com/google/uuid"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/labstack/echo/v4"
+)
+
+type WebhookHandler interface {
+	CreateWebhook(ectx echo.Context) error
+	GetWebhooks(ectx echo.Context) error
+	DeleteWebhook(ectx echo.Context) error
+	GetDeliveries(ectx echo.Context) error
+	Redeliver(ectx echo.Context) error
+}
+
+type webhookHandler struct {
+	di  *pkgs.Di
+	rdp pkgs.RequestDataCtx
+	ws  services.WebhookService
+}
+
+func NewWebhookHandler(di *pkgs.Di) (WebhookHandler, error) {
+	ctxData, err := pkgs.Invoke[pkgs.RequestDataCtx](di)
+	if err != nil {
+		return nil, err
+	}
+
+	ws, err := pkgs.Invoke[services.WebhookService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &webhookHandler{
+		di:  di,
+		rdp: ctxData,
+		ws:  ws,
+	}, nil
+}
+
+func (w *webhookHandler) CreateWebhook(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "webhook",
+		"method", "CreateWebhook",
+	)
+
+	storeID := ectx.Param("storeId")
+	if _, err := uuid.Parse(storeID); err != nil {
+		logger.Warn("invalid storeID format", "storeID", storeID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	var payload models.CreateWebhookPayload
+	if err := jsoniter.NewDecoder(ectx.Request().Body).Decode(&payload); err != nil {
+		logger.Error("error to bind payload", "error", err)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	if payload.URL == "" {
+		logger.Warn("url is empty")
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	userID, ok := w.rdp.GetUserID(ectx.Request().Context())
+	if !ok {
+		logger.Error("get user id from context")
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	resp, err := w.ws.CreateWebhook(ectx.Request().Context(), userID, storeID, payload)
+	if err != nil {
+		return w.handleWebhookError(ectx, logger, err)
+	}
+
+	return ectx.JSON(http.StatusCreated, resp)
+}
+
+func (w *webhookHandler) GetWebhooks(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "webhook",
+		"method", "GetWebhooks",
+	)
+
+	storeID := ectx.Param("storeId")
+	if _, err := uuid.Parse(storeID); err != nil {
+		logger.Warn("invalid storeID format", "storeID", storeID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	userID, ok := w.rdp.GetUserID(ectx.Request().Context())
+	if !ok {
+		logger.Error("get user id from context")
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	resp, err := w.ws.GetWebhooks(ectx.Request().Context(), userID, storeID)
+	if err != nil {
+		return w.handleWebhookError(ectx, logger, err)
+	}
+
+	return ectx.JSON(http.StatusOK, resp)
+}
+
+func (w *webhookHandler) DeleteWebhook(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "webhook",
+		"method", "DeleteWebhook",
+	)
+
+	storeID := ectx.Param("storeId")
+	if _, err := uuid.Parse(storeID); err != nil {
+		logger.Warn("invalid storeID format", "storeID", storeID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	webhookID := ectx.Param("webhookId")
+	if _, err := uuid.Parse(webhookID); err != nil {
+		logger.Warn("invalid webhookID format", "webhookID", webhookID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	userID, ok := w.rdp.GetUserID(ectx.Request().Context())
+	if !ok {
+		logger.Error("get user id from context")
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	if err := w.ws.DeleteWebhook(ectx.Request().Context(), userID, storeID, webhookID); err != nil {
+		return w.handleWebhookError(ectx, logger, err)
+	}
+
+	return ectx.NoContent(http.StatusNoContent)
+}
+
+func (w *webhookHandler) GetDeliveries(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "webhook",
+		"method", "GetDeliveries",
+	)
+
+	storeID := ectx.Param("storeId")
+	if _, err := uuid.Parse(storeID); err != nil {
+		logger.Warn("invalid storeID format", "storeID", storeID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	webhookID := ectx.Param("webhookId")
+	if _, err := uuid.Parse(webhookID); err != nil {
+		logger.Warn("invalid webhookID format", "webhookID", webhookID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	userID, ok := w.rdp.GetUserID(ectx.Request().Context())
+	if !ok {
+		logger.Error("get user id from context")
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	pag := models.NewPagination(ectx.QueryParam("page"), ectx.QueryParam("limit"))
+
+	resp, err := w.ws.GetDeliveries(ectx.Request().Context(), userID, storeID, webhookID, *pag)
+	if err != nil {
+		return w.handleWebhookError(ectx, logger, err)
+	}
+
+	return ectx.JSON(http.StatusOK, resp)
+}
+
+func (w *webhookHandler) Redeliver(ectx echo.Context) error {
+	logger := slog.With(
+		"handler", "webhook",
+		"method", "Redeliver",
+	)
+
+	storeID := ectx.Param("storeId")
+	if _, err := uuid.Parse(storeID); err != nil {
+		logger.Warn("invalid storeID format", "storeID", storeID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	webhookID := ectx.Param("webhookId")
+	if _, err := uuid.Parse(webhookID); err != nil {
+		logger.Warn("invalid webhookID format", "webhookID", webhookID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	deliveryID := ectx.Param("deliveryId")
+	if _, err := uuid.Parse(deliveryID); err != nil {
+		logger.Warn("invalid deliveryID format", "deliveryID", deliveryID)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	userID, ok := w.rdp.GetUserID(ectx.Request().Context())
+	if !ok {
+		logger.Error("get user id from context")
+		DelCookieSession(ectx)
+		return ectx.NoContent(http.StatusUnauthorized)
+	}
+
+	resp, err := w.ws.Redeliver(ectx.Request().Context(), userID, storeID, webhookID, deliveryID)
+	if err != nil {
+		return w.handleWebhookError(ectx, logger, err)
+	}
+
+	return ectx.JSON(http.StatusAccepted, resp)
+}
+
+func (w *webhookHandler) handleWebhookError(ectx echo.Context, logger *slog.Logger, err error) error {
+	if err == models.ErrStoreNotFound {
+		logger.Warn("store not found", "error", err)
+		return ectx.NoContent(http.StatusNotFound)
+	}
+
+	if err == models.ErrStoreNotPertenence || err == models.ErrStorePermissionDenied {
+		logger.Warn("store not pertenence to user", "error", err)
+		return ectx.NoContent(http.StatusForbidden)
+	}
+
+	if err == models.ErrInvalidWebhookURL || err == models.ErrInvalidWebhookEvent {
+		logger.Warn("invalid webhook payload", "error", err)
+		return ectx.NoContent(http.StatusBadRequest)
+	}
+
+	if err == models.ErrWebhookNotFound || err == models.ErrWebhookDeliveryNotFound {
+		logger.Warn("webhook not found", "error", err)
+		return ectx.NoContent(http.StatusNotFound)
+	}
+
+	logger.Error("webhook operation", "error", err)
+	return ectx.NoContent(http.StatusInternalServerError)
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/g-villarinho/flash-buy-api/config"
+	"github.com/g-villarinho/flash-buy-api/pkgs"
+)
+
+// A API precisa ser reiniciada para carregar o chaveiro novo
+func main() {
+	if err := config.LoadEnv(); err != nil {
+		log.Println("load env: ", err)
+	}
+
+	defaultDir := config.Env.Key.Dir
+	if defaultDir == "" {
+		defaultDir = "keys"
+	}
+
+	dir := flag.String("dir", defaultDir, "key ring directory")
+	pruneAfter := flag.Duration("prune-after", 0, "remove keys retired longer than this (0 keeps all keys)")
+	flag.Parse()
+
+	if err := os.MkdirAll(*dir, 0o700); err != nil {
+		log.Fatal("create key dir: ", err)
+	}
+
+	previous, err := os.ReadFile(filepath.Join(*dir, pkgs.KeyRingActiveFile))
+	if err != nil && !os.IsNotExist(err) {
+		log.Fatal("read active key: ", err)
+	}
+
+	key, pemKey, err := pkgs.GenerateSigningKey()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(*dir, key.ID+".pem"), []byte(pemKey), 0o600); err != nil {
+		log.Fatal("write key: ", err)
+	}
+
+	// rename para a troca ser atômica
+	tmpActive := filepath.Join(*dir, pkgs.KeyRingActiveFile+".tmp")
+	if err := os.WriteFile(tmpActive, []byte(key.ID+"\n"), 0o600); err != nil {
+		log.Fatal("write active key: ", err)
+	}
+
+	if err := os.Rename(tmpActive, filepath.Join(*dir, pkgs.KeyRingActiveFile)); err != nil {
+		log.Fatal("activate key: ", err)
+	}
+
+	now := time.Now()
+	if previousID := strings.TrimSpace(string(previous)); previousID != "" {
+		if err := os.Chtimes(filepath.Join(*dir, previousID+".pem"), now, now); err != nil && !os.IsNotExist(err) {
+			log.Fatal("mark retired key: ", err)
+		}
+	}
+
+	if *pruneAfter > 0 {
+		prune(*dir, key.ID, now.Add(-*pruneAfter))
+	}
+
+	log.Println("active key: ", key.ID)
+}
+
+func prune(dir, activeID string, retiredBefore time.Time) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		log.Fatal("read key dir: ", err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".pem" || entry.Name() == activeID+".pem" {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			log.Fatal("stat key: ", err)
+		}
+
+		if info.ModTime().Before(retiredBefore) {
+			if err := os.Remove(filepath.Join(dir, entry.Name())); err != nil {
+				log.Fatal("remove key: ", err)
+			}
+
+			log.Println("pruned key: ", strings.TrimSuffix(entry.Name(), ".pem"))
+		}
+	}
+}
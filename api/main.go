@@ -1,11 +1,18 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/g-villarinho/flash-buy-api/config"
 	"github.com/g-villarinho/flash-buy-api/middlewares"
 	"github.com/g-villarinho/flash-buy-api/pkgs"
+	"github.com/g-villarinho/flash-buy-api/services"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 )
@@ -21,11 +28,43 @@ func main() {
 
 	config.NewLogger()
 
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	e.Use(middlewares.CORS())
 	e.Use(middleware.Recover())
 
 	initDeps(di)
 	setupRoutes(e, di)
 
-	e.Logger.Fatal(e.Start(fmt.Sprintf(":%d", config.Env.API.Port)))
+	// Os handlers dos jobs são registrados na criação dos serviços
+	jobQueue, err := pkgs.Invoke[services.JobQueue](di)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	jobQueue.Start()
+	startWebhookDispatcher(ctx, e, di)
+	startOutboxRelay(ctx, e, di)
+	startOrderExpirer(ctx, e, di)
+	startCartCleaner(ctx, e, di)
+
+	go func() {
+		if err := e.Start(fmt.Sprintf(":%d", config.Env.API.Port)); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			e.Logger.Fatal(err)
+		}
+	}()
+
+	<-ctx.Done()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), config.Env.Jobs.ShutdownTimeout)
+	defer cancel()
+
+	if err := e.Shutdown(shutdownCtx); err != nil {
+		e.Logger.Error(fmt.Sprintf("shutdown server: %v", err))
+	}
+
+	if err := jobQueue.Shutdown(shutdownCtx); err != nil {
+		e.Logger.Error(fmt.Sprintf("shutdown job queue: %v", err))
+	}
 }
@@ -3,12 +3,16 @@ package middlewares
 import (
 	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/g-villarinho/flash-buy-api/config"
 	"github.com/g-villarinho/flash-buy-api/handlers"
 	"github.com/g-villarinho/flash-buy-api/models"
 	"github.com/g-villarinho/flash-buy-api/pkgs"
+	"github.com/g-villarinho/flash-buy-api/services"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/labstack/echo/v4"
 )
@@ -16,19 +20,27 @@ import (
 type AuthMiddleware interface {
 	Authenticate(next echo.HandlerFunc) echo.HandlerFunc
 	AuthenticateWithoutEmailVerification(next echo.HandlerFunc) echo.HandlerFunc
+	AuthenticateOptional(next echo.HandlerFunc) echo.HandlerFunc
+	AuthenticateWithAPIKey(next echo.HandlerFunc) echo.HandlerFunc
+	RequireRecentMFA(next echo.HandlerFunc) echo.HandlerFunc
 	GetClaims(tokenString string) (*models.TokenClaims, error)
 }
 
 type authMiddleware struct {
 	di  *pkgs.Di
-	ep  pkgs.EcdsaKeyPair
+	kr  pkgs.KeyRing
 	rdp pkgs.RequestDataCtx
+	ss  services.SessionService
+	tfs services.TwoFactorService
+	aks services.StoreAPIKeyService
 }
 
+const recentMFAMaxAge = 15 * time.Minute
+
 func NewAuthMiddleware(di *pkgs.Di) (AuthMiddleware, error) {
-	ecdsaKeyPair, err := pkgs.Invoke[pkgs.EcdsaKeyPair](di)
+	keyRing, err := pkgs.Invoke[pkgs.KeyRing](di)
 	if err != nil {
-		return nil, fmt.Errorf("invoke ecdsa key pair: %w", err)
+		return nil, fmt.Errorf("invoke key ring: %w", err)
 	}
 
 	ctxData, err := pkgs.Invoke[pkgs.RequestDataCtx](di)
@@ -36,10 +48,28 @@ func NewAuthMiddleware(di *pkgs.Di) (AuthMiddleware, error) {
 		return nil, err
 	}
 
+	ss, err := pkgs.Invoke[services.SessionService](di)
+	if err != nil {
+		return nil, fmt.Errorf("invoke session service: %w", err)
+	}
+
+	tfs, err := pkgs.Invoke[services.TwoFactorService](di)
+	if err != nil {
+		return nil, fmt.Errorf("invoke two factor service: %w", err)
+	}
+
+	aks, err := pkgs.Invoke[services.StoreAPIKeyService](di)
+	if err != nil {
+		return nil, fmt.Errorf("invoke store api key service: %w", err)
+	}
+
 	return authMiddleware{
 		di:  di,
-		ep:  ecdsaKeyPair,
+		kr:  keyRing,
 		rdp: ctxData,
+		ss:  ss,
+		tfs: tfs,
+		aks: aks,
 	}, nil
 }
 
@@ -51,14 +81,29 @@ func (a authMiddleware) Authenticate(next echo.HandlerFunc) echo.HandlerFunc {
 		}
 
 		claims, err := a.GetClaims(cookie.Value)
+		if err != nil || claims.Sid == "" {
+			handlers.DelCookieSession(ectx)
+			return ectx.NoContent(http.StatusUnauthorized)
+		}
+
+		active, err := a.ss.IsSessionActive(ectx.Request().Context(), claims.Sid, claims.Sub)
 		if err != nil {
+			slog.Error("check session", "sessionID", claims.Sid, "error", err)
+			return ectx.NoContent(http.StatusInternalServerError)
+		}
+
+		if !active {
 			handlers.DelCookieSession(ectx)
 			return ectx.NoContent(http.StatusUnauthorized)
 		}
 
 		ctx := a.rdp.SetToken(ectx.Request().Context(), cookie.Value)
 		ctx = a.rdp.SetUserID(ctx, claims.Sub)
+		ctx = a.rdp.SetSessionID(ctx, claims.Sid)
 		ctx = a.rdp.SetEmail(ctx, claims.Email)
+		if mfaAt, ok := claims.SecondFactorAt(); ok {
+			ctx = a.rdp.SetMFAAt(ctx, mfaAt)
+		}
 		ectx.SetRequest(ectx.Request().WithContext(ctx))
 
 		return next(ectx)
@@ -86,18 +131,113 @@ func (a authMiddleware) AuthenticateWithoutEmailVerification(next echo.HandlerFu
 	}
 }
 
-func (a authMiddleware) GetClaims(tokenString string) (*models.TokenClaims, error) {
-	publicKey, err := a.ep.ParseECDSAPublicKey(config.Env.Key.PublicKey)
-	if err != nil || publicKey == nil {
-		return nil, fmt.Errorf("failed to convert public key: %w", err)
+func (a authMiddleware) AuthenticateOptional(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(ectx echo.Context) error {
+		cookie, err := ectx.Cookie(config.Env.Cookie.Name)
+		if err != nil {
+			return next(ectx)
+		}
+
+		claims, err := a.GetClaims(cookie.Value)
+		if err != nil || claims.Sub == "" || claims.Sid == "" {
+			return next(ectx)
+		}
+
+		active, err := a.ss.IsSessionActive(ectx.Request().Context(), claims.Sid, claims.Sub)
+		if err != nil || !active {
+			return next(ectx)
+		}
+
+		ctx := a.rdp.SetUserID(ectx.Request().Context(), claims.Sub)
+		ctx = a.rdp.SetSessionID(ctx, claims.Sid)
+		ctx = a.rdp.SetEmail(ctx, claims.Email)
+		ectx.SetRequest(ectx.Request().WithContext(ctx))
+
+		return next(ectx)
 	}
+}
 
+func (a authMiddleware) AuthenticateWithAPIKey(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(ectx echo.Context) error {
+		authorization := ectx.Request().Header.Get(echo.HeaderAuthorization)
+		if authorization == "" {
+			return a.Authenticate(next)(ectx)
+		}
+
+		rawKey, ok := strings.CutPrefix(authorization, "Bearer ")
+		if !ok || rawKey == "" {
+			return ectx.NoContent(http.StatusUnauthorized)
+		}
+
+		key, err := a.aks.Authenticate(ectx.Request().Context(), strings.TrimSpace(rawKey))
+		if err != nil {
+			if err == models.ErrAPIKeyInvalid {
+				return ectx.NoContent(http.StatusUnauthorized)
+			}
+
+			slog.Error("authenticate api key", "error", err)
+			return ectx.NoContent(http.StatusInternalServerError)
+		}
+
+		ctx := a.rdp.SetUserID(ectx.Request().Context(), key.CreatedByID.String())
+		ctx = a.rdp.SetAPIKey(ctx, pkgs.APIKeyPrincipal{
+			KeyID:   key.ID.String(),
+			StoreID: key.StoreID.String(),
+			Scopes:  key.ScopeNames(),
+		})
+		ectx.SetRequest(ectx.Request().WithContext(ctx))
+
+		return next(ectx)
+	}
+}
+
+// Deve vir depois de Authenticate
+func (a authMiddleware) RequireRecentMFA(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(ectx echo.Context) error {
+		ctx := ectx.Request().Context()
+
+		userID, ok := a.rdp.GetUserID(ctx)
+		if !ok {
+			handlers.DelCookieSession(ectx)
+			return ectx.NoContent(http.StatusUnauthorized)
+		}
+
+		if mfaAt, ok := a.rdp.GetMFAAt(ctx); ok && time.Since(mfaAt) <= recentMFAMaxAge {
+			return next(ectx)
+		}
+
+		enabled, err := a.tfs.IsEnabled(ctx, userID)
+		if err != nil {
+			slog.Error("check two factor", "userID", userID, "error", err)
+			return ectx.NoContent(http.StatusInternalServerError)
+		}
+
+		if !enabled {
+			return next(ectx)
+		}
+
+		return ectx.JSON(http.StatusForbidden, models.MFARequiredResponse{MFARequired: true})
+	}
+}
+
+func (a authMiddleware) GetClaims(tokenString string) (*models.TokenClaims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &models.TokenClaims{}, func(token *jwt.Token) (any, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodECDSA); !ok {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
 
-		return publicKey, nil
+		// Tokens emitidos antes do chaveiro não têm kid
+		kid, _ := token.Header["kid"].(string)
+		if kid == "" {
+			var keySet jwt.VerificationKeySet
+			for _, key := range a.kr.PublicKeys() {
+				keySet.Keys = append(keySet.Keys, key.PublicKey)
+			}
+
+			return keySet, nil
+		}
+
+		return a.kr.PublicKey(kid)
 	})
 
 	if err != nil {
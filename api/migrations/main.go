@@ -21,20 +21,85 @@ func main() {
 		log.Fatal("error to connect to database: ", err)
 	}
 
+	// O AutoMigrate não altera constraints existentes
+	if err := db.Exec(`ALTER TABLE IF EXISTS cart_items DROP CONSTRAINT IF EXISTS fk_cart_items_product`).Error; err != nil {
+		log.Fatal("error to drop cart items product constraint: ", err)
+	}
+
 	if err := db.AutoMigrate(
 		&models.User{},
 		&models.Session{},
 		&models.OTP{},
+		&models.OTPAttempt{},
 		&models.Store{},
 		&models.Billboard{},
 		&models.Category{},
 		&models.Size{},
 		&models.Color{},
 		&models.Product{},
+		&models.ProductVariant{},
 		&models.ProductImage{},
+		&models.Cart{},
+		&models.CartItem{},
+		&models.Order{},
+		&models.OrderItem{},
+		&models.StockAdjustment{},
+		&models.FlashSale{},
+		&models.FlashSaleItem{},
+		&models.FlashSalePurchase{},
+		&models.StoreMember{},
+		&models.StoreInvitation{},
+		&models.SocialLoginState{},
+		&models.UserTOTP{},
+		&models.RecoveryCode{},
+		&models.StoreAPIKey{},
+		&models.Webhook{},
+		&models.WebhookDelivery{},
+		&models.Job{},
+		&models.DomainEvent{},
 	); err != nil {
 		log.Fatal("error to auto migrate: ", err)
 	}
 
+	if err := db.Exec(`
+		INSERT INTO store_members (id, role, created_at, store_id, user_id)
+		SELECT gen_random_uuid(), 'owner', created_at, id, user_id FROM stores
+		ON CONFLICT (store_id, user_id) DO NOTHING
+	`).Error; err != nil {
+		log.Fatal("error to backfill store owners: ", err)
+	}
+
+	if err := db.Exec(`
+		UPDATE products SET color_id = NULL, size_id = NULL
+		WHERE id IN (SELECT product_id FROM product_variants)
+	`).Error; err != nil {
+		log.Fatal("error to clear color and size of products with variants: ", err)
+	}
+
+	// Duplicatas antigas são descartadas mantendo o item mais antigo
+	if err := db.Exec(`
+		DELETE FROM cart_items a USING cart_items b
+		WHERE a.cart_id = b.cart_id AND a.product_id = b.product_id
+		AND a.variant_id IS NOT DISTINCT FROM b.variant_id
+		AND (a.created_at, a.id) > (b.created_at, b.id)
+	`).Error; err != nil {
+		log.Fatal("error to remove duplicated cart items: ", err)
+	}
+
+	if err := db.Exec(`
+		CREATE UNIQUE INDEX IF NOT EXISTS idx_cart_items_cart_product_variant
+		ON cart_items (cart_id, product_id, variant_id) NULLS NOT DISTINCT
+	`).Error; err != nil {
+		log.Fatal("error to create cart items unique index: ", err)
+	}
+
+	if err := db.Exec(`UPDATE outbox_events SET payload = payload #- '{data,Link}' WHERE type = 'store_invitation.created'`).Error; err != nil {
+		log.Fatal("error to remove invitation links from outbox: ", err)
+	}
+
+	if err := db.Exec(`UPDATE jobs SET payload = payload #- '{data,Link}' WHERE type = 'email.store_invitation'`).Error; err != nil {
+		log.Fatal("error to remove invitation links from jobs: ", err)
+	}
+
 	log.Println("migrations done")
 }
@@ -11,14 +11,18 @@ import (
 var (
 	ErrBillboardNotFound      = errors.New("billboard not found")
 	ErrBillboardNotPertenence = errors.New("billboard not pertenence to store")
+	ErrInvalidBillboardWindow = errors.New("billboard ends before it starts")
 )
 
 type Billboard struct {
-	ID        uuid.UUID      `gorm:"type:uuid;primaryKey"`
-	Label     string         `gorm:"not null"`
-	ImageURL  sql.NullString `gorm:"default:null"`
-	CreatedAt time.Time      `gorm:"not null"`
-	UpdatedAt sql.NullTime   `gorm:"default:null"`
+	ID         uuid.UUID       `gorm:"type:uuid;primaryKey"`
+	Label      string          `gorm:"not null"`
+	ImageURL   sql.NullString  `gorm:"default:null"`
+	Renditions ImageRenditions `gorm:"type:jsonb;serializer:json"`
+	StartsAt   sql.NullTime    `gorm:"default:null"`
+	EndsAt     sql.NullTime    `gorm:"default:null"`
+	CreatedAt  time.Time       `gorm:"not null"`
+	UpdatedAt  sql.NullTime    `gorm:"default:null"`
 
 	StoreID uuid.UUID `gorm:"type:uuid;not null;index"`
 	Store   Store     `gorm:"foreignKey:StoreID"`
@@ -31,11 +35,20 @@ type BillboardPagination struct {
 	Label *string
 }
 
+type BillboardPayload struct {
+	Label    string
+	StartsAt *time.Time
+	EndsAt   *time.Time
+}
+
 type BillboardResponse struct {
-	ID        uuid.UUID `json:"id"`
-	Label     string    `json:"label"`
-	ImageURL  string    `json:"imageUrl"`
-	CreatedAt time.Time `json:"createdAt"`
+	ID         uuid.UUID       `json:"id"`
+	Label      string          `json:"label"`
+	ImageURL   string          `json:"imageUrl"`
+	Renditions ImageRenditions `json:"renditions"`
+	StartsAt   *time.Time      `json:"startsAt"`
+	EndsAt     *time.Time      `json:"endsAt"`
+	CreatedAt  time.Time       `json:"createdAt"`
 }
 
 type BillboardBasicResponse struct {
@@ -44,14 +57,56 @@ type BillboardBasicResponse struct {
 }
 
 func (b *Billboard) ToBillboardResponse() BillboardResponse {
-	return BillboardResponse{
-		ID:        b.ID,
-		Label:     b.Label,
-		ImageURL:  b.ImageURL.String,
-		CreatedAt: b.CreatedAt,
+	resp := BillboardResponse{
+		ID:         b.ID,
+		Label:      b.Label,
+		ImageURL:   b.ImageURL.String,
+		Renditions: b.Renditions,
+		CreatedAt:  b.CreatedAt,
+	}
+
+	if b.StartsAt.Valid {
+		resp.StartsAt = &b.StartsAt.Time
+	}
+
+	if b.EndsAt.Valid {
+		resp.EndsAt = &b.EndsAt.Time
+	}
+
+	return resp
+}
+
+// EndsAt é exclusivo
+func (b *Billboard) IsActiveAt(t time.Time) bool {
+	if b.StartsAt.Valid && t.Before(b.StartsAt.Time) {
+		return false
+	}
+
+	return !b.EndsAt.Valid || t.Before(b.EndsAt.Time)
+}
+
+func (b *Billboard) Apply(p BillboardPayload) {
+	b.Label = p.Label
+	b.StartsAt = sql.NullTime{}
+	b.EndsAt = sql.NullTime{}
+
+	if p.StartsAt != nil {
+		b.StartsAt = sql.NullTime{Time: *p.StartsAt, Valid: true}
+	}
+
+	if p.EndsAt != nil {
+		b.EndsAt = sql.NullTime{Time: *p.EndsAt, Valid: true}
 	}
 }
 
+func (p BillboardPayload) Validate() error {
+	if p.StartsAt != nil && p.EndsAt != nil && !p.EndsAt.After(*p.StartsAt) {
+		return ErrInvalidBillboardWindow
+	}
+
+	return nil
+}
+
 func (b *Billboard) ToBillboardBasicResponse() BillboardBasicResponse {
 	return BillboardBasicResponse{
 		ID:    b.ID,
@@ -59,19 +114,27 @@ func (b *Billboard) ToBillboardBasicResponse() BillboardBasicResponse {
 	}
 }
 
-func NewBillboard(label string, storeID string, imageURL string) (*Billboard, error) {
+func NewBillboard(payload BillboardPayload, storeID string, renditions ImageRenditions) (*Billboard, error) {
 	storeIDuuid, err := uuid.Parse(storeID)
 	if err != nil {
 		return nil, err
 	}
 
-	return &Billboard{
+	billboard := &Billboard{
 		ID:        uuid.New(),
-		Label:     label,
 		CreatedAt: time.Now(),
-		ImageURL:  sql.NullString{String: imageURL, Valid: imageURL != ""},
 		StoreID:   storeIDuuid,
-	}, nil
+	}
+
+	billboard.Apply(payload)
+	billboard.SetImage(renditions)
+
+	return billboard, nil
+}
+
+func (b *Billboard) SetImage(renditions ImageRenditions) {
+	b.ImageURL = sql.NullString{String: renditions.Full, Valid: renditions.Full != ""}
+	b.Renditions = renditions
 }
 
 func NewBillboardPagination(page, limit string, label *string) *BillboardPagination {
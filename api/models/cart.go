@@ -0,0 +1,201 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrCartNotFound        = errors.New("cart not found")
+	ErrCartItemNotFound    = errors.New("cart item not found")
+	ErrInvalidCartQuantity = errors.New("invalid cart quantity")
+	ErrProductArchived     = errors.New("product archived")
+)
+
+const (
+	MaxCartItemQuantity = 99
+)
+
+type Cart struct {
+	ID        uuid.UUID    `gorm:"type:uuid;primaryKey"`
+	Token     string       `gorm:"not null;unique"`
+	ExpiresAt time.Time    `gorm:"not null;index"`
+	CreatedAt time.Time    `gorm:"not null"`
+	UpdatedAt sql.NullTime `gorm:"default:null"`
+
+	StoreID uuid.UUID `gorm:"type:uuid;not null;index"`
+	Store   Store     `gorm:"foreignKey:StoreID"`
+
+	CustomerID uuid.NullUUID `gorm:"type:uuid;default:null;index"`
+
+	Items []CartItem `gorm:"foreignKey:CartID;constraint:OnDelete:CASCADE"`
+}
+
+type CartItem struct {
+	ID        uuid.UUID    `gorm:"type:uuid;primaryKey"`
+	Quantity  int          `gorm:"not null"`
+	CreatedAt time.Time    `gorm:"not null"`
+	UpdatedAt sql.NullTime `gorm:"default:null"`
+
+	CartID uuid.UUID `gorm:"type:uuid;not null;index"`
+	Cart   Cart      `gorm:"foreignKey:CartID"`
+
+	ProductID uuid.UUID `gorm:"type:uuid;not null;index"`
+	Product   Product   `gorm:"foreignKey:ProductID;constraint:OnDelete:CASCADE"`
+
+	VariantID uuid.NullUUID   `gorm:"type:uuid;default:null;index"`
+	Variant   *ProductVariant `gorm:"foreignKey:VariantID;constraint:OnDelete:CASCADE"`
+}
+
+type CartIdentity struct {
+	Token      string
+	CustomerID string
+}
+
+type AddCartItemPayload struct {
+	ProductID string `json:"productId" binding:"required"`
+	VariantID string `json:"variantId"`
+	Quantity  int    `json:"quantity" binding:"required"`
+}
+
+type UpdateCartItemPayload struct {
+	Quantity int `json:"quantity" binding:"required"`
+}
+
+type CartItemResponse struct {
+	ID          uuid.UUID                     `json:"id"`
+	Quantity    int                           `json:"quantity"`
+	UnitPrice   float64                       `json:"unitPrice"`
+	Subtotal    float64                       `json:"subtotal"`
+	IsAvailable bool                          `json:"isAvailable"`
+	Product     PublicProductResponse         `json:"product"`
+	Variant     *PublicProductVariantResponse `json:"variant,omitempty"`
+}
+
+type CartResponse struct {
+	ID         uuid.UUID          `json:"id"`
+	Token      string             `json:"token"`
+	ExpiresAt  time.Time          `json:"expiresAt"`
+	ItemsCount int                `json:"itemsCount"`
+	Total      float64            `json:"total"`
+	Items      []CartItemResponse `json:"items"`
+}
+
+func NewCart(storeID, token, customerID string, expiresAt time.Time) (*Cart, error) {
+	storeUUID, err := uuid.Parse(storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	cart := &Cart{
+		ID:        uuid.New(),
+		Token:     token,
+		ExpiresAt: expiresAt,
+		CreatedAt: time.Now(),
+		StoreID:   storeUUID,
+	}
+
+	if customerID != "" {
+		customerUUID, err := uuid.Parse(customerID)
+		if err != nil {
+			return nil, err
+		}
+
+		cart.CustomerID = uuid.NullUUID{UUID: customerUUID, Valid: true}
+	}
+
+	return cart, nil
+}
+
+func NewCartItem(cartID uuid.UUID, productID uuid.UUID, variantID uuid.NullUUID, quantity int) *CartItem {
+	return &CartItem{
+		ID:        uuid.New(),
+		CartID:    cartID,
+		ProductID: productID,
+		VariantID: variantID,
+		Quantity:  quantity,
+		CreatedAt: time.Now(),
+	}
+}
+
+func (c *Cart) IsExpired() bool {
+	return time.Now().After(c.ExpiresAt)
+}
+
+func (c *Cart) FindItem(productID uuid.UUID, variantID uuid.NullUUID) *CartItem {
+	for i := range c.Items {
+		if c.Items[i].ProductID == productID && c.Items[i].VariantID == variantID {
+			return &c.Items[i]
+		}
+	}
+
+	return nil
+}
+
+func (c *Cart) FindItemByID(itemID string) *CartItem {
+	for i := range c.Items {
+		if c.Items[i].ID.String() == itemID {
+			return &c.Items[i]
+		}
+	}
+
+	return nil
+}
+
+func (c *Cart) TotalInCents() int64 {
+	var total int64
+	for _, item := range c.Items {
+		if item.Product.IsArchived {
+			continue
+		}
+
+		total += item.UnitPriceInCents() * int64(item.Quantity)
+	}
+
+	return total
+}
+
+func (i *CartItem) UnitPriceInCents() int64 {
+	if i.Variant != nil {
+		return i.Variant.EffectivePriceInCents(&i.Product)
+	}
+
+	return i.Product.PriceInCents
+}
+
+func (c *Cart) ToCartResponse() *CartResponse {
+	items := make([]CartItemResponse, len(c.Items))
+	itemsCount := 0
+
+	for i, item := range c.Items {
+		items[i] = CartItemResponse{
+			ID:          item.ID,
+			Quantity:    item.Quantity,
+			UnitPrice:   float64(item.UnitPriceInCents()) / 100,
+			Subtotal:    float64(item.UnitPriceInCents()*int64(item.Quantity)) / 100,
+			IsAvailable: !item.Product.IsArchived,
+			Product:     item.Product.ToPublicProductResponse(),
+		}
+
+		if item.Variant != nil {
+			variant := item.Variant.ToPublicProductVariantResponse(&item.Product)
+			items[i].Variant = &variant
+		}
+
+		if !item.Product.IsArchived {
+			itemsCount += item.Quantity
+		}
+	}
+
+	return &CartResponse{
+		ID:         c.ID,
+		Token:      c.Token,
+		ExpiresAt:  c.ExpiresAt,
+		ItemsCount: itemsCount,
+		Total:      float64(c.TotalInCents()) / 100,
+		Items:      items,
+	}
+}
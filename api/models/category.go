@@ -10,18 +10,28 @@ import (
 )
 
 var (
-	ErrCategoryNotFound = errors.New("category not found")
+	ErrCategoryNotFound      = errors.New("category not found")
+	ErrCategoryCycle         = errors.New("category can not be moved into itself or a descendant")
+	ErrCategoryHasChildren   = errors.New("category has children")
+	ErrInvalidCategoryOrder  = errors.New("invalid category order")
+	ErrInvalidCategoryParent = errors.New("invalid category parent")
 )
 
+const CategoryOrder = "position, name"
+
 type Category struct {
 	ID        uuid.UUID    `gorm:"type:uuid;primaryKey"`
 	Name      string       `gorm:"not null"`
+	Position  int          `gorm:"not null;default:0"`
 	CreatedAt time.Time    `gorm:"not null"`
 	UpdatedAt sql.NullTime `gorm:"default:null"`
 
 	StoreID uuid.UUID `gorm:"type:uuid;not null;index"`
 	Store   Store     `gorm:"foreignKey:StoreID"`
 
+	ParentID uuid.NullUUID `gorm:"type:uuid;index"`
+	Parent   *Category     `gorm:"foreignKey:ParentID"`
+
 	BillboardID uuid.UUID `gorm:"type:uuid;not null;index"`
 	Billboard   Billboard `gorm:"foreignKey:BillboardID"`
 }
@@ -33,18 +43,44 @@ type CategoryPagination struct {
 }
 
 type CreateCategoryPayload struct {
-	Name        string `json:"name" binding:"required"`
-	BillboardID string `json:"billboardId" binding:"required"`
+	Name        string     `json:"name" binding:"required"`
+	BillboardID string     `json:"billboardId" binding:"required"`
+	ParentID    *uuid.UUID `json:"parentId"`
+}
+
+type MoveCategoryPayload struct {
+	ParentID *uuid.UUID `json:"parentId"`
+	Position *int       `json:"position"`
+}
+
+type ReorderCategoriesPayload struct {
+	ParentID    *uuid.UUID  `json:"parentId"`
+	CategoryIDs []uuid.UUID `json:"categoryIds"`
 }
 
 type CategoryResponse struct {
-	ID        uuid.UUID `json:"id"`
-	Name      string    `json:"name"`
-	CreatedAt time.Time `json:"createdAt"`
+	ID        uuid.UUID  `json:"id"`
+	Name      string     `json:"name"`
+	ParentID  *uuid.UUID `json:"parentId"`
+	Position  int        `json:"position"`
+	CreatedAt time.Time  `json:"createdAt"`
 
 	BillboardResponse BillboardBasicResponse `json:"billboard"`
 }
 
+type CategoryTreeResponse struct {
+	ID          uuid.UUID              `json:"id"`
+	Name        string                 `json:"name"`
+	Position    int                    `json:"position"`
+	BillboardID uuid.UUID              `json:"billboardId"`
+	Children    []CategoryTreeResponse `json:"children"`
+}
+
+type CategoryBasicResponse struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+}
+
 func (p *CreateCategoryPayload) ToCategory(storeID string) (*Category, error) {
 	storeUUID, err := uuid.Parse(storeID)
 	if err != nil {
@@ -56,22 +92,66 @@ func (p *CreateCategoryPayload) ToCategory(storeID string) (*Category, error) {
 		return nil, fmt.Errorf("invalid billboardID: %w", err)
 	}
 
-	return &Category{
+	category := &Category{
 		ID:          uuid.New(),
 		Name:        p.Name,
 		StoreID:     storeUUID,
 		BillboardID: billboardUUID,
 		CreatedAt:   time.Now(),
-	}, nil
+	}
+
+	if p.ParentID != nil {
+		category.ParentID = uuid.NullUUID{UUID: *p.ParentID, Valid: true}
+	}
+
+	return category, nil
 }
 
 func (c *Category) ToCategoryResponse() *CategoryResponse {
-	return &CategoryResponse{
+	resp := &CategoryResponse{
 		ID:                c.ID,
 		Name:              c.Name,
+		Position:          c.Position,
 		CreatedAt:         c.CreatedAt,
 		BillboardResponse: c.Billboard.ToBillboardBasicResponse(),
 	}
+
+	if c.ParentID.Valid {
+		resp.ParentID = &c.ParentID.UUID
+	}
+
+	return resp
+}
+
+func BuildCategoryTree(categories []Category) []CategoryTreeResponse {
+	children := make(map[uuid.NullUUID][]Category, len(categories))
+	for _, category := range categories {
+		children[category.ParentID] = append(children[category.ParentID], category)
+	}
+
+	var build func(parentID uuid.NullUUID) []CategoryTreeResponse
+	build = func(parentID uuid.NullUUID) []CategoryTreeResponse {
+		nodes := make([]CategoryTreeResponse, len(children[parentID]))
+		for i, category := range children[parentID] {
+			nodes[i] = CategoryTreeResponse{
+				ID:          category.ID,
+				Name:        category.Name,
+				Position:    category.Position,
+				BillboardID: category.BillboardID,
+				Children:    build(uuid.NullUUID{UUID: category.ID, Valid: true}),
+			}
+		}
+		return nodes
+	}
+
+	return build(uuid.NullUUID{})
+}
+
+func (c *Category) ToCategoryBasicResponse() CategoryBasicResponse {
+	return CategoryBasicResponse{
+		ID:   c.ID,
+		Name: c.Name,
+	}
 }
 
 func NewCategoryPagination(page, limit string, name, billboardID *string) *CategoryPagination {
@@ -3,3 +3,13 @@ package models
 type VerificationEmailData struct {
 	Code string
 }
+
+type MagicLinkEmailData struct {
+	Link string
+}
+
+type StoreInvitationEmailData struct {
+	StoreName string
+	Role      StoreRole
+	Link      string
+}
@@ -0,0 +1,265 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrFlashSaleNotFound         = errors.New("flash sale not found")
+	ErrInvalidFlashSale          = errors.New("invalid flash sale")
+	ErrFlashSaleAlreadyStarted   = errors.New("flash sale already started")
+	ErrFlashSaleUnavailable      = errors.New("flash sale sold out or ended")
+	ErrFlashSaleCustomerLimit    = errors.New("flash sale customer limit exceeded")
+	ErrFlashSaleLoginRequired    = errors.New("flash sale requires an authenticated customer")
+	ErrFlashSaleProductDuplicate = errors.New("flash sale product duplicated")
+)
+
+type FlashSaleStatus string
+
+const (
+	FlashSaleStatusScheduled FlashSaleStatus = "scheduled"
+	FlashSaleStatusLive      FlashSaleStatus = "live"
+	FlashSaleStatusSoldOut   FlashSaleStatus = "sold_out"
+	FlashSaleStatusEnded     FlashSaleStatus = "ended"
+)
+
+type FlashSale struct {
+	ID               uuid.UUID    `gorm:"type:uuid;primaryKey"`
+	Name             string       `gorm:"not null"`
+	StartsAt         time.Time    `gorm:"not null;index"`
+	EndsAt           time.Time    `gorm:"not null;index"`
+	QuantityLimit    int64        `gorm:"not null"`
+	SoldQuantity     int64        `gorm:"not null;default:0;check:sold_quantity >= 0"`
+	PerCustomerLimit int64        `gorm:"not null"`
+	CreatedAt        time.Time    `gorm:"not null"`
+	UpdatedAt        sql.NullTime `gorm:"default:null"`
+
+	StoreID uuid.UUID `gorm:"type:uuid;not null;index"`
+	Store   Store     `gorm:"foreignKey:StoreID"`
+
+	Items []FlashSaleItem `gorm:"foreignKey:FlashSaleID;constraint:OnDelete:CASCADE"`
+}
+
+type FlashSaleItem struct {
+	ID               uuid.UUID `gorm:"type:uuid;primaryKey"`
+	SalePriceInCents int64     `gorm:"not null"`
+	CreatedAt        time.Time `gorm:"not null"`
+
+	FlashSaleID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_flash_sale_product"`
+	FlashSale   FlashSale `gorm:"foreignKey:FlashSaleID"`
+
+	ProductID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_flash_sale_product;index"`
+	Product   Product   `gorm:"foreignKey:ProductID;constraint:OnDelete:CASCADE"`
+}
+
+// Único por (flash_sale_id, customer_key) para o limite por cliente caber num upsert condicional
+type FlashSalePurchase struct {
+	ID          uuid.UUID    `gorm:"type:uuid;primaryKey"`
+	CustomerKey string       `gorm:"not null;uniqueIndex:idx_flash_sale_customer"`
+	Quantity    int64        `gorm:"not null;check:quantity >= 0"`
+	CreatedAt   time.Time    `gorm:"not null"`
+	UpdatedAt   sql.NullTime `gorm:"default:null"`
+
+	FlashSaleID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_flash_sale_customer"`
+	FlashSale   FlashSale `gorm:"foreignKey:FlashSaleID;constraint:OnDelete:CASCADE"`
+}
+
+type FlashSalePagination struct {
+	*Pagination
+	Name *string
+}
+
+type CreateFlashSalePayload struct {
+	Name             string                       `json:"name" binding:"required"`
+	StartsAt         time.Time                    `json:"startsAt" binding:"required"`
+	EndsAt           time.Time                    `json:"endsAt" binding:"required"`
+	QuantityLimit    int64                        `json:"quantityLimit" binding:"required"`
+	PerCustomerLimit int64                        `json:"perCustomerLimit" binding:"required"`
+	Items            []CreateFlashSaleItemPayload `json:"items" binding:"required"`
+}
+
+type CreateFlashSaleItemPayload struct {
+	ProductID string  `json:"productId" binding:"required"`
+	SalePrice float64 `json:"salePrice" binding:"required"`
+}
+
+type FlashSaleItemResponse struct {
+	ProductID     uuid.UUID `json:"productId"`
+	ProductName   string    `json:"productName"`
+	OriginalPrice float64   `json:"originalPrice"`
+	SalePrice     float64   `json:"salePrice"`
+}
+
+type FlashSaleResponse struct {
+	ID                uuid.UUID               `json:"id"`
+	Name              string                  `json:"name"`
+	Status            FlashSaleStatus         `json:"status"`
+	StartsAt          time.Time               `json:"startsAt"`
+	EndsAt            time.Time               `json:"endsAt"`
+	QuantityLimit     int64                   `json:"quantityLimit"`
+	SoldQuantity      int64                   `json:"soldQuantity"`
+	RemainingQuantity int64                   `json:"remainingQuantity"`
+	PerCustomerLimit  int64                   `json:"perCustomerLimit"`
+	CreatedAt         time.Time               `json:"createdAt"`
+	Items             []FlashSaleItemResponse `json:"items"`
+}
+
+type PublicFlashSaleResponse struct {
+	ID                uuid.UUID               `json:"id"`
+	Name              string                  `json:"name"`
+	Status            FlashSaleStatus         `json:"status"`
+	StartsAt          time.Time               `json:"startsAt"`
+	EndsAt            time.Time               `json:"endsAt"`
+	RemainingQuantity int64                   `json:"remainingQuantity"`
+	PerCustomerLimit  int64                   `json:"perCustomerLimit"`
+	Items             []FlashSaleItemResponse `json:"items"`
+}
+
+func (p *CreateFlashSalePayload) ToFlashSale(storeID string) (*FlashSale, error) {
+	storeUUID, err := uuid.Parse(storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSpace(p.Name) == "" || len(p.Items) == 0 {
+		return nil, ErrInvalidFlashSale
+	}
+
+	if !p.EndsAt.After(p.StartsAt) || !p.EndsAt.After(time.Now()) {
+		return nil, ErrInvalidFlashSale
+	}
+
+	if p.QuantityLimit <= 0 || p.PerCustomerLimit <= 0 || p.PerCustomerLimit > p.QuantityLimit {
+		return nil, ErrInvalidFlashSale
+	}
+
+	now := time.Now()
+	sale := &FlashSale{
+		ID:               uuid.New(),
+		Name:             p.Name,
+		StartsAt:         p.StartsAt,
+		EndsAt:           p.EndsAt,
+		QuantityLimit:    p.QuantityLimit,
+		PerCustomerLimit: p.PerCustomerLimit,
+		CreatedAt:        now,
+		StoreID:          storeUUID,
+		Items:            make([]FlashSaleItem, len(p.Items)),
+	}
+
+	seen := make(map[uuid.UUID]bool, len(p.Items))
+	for i, item := range p.Items {
+		productUUID, err := uuid.Parse(item.ProductID)
+		if err != nil {
+			return nil, err
+		}
+
+		if seen[productUUID] {
+			return nil, ErrFlashSaleProductDuplicate
+		}
+		seen[productUUID] = true
+
+		if item.SalePrice <= 0 {
+			return nil, ErrInvalidFlashSale
+		}
+
+		sale.Items[i] = FlashSaleItem{
+			ID:               uuid.New(),
+			FlashSaleID:      sale.ID,
+			ProductID:        productUUID,
+			SalePriceInCents: toCents(item.SalePrice),
+			CreatedAt:        now,
+		}
+	}
+
+	return sale, nil
+}
+
+func (f *FlashSale) RemainingQuantity() int64 {
+	remaining := f.QuantityLimit - f.SoldQuantity
+	if remaining < 0 {
+		return 0
+	}
+
+	return remaining
+}
+
+func (f *FlashSale) StatusAt(now time.Time) FlashSaleStatus {
+	if now.Before(f.StartsAt) {
+		return FlashSaleStatusScheduled
+	}
+
+	if !now.Before(f.EndsAt) {
+		return FlashSaleStatusEnded
+	}
+
+	if f.RemainingQuantity() == 0 {
+		return FlashSaleStatusSoldOut
+	}
+
+	return FlashSaleStatusLive
+}
+
+func (f *FlashSale) ToFlashSaleResponse() *FlashSaleResponse {
+	return &FlashSaleResponse{
+		ID:                f.ID,
+		Name:              f.Name,
+		Status:            f.StatusAt(time.Now()),
+		StartsAt:          f.StartsAt,
+		EndsAt:            f.EndsAt,
+		QuantityLimit:     f.QuantityLimit,
+		SoldQuantity:      f.SoldQuantity,
+		RemainingQuantity: f.RemainingQuantity(),
+		PerCustomerLimit:  f.PerCustomerLimit,
+		CreatedAt:         f.CreatedAt,
+		Items:             f.toFlashSaleItemResponseList(),
+	}
+}
+
+func (f *FlashSale) ToPublicFlashSaleResponse() PublicFlashSaleResponse {
+	return PublicFlashSaleResponse{
+		ID:                f.ID,
+		Name:              f.Name,
+		Status:            f.StatusAt(time.Now()),
+		StartsAt:          f.StartsAt,
+		EndsAt:            f.EndsAt,
+		RemainingQuantity: f.RemainingQuantity(),
+		PerCustomerLimit:  f.PerCustomerLimit,
+		Items:             f.toFlashSaleItemResponseList(),
+	}
+}
+
+func (f *FlashSale) toFlashSaleItemResponseList() []FlashSaleItemResponse {
+	items := make([]FlashSaleItemResponse, len(f.Items))
+	for i, item := range f.Items {
+		items[i] = FlashSaleItemResponse{
+			ProductID:     item.ProductID,
+			ProductName:   item.Product.Name,
+			OriginalPrice: float64(item.Product.PriceInCents) / 100,
+			SalePrice:     float64(item.SalePriceInCents) / 100,
+		}
+	}
+
+	return items
+}
+
+// A chave por e-mail resta apenas para liberar pedidos antigos
+func FlashSaleCustomerKey(order *Order) string {
+	if order.CustomerID.Valid {
+		return fmt.Sprintf("user:%s", order.CustomerID.UUID)
+	}
+
+	return fmt.Sprintf("email:%s", strings.ToLower(strings.TrimSpace(order.CustomerEmail)))
+}
+
+func NewFlashSalePagination(page, limit string, name *string) *FlashSalePagination {
+	return &FlashSalePagination{
+		Pagination: NewPagination(page, limit),
+		Name:       name,
+	}
+}
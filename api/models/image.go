@@ -0,0 +1,15 @@
+package models
+
+import "errors"
+
+var (
+	ErrInvalidImage         = errors.New("invalid image")
+	ErrImageTooLarge        = errors.New("image too large")
+	ErrUnsupportedImageType = errors.New("unsupported image type")
+)
+
+type ImageRenditions struct {
+	Thumbnail string `json:"thumbnail"`
+	Card      string `json:"card"`
+	Full      string `json:"full"`
+}
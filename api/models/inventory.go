@@ -0,0 +1,119 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInsufficientStock      = errors.New("insufficient stock")
+	ErrInvalidStockAdjustment = errors.New("invalid stock adjustment")
+)
+
+type StockAdjustmentType string
+
+const (
+	StockAdjustmentInitial StockAdjustmentType = "initial"
+	StockAdjustmentManual  StockAdjustmentType = "manual"
+	StockAdjustmentSale    StockAdjustmentType = "sale"
+	StockAdjustmentCancel  StockAdjustmentType = "cancel"
+)
+
+// Apenas inserção, nunca alterado ou removido
+type StockAdjustment struct {
+	ID         uuid.UUID           `gorm:"type:uuid;primaryKey"`
+	Type       StockAdjustmentType `gorm:"type:varchar(20);not null"`
+	Delta      int64               `gorm:"not null"`
+	StockAfter int64               `gorm:"not null"`
+	Reason     string              `gorm:"not null"`
+	CreatedAt  time.Time           `gorm:"not null;index"`
+
+	ProductID uuid.UUID     `gorm:"type:uuid;not null;index"`
+	VariantID uuid.NullUUID `gorm:"type:uuid;default:null;index"`
+	StoreID   uuid.UUID     `gorm:"type:uuid;not null;index"`
+
+	UserID  uuid.NullUUID `gorm:"type:uuid;default:null"`
+	OrderID uuid.NullUUID `gorm:"type:uuid;default:null;index"`
+}
+
+type AdjustStockPayload struct {
+	VariantID string `json:"variantId"`
+	Delta     int64  `json:"delta"`
+	Reason    string `json:"reason"`
+}
+
+type StockAdjustmentResponse struct {
+	ID         uuid.UUID           `json:"id"`
+	Type       StockAdjustmentType `json:"type"`
+	Delta      int64               `json:"delta"`
+	StockAfter int64               `json:"stockAfter"`
+	Reason     string              `json:"reason"`
+	ProductID  uuid.UUID           `json:"productId"`
+	VariantID  *uuid.UUID          `json:"variantId,omitempty"`
+	UserID     *uuid.UUID          `json:"userId,omitempty"`
+	OrderID    *uuid.UUID          `json:"orderId,omitempty"`
+	CreatedAt  time.Time           `json:"createdAt"`
+}
+
+func NewStockAdjustment(product *Product, adjustmentType StockAdjustmentType, delta int64, reason string) *StockAdjustment {
+	return &StockAdjustment{
+		ID:         uuid.New(),
+		Type:       adjustmentType,
+		Delta:      delta,
+		StockAfter: product.Stock,
+		Reason:     reason,
+		CreatedAt:  time.Now(),
+		ProductID:  product.ID,
+		StoreID:    product.StoreID,
+	}
+}
+
+func NewVariantStockAdjustment(variant *ProductVariant, adjustmentType StockAdjustmentType, delta int64, reason string) *StockAdjustment {
+	return &StockAdjustment{
+		ID:         uuid.New(),
+		Type:       adjustmentType,
+		Delta:      delta,
+		StockAfter: variant.Stock,
+		Reason:     reason,
+		CreatedAt:  time.Now(),
+		ProductID:  variant.ProductID,
+		VariantID:  uuid.NullUUID{UUID: variant.ID, Valid: true},
+		StoreID:    variant.StoreID,
+	}
+}
+
+func (p *AdjustStockPayload) Validate() error {
+	if p.Delta == 0 || p.Reason == "" {
+		return ErrInvalidStockAdjustment
+	}
+
+	return nil
+}
+
+func (s *StockAdjustment) ToStockAdjustmentResponse() StockAdjustmentResponse {
+	resp := StockAdjustmentResponse{
+		ID:         s.ID,
+		Type:       s.Type,
+		Delta:      s.Delta,
+		StockAfter: s.StockAfter,
+		Reason:     s.Reason,
+		ProductID:  s.ProductID,
+		CreatedAt:  s.CreatedAt,
+	}
+
+	if s.VariantID.Valid {
+		resp.VariantID = &s.VariantID.UUID
+	}
+
+	if s.UserID.Valid {
+		resp.UserID = &s.UserID.UUID
+	}
+
+	if s.OrderID.Valid {
+		resp.OrderID = &s.OrderID.UUID
+	}
+
+	return resp
+}
@@ -0,0 +1,80 @@
+package models
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrJobPermanent = errors.New("permanent job failure")
+
+var ErrJobNotFound = errors.New("job not found")
+
+type JobType string
+
+const (
+	JobTypeVerificationEmail    JobType = "email.verification"
+	JobTypeMagicLinkEmail       JobType = "email.magic_link"
+	JobTypeStoreInvitationEmail JobType = "email.store_invitation"
+	JobTypeProductImageUpload   JobType = "product_image.upload"
+)
+
+type JobStatus string
+
+const (
+	JobStatusPending JobStatus = "pending"
+	JobStatusRunning JobStatus = "running"
+	JobStatusDead    JobStatus = "dead"
+)
+
+type Job struct {
+	ID          uuid.UUID       `gorm:"type:uuid;primaryKey"`
+	Type        JobType         `gorm:"not null"`
+	Payload     json.RawMessage `gorm:"type:jsonb;not null"`
+	Status      JobStatus       `gorm:"not null;index:idx_jobs_due,priority:1"`
+	Attempts    int             `gorm:"not null;default:0"`
+	MaxAttempts int             `gorm:"not null"`
+	RunAt       time.Time       `gorm:"not null;index:idx_jobs_due,priority:2"`
+	LockedUntil sql.NullTime    `gorm:"default:null"`
+	LastError   string          `gorm:"not null;default:''"`
+	CreatedAt   time.Time       `gorm:"not null"`
+}
+
+type VerificationEmailJob struct {
+	Email string `json:"email"`
+	Code  string `json:"code"`
+}
+
+type MagicLinkEmailJob struct {
+	Email string `json:"email"`
+	Link  string `json:"link"`
+}
+
+// O token do convite é emitido no envio e nunca fica gravado na fila
+type StoreInvitationEmailJob struct {
+	InvitationID uuid.UUID                `json:"invitationId"`
+	Email        string                   `json:"email"`
+	Data         StoreInvitationEmailData `json:"data"`
+}
+
+type ProductImageUploadJob struct {
+	ImageID   uuid.UUID `json:"imageId"`
+	ProductID uuid.UUID `json:"productId"`
+	Filename  string    `json:"filename"`
+	Content   []byte    `json:"content"`
+}
+
+func NewJob(jobType JobType, payload json.RawMessage, maxAttempts int, runAt time.Time) *Job {
+	return &Job{
+		ID:          uuid.New(),
+		Type:        jobType,
+		Payload:     payload,
+		Status:      JobStatusPending,
+		MaxAttempts: maxAttempts,
+		RunAt:       runAt,
+		CreatedAt:   time.Now(),
+	}
+}
@@ -1,5 +1,28 @@
 package models
 
+import "errors"
+
+var ErrInvalidLoginMethod = errors.New("invalid login method")
+
+type LoginMethod string
+
+const (
+	LoginMethodCode      LoginMethod = "code"
+	LoginMethodMagicLink LoginMethod = "magic_link"
+)
+
 type LoginPayload struct {
-	Email string `json:"email"`
+	Email  string      `json:"email"`
+	Method LoginMethod `json:"method"`
+}
+
+func (m LoginMethod) Flow() (OTPFlow, bool) {
+	switch m {
+	case "", LoginMethodCode:
+		return UserVerificationFLow, true
+	case LoginMethodMagicLink:
+		return MagicLinkFlow, true
+	default:
+		return "", false
+	}
 }
@@ -0,0 +1,281 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrOrderNotFound           = errors.New("order not found")
+	ErrInvalidOrderStatus      = errors.New("invalid order status")
+	ErrInvalidStatusTransition = errors.New("invalid order status transition")
+	ErrCartEmpty               = errors.New("cart empty")
+	ErrStatusManagedByPayment  = errors.New("order status managed by payment provider")
+	ErrOrderWithoutPayment     = errors.New("order without payment")
+	ErrPaymentUnavailable      = errors.New("payment unavailable")
+	ErrPaymentAmountMismatch   = errors.New("payment amount mismatch")
+)
+
+type OrderStatus string
+
+const (
+	OrderStatusPending   OrderStatus = "pending"
+	OrderStatusPaid      OrderStatus = "paid"
+	OrderStatusFulfilled OrderStatus = "fulfilled"
+	OrderStatusDelivered OrderStatus = "delivered"
+	OrderStatusCancelled OrderStatus = "cancelled"
+	OrderStatusRefunded  OrderStatus = "refunded"
+)
+
+// Status ausentes do mapa são finais
+var orderStatusTransitions = map[OrderStatus][]OrderStatus{
+	OrderStatusPending:   {OrderStatusPaid, OrderStatusCancelled},
+	OrderStatusPaid:      {OrderStatusFulfilled, OrderStatusRefunded},
+	OrderStatusFulfilled: {OrderStatusDelivered, OrderStatusRefunded},
+	OrderStatusDelivered: {OrderStatusRefunded},
+}
+
+type Order struct {
+	ID            uuid.UUID      `gorm:"type:uuid;primaryKey"`
+	Status        OrderStatus    `gorm:"type:varchar(20);not null;index"`
+	TotalInCents  int64          `gorm:"not null"`
+	CustomerName  string         `gorm:"not null"`
+	CustomerEmail string         `gorm:"not null;index"`
+	Phone         string         `gorm:"not null"`
+	Address       string         `gorm:"not null"`
+	PaymentID     sql.NullString `gorm:"default:null;index"`
+	CreatedAt     time.Time      `gorm:"not null"`
+	UpdatedAt     sql.NullTime   `gorm:"default:null"`
+
+	StoreID uuid.UUID `gorm:"type:uuid;not null;index"`
+	Store   Store     `gorm:"foreignKey:StoreID"`
+
+	CustomerID uuid.NullUUID `gorm:"type:uuid;default:null;index"`
+
+	Items []OrderItem `gorm:"foreignKey:OrderID;constraint:OnDelete:CASCADE"`
+}
+
+type OrderItem struct {
+	ID               uuid.UUID `gorm:"type:uuid;primaryKey"`
+	ProductID        uuid.UUID `gorm:"type:uuid;not null;index"`
+	ProductName      string    `gorm:"not null"`
+	UnitPriceInCents int64     `gorm:"not null"`
+	Quantity         int       `gorm:"not null"`
+	CreatedAt        time.Time `gorm:"not null"`
+
+	OrderID uuid.UUID `gorm:"type:uuid;not null;index"`
+
+	VariantID  uuid.NullUUID `gorm:"type:uuid;default:null;index"`
+	VariantSKU string        `gorm:"not null;default:''"`
+
+	FlashSaleID uuid.NullUUID `gorm:"type:uuid;default:null;index"`
+}
+
+type OrderPagination struct {
+	*Pagination
+	Status        *string
+	CustomerEmail *string
+}
+
+type CheckoutPayload struct {
+	CustomerName  string `json:"customerName" binding:"required"`
+	CustomerEmail string `json:"customerEmail" binding:"required"`
+	Phone         string `json:"phone" binding:"required"`
+	Address       string `json:"address" binding:"required"`
+}
+
+type UpdateOrderStatusPayload struct {
+	Status OrderStatus `json:"status" binding:"required"`
+}
+
+type PaymentIntentResponse struct {
+	ID           string `json:"id"`
+	ClientSecret string `json:"clientSecret"`
+	CheckoutURL  string `json:"checkoutUrl"`
+}
+
+type CheckoutResponse struct {
+	Order   *OrderResponse         `json:"order"`
+	Payment *PaymentIntentResponse `json:"payment"`
+}
+
+type OrderItemResponse struct {
+	ID          uuid.UUID  `json:"id"`
+	ProductID   uuid.UUID  `json:"productId"`
+	ProductName string     `json:"productName"`
+	UnitPrice   float64    `json:"unitPrice"`
+	Quantity    int        `json:"quantity"`
+	Subtotal    float64    `json:"subtotal"`
+	VariantID   *uuid.UUID `json:"variantId,omitempty"`
+	SKU         string     `json:"sku,omitempty"`
+	FlashSaleID *uuid.UUID `json:"flashSaleId,omitempty"`
+}
+
+type OrderResponse struct {
+	ID            uuid.UUID           `json:"id"`
+	Status        OrderStatus         `json:"status"`
+	Total         float64             `json:"total"`
+	CustomerName  string              `json:"customerName"`
+	CustomerEmail string              `json:"customerEmail"`
+	Phone         string              `json:"phone"`
+	Address       string              `json:"address"`
+	PaymentID     string              `json:"paymentId"`
+	CreatedAt     time.Time           `json:"createdAt"`
+	Items         []OrderItemResponse `json:"items"`
+}
+
+func (s OrderStatus) IsValid() bool {
+	switch s {
+	case OrderStatusPending, OrderStatusPaid, OrderStatusFulfilled,
+		OrderStatusDelivered, OrderStatusCancelled, OrderStatusRefunded:
+		return true
+	}
+
+	return false
+}
+
+// Só alcançados por callbacks verificados do provedor de pagamento
+func (s OrderStatus) IsPaymentManaged() bool {
+	return s == OrderStatusPaid || s == OrderStatusRefunded
+}
+
+func (s OrderStatus) CanTransitionTo(next OrderStatus) bool {
+	for _, allowed := range orderStatusTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (o *Order) TransitionTo(next OrderStatus) error {
+	if !next.IsValid() {
+		return ErrInvalidOrderStatus
+	}
+
+	if !o.Status.CanTransitionTo(next) {
+		return ErrInvalidStatusTransition
+	}
+
+	o.Status = next
+	o.UpdatedAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+	return nil
+}
+
+func NewOrderFromCart(cart *Cart, payload CheckoutPayload) (*Order, error) {
+	if len(cart.Items) == 0 {
+		return nil, ErrCartEmpty
+	}
+
+	now := time.Now()
+	order := &Order{
+		ID:            uuid.New(),
+		Status:        OrderStatusPending,
+		CustomerName:  payload.CustomerName,
+		CustomerEmail: payload.CustomerEmail,
+		Phone:         payload.Phone,
+		Address:       payload.Address,
+		CreatedAt:     now,
+		StoreID:       cart.StoreID,
+		CustomerID:    cart.CustomerID,
+		Items:         make([]OrderItem, len(cart.Items)),
+	}
+
+	for i, item := range cart.Items {
+		if item.Product.IsArchived {
+			return nil, ErrProductArchived
+		}
+
+		order.Items[i] = OrderItem{
+			ID:               uuid.New(),
+			OrderID:          order.ID,
+			ProductID:        item.ProductID,
+			ProductName:      item.Product.Name,
+			UnitPriceInCents: item.UnitPriceInCents(),
+			Quantity:         item.Quantity,
+			CreatedAt:        now,
+			VariantID:        item.VariantID,
+		}
+
+		if item.Variant != nil {
+			order.Items[i].VariantSKU = item.Variant.SKU
+		}
+
+		order.TotalInCents += order.Items[i].UnitPriceInCents * int64(item.Quantity)
+	}
+
+	return order, nil
+}
+
+// A linha pode já ter o preço menor de uma variante
+func (o *Order) ApplyFlashSale(items map[uuid.UUID]FlashSaleItem) {
+	o.TotalInCents = 0
+	for i := range o.Items {
+		if saleItem, ok := items[o.Items[i].ProductID]; ok && saleItem.SalePriceInCents < o.Items[i].UnitPriceInCents {
+			o.Items[i].UnitPriceInCents = saleItem.SalePriceInCents
+			o.Items[i].FlashSaleID = uuid.NullUUID{UUID: saleItem.FlashSaleID, Valid: true}
+		}
+
+		o.TotalInCents += o.Items[i].UnitPriceInCents * int64(o.Items[i].Quantity)
+	}
+}
+
+func (o *Order) FlashSaleQuantities() map[uuid.UUID]int64 {
+	quantities := make(map[uuid.UUID]int64)
+	for _, item := range o.Items {
+		if item.FlashSaleID.Valid {
+			quantities[item.FlashSaleID.UUID] += int64(item.Quantity)
+		}
+	}
+
+	return quantities
+}
+
+func (o *Order) ToOrderResponse() *OrderResponse {
+	items := make([]OrderItemResponse, len(o.Items))
+	for i, item := range o.Items {
+		items[i] = OrderItemResponse{
+			ID:          item.ID,
+			ProductID:   item.ProductID,
+			ProductName: item.ProductName,
+			UnitPrice:   float64(item.UnitPriceInCents) / 100,
+			Quantity:    item.Quantity,
+			Subtotal:    float64(item.UnitPriceInCents*int64(item.Quantity)) / 100,
+		}
+
+		if item.VariantID.Valid {
+			items[i].VariantID = &item.VariantID.UUID
+			items[i].SKU = item.VariantSKU
+		}
+
+		if item.FlashSaleID.Valid {
+			items[i].FlashSaleID = &item.FlashSaleID.UUID
+		}
+	}
+
+	return &OrderResponse{
+		ID:            o.ID,
+		Status:        o.Status,
+		Total:         float64(o.TotalInCents) / 100,
+		CustomerName:  o.CustomerName,
+		CustomerEmail: o.CustomerEmail,
+		Phone:         o.Phone,
+		Address:       o.Address,
+		PaymentID:     o.PaymentID.String,
+		CreatedAt:     o.CreatedAt,
+		Items:         items,
+	}
+}
+
+func NewOrderPagination(page, limit string, status, customerEmail *string) *OrderPagination {
+	return &OrderPagination{
+		Pagination:    NewPagination(page, limit),
+		Status:        status,
+		CustomerEmail: customerEmail,
+	}
+}
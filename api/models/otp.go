@@ -11,12 +11,19 @@ var (
 	ErrOTPNotFound = errors.New("otp not found")
 	ErrOTPInvalid  = errors.New("otp invalid")
 	ErrOTPExpired  = errors.New("otp expired")
+	ErrOTPLocked   = errors.New("otp locked after too many failed attempts")
+
+	ErrMagicLinkInvalid = errors.New("magic link invalid")
+
+	ErrOTPResendCooldown = errors.New("otp resend cooldown")
+	ErrOTPSendLimit      = errors.New("otp daily send limit reached")
 )
 
 type OTPFlow string
 
 const (
 	UserVerificationFLow OTPFlow = "user_verification"
+	MagicLinkFlow        OTPFlow = "magic_link"
 )
 
 type OTP struct {
@@ -25,6 +32,8 @@ type OTP struct {
 	Flow              OTPFlow   `gorm:"type:varchar(50);not null"`
 	VerificationToken string    `gorm:"not null;unique"`
 	ExpiresAt         time.Time `gorm:"not null"`
+	FailedAttempts    int       `gorm:"not null;default:0"`
+	LastSentAt        time.Time `gorm:"not null;default:CURRENT_TIMESTAMP"`
 
 	UserID uuid.UUID `gorm:"type:uuid;not null"`
 	User   User      `gorm:"foreignKey:UserID"`
@@ -34,10 +43,18 @@ type VerifyOTPPayload struct {
 	Code string `json:"code"`
 }
 
+type VerifyMagicLinkPayload struct {
+	Token string `json:"token"`
+}
+
 func (o *OTP) IsExpired() bool {
 	return time.Now().After(o.ExpiresAt)
 }
 
+func (o *OTP) IsLocked(maxAttempts int) bool {
+	return o.FailedAttempts >= maxAttempts
+}
+
 func NewOTP(userID, code string, flow OTPFlow, expiresAt time.Time, token string) (*OTP, error) {
 	userIDuuid, err := uuid.Parse(userID)
 	if err != nil {
@@ -51,5 +68,6 @@ func NewOTP(userID, code string, flow OTPFlow, expiresAt time.Time, token string
 		VerificationToken: token,
 		Code:              code,
 		ExpiresAt:         expiresAt,
+		LastSentAt:        time.Now(),
 	}, nil
 }
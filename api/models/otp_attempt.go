@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type OTPAttemptType string
+
+const (
+	OTPAttemptSent           OTPAttemptType = "sent"
+	OTPAttemptVerified       OTPAttemptType = "verified"
+	OTPAttemptFailed         OTPAttemptType = "failed"
+	OTPAttemptLocked         OTPAttemptType = "locked"
+	OTPAttemptResendThrottle OTPAttemptType = "resend_throttled"
+)
+
+type OTPAttempt struct {
+	ID        uuid.UUID      `gorm:"type:uuid;primaryKey"`
+	Type      OTPAttemptType `gorm:"type:varchar(30);not null;index"`
+	Flow      OTPFlow        `gorm:"type:varchar(50);not null"`
+	IP        string         `gorm:"not null;default:''"`
+	UserAgent string         `gorm:"not null;default:''"`
+	CreatedAt time.Time      `gorm:"not null;index"`
+
+	OTPID uuid.NullUUID `gorm:"type:uuid;default:null"`
+
+	UserID uuid.UUID `gorm:"type:uuid;not null;index"`
+	User   User      `gorm:"foreignKey:UserID"`
+}
+
+func NewOTPAttempt(otp *OTP, attemptType OTPAttemptType, ssi SessionSecurityInfo) *OTPAttempt {
+	return &OTPAttempt{
+		ID:        uuid.New(),
+		Type:      attemptType,
+		Flow:      otp.Flow,
+		IP:        ssi.IP,
+		UserAgent: ssi.UserAgent,
+		CreatedAt: time.Now(),
+		OTPID:     uuid.NullUUID{UUID: otp.ID, Valid: true},
+		UserID:    otp.UserID,
+	}
+}
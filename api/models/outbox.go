@@ -0,0 +1,62 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type DomainEventType string
+
+const (
+	DomainEventStoreInvitationCreated DomainEventType = "store_invitation.created"
+)
+
+// Gravado na transação da alteração; AvailableAt serve de reserva e de próxima tentativa
+type DomainEvent struct {
+	ID          uuid.UUID       `gorm:"type:uuid;primaryKey"`
+	Type        DomainEventType `gorm:"not null"`
+	StoreID     uuid.NullUUID   `gorm:"type:uuid"`
+	Payload     json.RawMessage `gorm:"type:jsonb;not null"`
+	Attempts    int             `gorm:"not null;default:0"`
+	LastError   string          `gorm:"not null;default:''"`
+	AvailableAt time.Time       `gorm:"not null;index"`
+	CreatedAt   time.Time       `gorm:"not null"`
+}
+
+func (DomainEvent) TableName() string {
+	return "outbox_events"
+}
+
+func NewDomainEvent(eventType DomainEventType, data any) (*DomainEvent, error) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("marshal event data: %w", err)
+	}
+
+	now := time.Now()
+
+	return &DomainEvent{
+		ID:          uuid.New(),
+		Type:        eventType,
+		Payload:     payload,
+		AvailableAt: now,
+		CreatedAt:   now,
+	}, nil
+}
+
+func NewStoreEvent(storeID uuid.UUID, eventType WebhookEventType, data any) (*DomainEvent, error) {
+	event, err := NewDomainEvent(DomainEventType(eventType), data)
+	if err != nil {
+		return nil, err
+	}
+
+	event.StoreID = uuid.NullUUID{UUID: storeID, Valid: true}
+	return event, nil
+}
+
+func (e *DomainEvent) Decode(out any) error {
+	return json.Unmarshal(e.Payload, out)
+}
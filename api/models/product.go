@@ -2,9 +2,19 @@ package models
 
 import (
 	"database/sql"
+	"errors"
+	"math"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
+	jsoniter "github.com/json-iterator/go"
+)
+
+var (
+	ErrProductNotFound          = errors.New("product not found")
+	ErrInvalidProductReference  = errors.New("invalid product category, color or size")
+	ErrProductOptionsOnVariants = errors.New("product with variants cannot have its own color or size")
 )
 
 type Product struct {
@@ -13,6 +23,7 @@ type Product struct {
 	PriceInCents int64        `gorm:"not null"`
 	IsFeatured   bool         `gorm:"not null;default:false"`
 	IsArchived   bool         `gorm:"not null;default:false"`
+	Stock        int64        `gorm:"not null;default:0;check:stock >= 0"`
 	CreatedAt    time.Time    `gorm:"not null"`
 	UpdatedAt    sql.NullTime `gorm:"default:null"`
 
@@ -22,13 +33,27 @@ type Product struct {
 	CategoryID uuid.UUID `gorm:"type:uuid;not null;index"`
 	Category   Category  `gorm:"foreignKey:CategoryID"`
 
-	ColorID uuid.UUID `gorm:"type:uuid;not null;index"`
-	Color   Color     `gorm:"foreignKey:ColorID"`
+	// Só em produtos sem variantes
+	ColorID uuid.NullUUID `gorm:"type:uuid;default:null;index"`
+	Color   *Color        `gorm:"foreignKey:ColorID"`
+
+	SizeID uuid.NullUUID `gorm:"type:uuid;default:null;index"`
+	Size   *Size         `gorm:"foreignKey:SizeID"`
 
-	SizeID uuid.UUID `gorm:"type:uuid;not null;index"`
-	Size   Size      `gorm:"foreignKey:SizeID"`
+	ProductImages []ProductImage   `gorm:"foreignKey:ProductID"`
+	Variants      []ProductVariant `gorm:"foreignKey:ProductID;constraint:OnDelete:CASCADE"`
+}
+
+type ProductPagination struct {
+	*Pagination
+	Name       *string
+	CategoryID *string
+	ColorID    *string
+	SizeID     *string
+	IsFeatured *bool
+	IsArchived *bool
 
-	ProductImages []ProductImage `gorm:"foreignKey:ProductID"`
+	IncludeSubcategories bool
 }
 
 type CreateProductPayload struct {
@@ -37,8 +62,43 @@ type CreateProductPayload struct {
 	IsFeatured bool    `form:"isFeatured"`
 	IsArchived bool    `form:"isArchived"`
 	CategoryID string  `form:"categoryId" binding:"required"`
-	ColorID    string  `form:"colorId" binding:"required"`
-	SizeID     string  `form:"sizeId" binding:"required"`
+	ColorID    string  `form:"colorId"`
+	SizeID     string  `form:"sizeId"`
+	Variants   string  `form:"variants"`
+}
+
+type UpdateProductPayload struct {
+	Name       string  `json:"name" binding:"required"`
+	Price      float64 `json:"price" binding:"required"`
+	IsFeatured bool    `json:"isFeatured"`
+	IsArchived bool    `json:"isArchived"`
+	CategoryID string  `json:"categoryId" binding:"required"`
+	ColorID    string  `json:"colorId"`
+	SizeID     string  `json:"sizeId"`
+}
+
+type ArchiveProductPayload struct {
+	IsArchived bool `json:"isArchived"`
+}
+
+type ProductEventData struct {
+	ID uuid.UUID `json:"id"`
+}
+
+type ProductResponse struct {
+	ID         uuid.UUID `json:"id"`
+	Name       string    `json:"name"`
+	Price      float64   `json:"price"`
+	IsFeatured bool      `json:"isFeatured"`
+	IsArchived bool      `json:"isArchived"`
+	Stock      int64     `json:"stock"`
+	CreatedAt  time.Time `json:"createdAt"`
+
+	Category CategoryBasicResponse    `json:"category"`
+	Colors   []ColorResponse          `json:"colors"`
+	Sizes    []SizeResponse           `json:"sizes"`
+	Images   []ProductImageResponse   `json:"images"`
+	Variants []ProductVariantResponse `json:"variants"`
 }
 
 func (p *CreateProductPayload) ToProduct(storeId string) (*Product, error) {
@@ -52,20 +112,20 @@ func (p *CreateProductPayload) ToProduct(storeId string) (*Product, error) {
 		return nil, err
 	}
 
-	colorUUID, err := uuid.Parse(p.ColorID)
+	colorUUID, err := parseOptionalUUID(p.ColorID)
 	if err != nil {
 		return nil, err
 	}
 
-	sizeUUID, err := uuid.Parse(p.SizeID)
+	sizeUUID, err := parseOptionalUUID(p.SizeID)
 	if err != nil {
 		return nil, err
 	}
 
-	return &Product{
+	product := &Product{
 		ID:           uuid.New(),
 		Name:         p.Name,
-		PriceInCents: int64(p.Price * 100),
+		PriceInCents: toCents(p.Price),
 		IsFeatured:   p.IsFeatured,
 		IsArchived:   p.IsArchived,
 		CreatedAt:    time.Now(),
@@ -73,5 +133,198 @@ func (p *CreateProductPayload) ToProduct(storeId string) (*Product, error) {
 		CategoryID:   categoryUUID,
 		ColorID:      colorUUID,
 		SizeID:       sizeUUID,
-	}, nil
+	}
+
+	if p.Variants != "" {
+		var matrix ProductVariantMatrixPayload
+		if err := jsoniter.UnmarshalFromString(p.Variants, &matrix); err != nil {
+			return nil, err
+		}
+
+		variants, err := matrix.ToProductVariants(product)
+		if err != nil {
+			return nil, err
+		}
+
+		product.Variants = variants
+	}
+
+	if len(product.Variants) > 0 && product.HasOwnOptions() {
+		return nil, ErrProductOptionsOnVariants
+	}
+
+	return product, nil
+}
+
+func (p *UpdateProductPayload) ApplyTo(product *Product) error {
+	categoryUUID, err := uuid.Parse(p.CategoryID)
+	if err != nil {
+		return ErrInvalidProductReference
+	}
+
+	colorUUID, err := parseOptionalUUID(p.ColorID)
+	if err != nil {
+		return ErrInvalidProductReference
+	}
+
+	sizeUUID, err := parseOptionalUUID(p.SizeID)
+	if err != nil {
+		return ErrInvalidProductReference
+	}
+
+	product.Name = p.Name
+	product.PriceInCents = toCents(p.Price)
+	product.IsFeatured = p.IsFeatured
+	product.IsArchived = p.IsArchived
+	product.CategoryID = categoryUUID
+	product.ColorID = colorUUID
+	product.SizeID = sizeUUID
+	product.UpdatedAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+	return nil
+}
+
+func (p *Product) ToProductResponse() *ProductResponse {
+	images := make([]ProductImageResponse, len(p.ProductImages))
+	for i, image := range p.ProductImages {
+		images[i] = image.ToProductImageResponse()
+	}
+
+	variants := make([]ProductVariantResponse, len(p.Variants))
+	for i, variant := range p.Variants {
+		variants[i] = variant.ToProductVariantResponse()
+	}
+
+	colors := p.Colors()
+	colorResponses := make([]ColorResponse, len(colors))
+	for i := range colors {
+		colorResponses[i] = *colors[i].ToColorResponse()
+	}
+
+	sizes := p.Sizes()
+	sizeResponses := make([]SizeResponse, len(sizes))
+	for i := range sizes {
+		sizeResponses[i] = *sizes[i].ToSizeResponse()
+	}
+
+	return &ProductResponse{
+		ID:         p.ID,
+		Name:       p.Name,
+		Price:      float64(p.PriceInCents) / 100,
+		IsFeatured: p.IsFeatured,
+		IsArchived: p.IsArchived,
+		Stock:      p.TotalStock(),
+		CreatedAt:  p.CreatedAt,
+		Category:   p.Category.ToCategoryBasicResponse(),
+		Colors:     colorResponses,
+		Sizes:      sizeResponses,
+		Images:     images,
+		Variants:   variants,
+	}
+}
+
+func (p *Product) HasOwnOptions() bool {
+	return p.ColorID.Valid || p.SizeID.Valid
+}
+
+func (p *Product) Colors() []Color {
+	if len(p.Variants) == 0 {
+		if p.Color == nil {
+			return []Color{}
+		}
+		return []Color{*p.Color}
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	colors := make([]Color, 0, len(p.Variants))
+	for _, variant := range p.Variants {
+		if !seen[variant.ColorID] {
+			seen[variant.ColorID] = true
+			colors = append(colors, variant.Color)
+		}
+	}
+
+	return colors
+}
+
+func (p *Product) Sizes() []Size {
+	if len(p.Variants) == 0 {
+		if p.Size == nil {
+			return []Size{}
+		}
+		return []Size{*p.Size}
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	sizes := make([]Size, 0, len(p.Variants))
+	for _, variant := range p.Variants {
+		if !seen[variant.SizeID] {
+			seen[variant.SizeID] = true
+			sizes = append(sizes, variant.Size)
+		}
+	}
+
+	return sizes
+}
+
+func (p *Product) TotalStock() int64 {
+	if len(p.Variants) == 0 {
+		return p.Stock
+	}
+
+	var total int64
+	for _, variant := range p.Variants {
+		total += variant.Stock
+	}
+
+	return total
+}
+
+func NewProductPagination(page, limit string, name, categoryID, colorID, sizeID, isFeatured, isArchived, includeSubcategories *string) *ProductPagination {
+	pag := &ProductPagination{
+		Pagination: NewPagination(page, limit),
+		Name:       name,
+		CategoryID: categoryID,
+		ColorID:    colorID,
+		SizeID:     sizeID,
+		IsFeatured: parseBoolPointer(isFeatured),
+		IsArchived: parseBoolPointer(isArchived),
+	}
+
+	if include := parseBoolPointer(includeSubcategories); include != nil {
+		pag.IncludeSubcategories = *include
+	}
+
+	return pag
+}
+
+func parseBoolPointer(value *string) *bool {
+	if value == nil {
+		return nil
+	}
+
+	parsed, err := strconv.ParseBool(*value)
+	if err != nil {
+		return nil
+	}
+
+	return &parsed
+}
+
+// 0.29 * 100 vira 28.999999999999996
+func toCents(price float64) int64 {
+	return int64(math.Round(price * 100))
+}
+
+func parseOptionalUUID(value string) (uuid.NullUUID, error) {
+	if value == "" {
+		return uuid.NullUUID{}, nil
+	}
+
+	parsed, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.NullUUID{}, err
+	}
+
+	return uuid.NullUUID{UUID: parsed, Valid: true}, nil
 }
@@ -2,31 +2,84 @@ package models
 
 import (
 	"database/sql"
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+var (
+	ErrProductImageNotFound     = errors.New("product image not found")
+	ErrInvalidProductImageOrder = errors.New("invalid product image order")
+	ErrProductImageNotFailed    = errors.New("product image has not failed")
+	ErrProductImageNotRetryable = errors.New("product image upload can not be retried")
+)
+
+const ProductImageOrder = "position, created_at"
+
+type ProductImageStatus string
+
+const (
+	ProductImageStatusPending ProductImageStatus = "pending"
+	ProductImageStatusReady   ProductImageStatus = "ready"
+	ProductImageStatusFailed  ProductImageStatus = "failed"
+)
+
 type ProductImage struct {
-	ID        uuid.UUID    `gorm:"type:uuid;primaryKey"`
-	ImageURL  string       `gorm:"not null"`
-	CreatedAt time.Time    `gorm:"not null"`
-	UpdatedAt sql.NullTime `gorm:"default:null"`
+	ID         uuid.UUID          `gorm:"type:uuid;primaryKey"`
+	ImageURL   string             `gorm:"not null;default:''"`
+	Renditions ImageRenditions    `gorm:"type:jsonb;serializer:json"`
+	Position   int                `gorm:"not null;default:0"`
+	IsPrimary  bool               `gorm:"not null;default:false"`
+	Status     ProductImageStatus `gorm:"not null;default:'ready'"`
+	LastError  string             `gorm:"not null;default:''"`
+	JobID      uuid.NullUUID      `gorm:"type:uuid"`
+	CreatedAt  time.Time          `gorm:"not null"`
+	UpdatedAt  sql.NullTime       `gorm:"default:null"`
 
 	ProductID uuid.UUID `gorm:"type:uuid;not null;index"`
 	Product   Product   `gorm:"foreignKey:ProductID"`
 }
 
-func NewProductImage(productID, imageURL string) (*ProductImage, error) {
-	productUUID, err := uuid.Parse(productID)
-	if err != nil {
-		return nil, err
+type ReorderProductImagesPayload struct {
+	ImageIDs []uuid.UUID `json:"imageIds"`
+}
+
+type ProductImageResponse struct {
+	ID         uuid.UUID          `json:"id"`
+	ImageURL   string             `json:"imageUrl"`
+	Renditions ImageRenditions    `json:"renditions"`
+	Position   int                `json:"position"`
+	IsPrimary  bool               `json:"isPrimary"`
+	Status     ProductImageStatus `json:"status"`
+	Error      string             `json:"error,omitempty"`
+	CreatedAt  time.Time          `json:"createdAt"`
+}
+
+func (p *ProductImage) ToProductImageResponse() ProductImageResponse {
+	return ProductImageResponse{
+		ID:         p.ID,
+		ImageURL:   p.ImageURL,
+		Renditions: p.Renditions,
+		Position:   p.Position,
+		IsPrimary:  p.IsPrimary,
+		Status:     p.Status,
+		Error:      p.LastError,
+		CreatedAt:  p.CreatedAt,
 	}
+}
 
+func (p *ProductImage) IsReady() bool {
+	return p.Status == ProductImageStatusReady
+}
+
+func NewProductImage(productID uuid.UUID, position int, isPrimary bool) *ProductImage {
 	return &ProductImage{
 		ID:        uuid.New(),
-		ProductID: productUUID,
-		ImageURL:  imageURL,
+		ProductID: productID,
+		Position:  position,
+		IsPrimary: isPrimary,
+		Status:    ProductImageStatusPending,
 		CreatedAt: time.Now(),
-	}, nil
+	}
 }
@@ -0,0 +1,216 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrProductVariantNotFound  = errors.New("product variant not found")
+	ErrInvalidProductVariant   = errors.New("invalid product variant")
+	ErrProductVariantDuplicate = errors.New("product variant duplicated")
+	ErrProductVariantRequired  = errors.New("product variant required")
+	ErrSKUAlreadyExists        = errors.New("sku already exists")
+)
+
+var skuInvalidChars = regexp.MustCompile(`[^A-Z0-9]+`)
+
+type ProductVariant struct {
+	ID           uuid.UUID     `gorm:"type:uuid;primaryKey"`
+	SKU          string        `gorm:"not null;uniqueIndex:idx_product_variant_sku"`
+	PriceInCents sql.NullInt64 `gorm:"default:null"`
+	Stock        int64         `gorm:"not null;default:0;check:stock >= 0"`
+	CreatedAt    time.Time     `gorm:"not null"`
+	UpdatedAt    sql.NullTime  `gorm:"default:null"`
+
+	StoreID   uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_product_variant_sku"`
+	ProductID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_product_variant_options"`
+
+	SizeID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_product_variant_options"`
+	Size   Size      `gorm:"foreignKey:SizeID"`
+
+	ColorID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_product_variant_options"`
+	Color   Color     `gorm:"foreignKey:ColorID"`
+}
+
+type ProductVariantPayload struct {
+	SKU     string   `json:"sku"`
+	SizeID  string   `json:"sizeId"`
+	ColorID string   `json:"colorId"`
+	Price   *float64 `json:"price"`
+	Stock   int64    `json:"stock"`
+}
+
+type ProductVariantMatrixPayload struct {
+	SizeIDs  []string                `json:"sizeIds"`
+	ColorIDs []string                `json:"colorIds"`
+	Price    *float64                `json:"price"`
+	Stock    int64                   `json:"stock"`
+	Variants []ProductVariantPayload `json:"variants"`
+}
+
+type UpdateProductVariantPayload struct {
+	SKU   string   `json:"sku" binding:"required"`
+	Price *float64 `json:"price"`
+}
+
+type ProductVariantResponse struct {
+	ID        uuid.UUID     `json:"id"`
+	SKU       string        `json:"sku"`
+	Price     *float64      `json:"price"`
+	Stock     int64         `json:"stock"`
+	Size      SizeResponse  `json:"size"`
+	Color     ColorResponse `json:"color"`
+	CreatedAt time.Time     `json:"createdAt"`
+}
+
+type PublicProductVariantResponse struct {
+	ID      uuid.UUID           `json:"id"`
+	SKU     string              `json:"sku"`
+	Price   float64             `json:"price"`
+	InStock bool                `json:"inStock"`
+	Size    PublicSizeResponse  `json:"size"`
+	Color   PublicColorResponse `json:"color"`
+}
+
+func (p *ProductVariantMatrixPayload) ToProductVariants(product *Product) ([]ProductVariant, error) {
+	type option struct {
+		sizeID  string
+		colorID string
+	}
+
+	var order []option
+	payloads := make(map[option]ProductVariantPayload)
+
+	add := func(payload ProductVariantPayload) {
+		key := option{sizeID: payload.SizeID, colorID: payload.ColorID}
+		if _, ok := payloads[key]; !ok {
+			order = append(order, key)
+		}
+		payloads[key] = payload
+	}
+
+	for _, sizeID := range p.SizeIDs {
+		for _, colorID := range p.ColorIDs {
+			add(ProductVariantPayload{SizeID: sizeID, ColorID: colorID, Price: p.Price, Stock: p.Stock})
+		}
+	}
+
+	for _, variant := range p.Variants {
+		add(variant)
+	}
+
+	variants := make([]ProductVariant, len(order))
+	for i, key := range order {
+		payload := payloads[key]
+		variant, err := payload.ToProductVariant(product)
+		if err != nil {
+			return nil, err
+		}
+
+		variants[i] = *variant
+	}
+
+	return variants, nil
+}
+
+func (p *ProductVariantPayload) ToProductVariant(product *Product) (*ProductVariant, error) {
+	sizeUUID, err := uuid.Parse(p.SizeID)
+	if err != nil {
+		return nil, ErrInvalidProductVariant
+	}
+
+	colorUUID, err := uuid.Parse(p.ColorID)
+	if err != nil {
+		return nil, ErrInvalidProductVariant
+	}
+
+	if p.Stock < 0 || (p.Price != nil && *p.Price <= 0) {
+		return nil, ErrInvalidProductVariant
+	}
+
+	variant := &ProductVariant{
+		ID:        uuid.New(),
+		SKU:       NormalizeSKU(p.SKU),
+		Stock:     p.Stock,
+		CreatedAt: time.Now(),
+		StoreID:   product.StoreID,
+		ProductID: product.ID,
+		SizeID:    sizeUUID,
+		ColorID:   colorUUID,
+	}
+
+	if p.Price != nil {
+		variant.PriceInCents = sql.NullInt64{Int64: toCents(*p.Price), Valid: true}
+	}
+
+	return variant, nil
+}
+
+func (p *UpdateProductVariantPayload) ApplyTo(variant *ProductVariant) error {
+	sku := NormalizeSKU(p.SKU)
+	if sku == "" || (p.Price != nil && *p.Price <= 0) {
+		return ErrInvalidProductVariant
+	}
+
+	variant.SKU = sku
+	variant.PriceInCents = sql.NullInt64{}
+	if p.Price != nil {
+		variant.PriceInCents = sql.NullInt64{Int64: toCents(*p.Price), Valid: true}
+	}
+	variant.UpdatedAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+	return nil
+}
+
+func NormalizeSKU(sku string) string {
+	sku = skuInvalidChars.ReplaceAllString(strings.ToUpper(sku), "-")
+	return strings.Trim(sku, "-")
+}
+
+func GenerateSKU(productName, sizeValue, colorName string) string {
+	return NormalizeSKU(fmt.Sprintf("%s-%s-%s", productName, sizeValue, colorName))
+}
+
+func (v *ProductVariant) EffectivePriceInCents(product *Product) int64 {
+	if v.PriceInCents.Valid {
+		return v.PriceInCents.Int64
+	}
+
+	return product.PriceInCents
+}
+
+func (v *ProductVariant) ToProductVariantResponse() ProductVariantResponse {
+	resp := ProductVariantResponse{
+		ID:        v.ID,
+		SKU:       v.SKU,
+		Stock:     v.Stock,
+		Size:      *v.Size.ToSizeResponse(),
+		Color:     *v.Color.ToColorResponse(),
+		CreatedAt: v.CreatedAt,
+	}
+
+	if v.PriceInCents.Valid {
+		price := float64(v.PriceInCents.Int64) / 100
+		resp.Price = &price
+	}
+
+	return resp
+}
+
+func (v *ProductVariant) ToPublicProductVariantResponse(product *Product) PublicProductVariantResponse {
+	return PublicProductVariantResponse{
+		ID:      v.ID,
+		SKU:     v.SKU,
+		Price:   float64(v.EffectivePriceInCents(product)) / 100,
+		InStock: v.Stock > 0,
+		Size:    v.Size.ToPublicSizeResponse(),
+		Color:   v.Color.ToPublicColorResponse(),
+	}
+}
@@ -3,6 +3,7 @@ package models
 import (
 	"database/sql"
 	"errors"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
@@ -10,6 +11,7 @@ import (
 
 var (
 	ErrSessionNotFoundOrExpired = errors.New("session not found or expired")
+	ErrSessionNotFound          = errors.New("session not found")
 )
 
 type SessionSecurityInfo struct {
@@ -25,13 +27,58 @@ type Session struct {
 	VerifiedAt sql.NullTime `gorm:"default:null"`
 	IP         string       `gorm:"not null"`
 	UserAgent  string       `gorm:"not null"`
+	LastUsedAt sql.NullTime `gorm:"default:null"`
 	CreatedAt  time.Time    `gorm:"not null"`
 	UpdatedAt  sql.NullTime `gorm:"default:null"`
 
-	UserID uuid.UUID `gorm:"type:uuid;not null"`
+	AuthMethods   []AuthMethod `gorm:"type:jsonb;serializer:json;default:null"`
+	MFAVerifiedAt sql.NullTime `gorm:"default:null"`
+
+	UserID uuid.UUID `gorm:"type:uuid;not null;index"`
 	User   User      `gorm:"foreignKey:UserID"`
 }
 
+type SessionResponse struct {
+	ID         uuid.UUID  `json:"id"`
+	IP         string     `json:"ip"`
+	UserAgent  string     `json:"userAgent"`
+	Current    bool       `json:"current"`
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+	ExpiresAt  time.Time  `json:"expiresAt"`
+	CreatedAt  time.Time  `json:"createdAt"`
+}
+
 func (s *Session) IsExpired() bool {
 	return time.Now().After(s.ExpiresAt)
 }
+
+func (s *Session) HasAuthMethod(method AuthMethod) bool {
+	return slices.Contains(s.AuthMethods, method)
+}
+
+func (s *Session) AddAuthMethod(method AuthMethod) {
+	if !s.HasAuthMethod(method) {
+		s.AuthMethods = append(s.AuthMethods, method)
+	}
+}
+
+func (s *Session) IsPendingMFA() bool {
+	return !s.VerifiedAt.Valid && slices.ContainsFunc(s.AuthMethods, AuthMethod.IsFirstFactor)
+}
+
+func (s *Session) ToSessionResponse(currentSessionID string) SessionResponse {
+	resp := SessionResponse{
+		ID:        s.ID,
+		IP:        s.IP,
+		UserAgent: s.UserAgent,
+		Current:   s.ID.String() == currentSessionID,
+		ExpiresAt: s.ExpiresAt,
+		CreatedAt: s.CreatedAt,
+	}
+
+	if s.LastUsedAt.Valid {
+		resp.LastUsedAt = &s.LastUsedAt.Time
+	}
+
+	return resp
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrSocialLoginProviderNotFound = errors.New("social login provider not found")
+	ErrSocialLoginStateInvalid     = errors.New("social login state invalid")
+	ErrSocialLoginCodeInvalid      = errors.New("social login authorization code invalid")
+	ErrSocialLoginIDTokenInvalid   = errors.New("social login id token invalid")
+	ErrSocialLoginEmailNotVerified = errors.New("social login email not verified")
+)
+
+// Só o hash do state é salvo, o valor fica no cookie do navegador
+type SocialLoginState struct {
+	ID           uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Provider     string    `gorm:"type:varchar(50);not null"`
+	StateHash    string    `gorm:"not null;unique"`
+	Nonce        string    `gorm:"not null"`
+	CodeVerifier string    `gorm:"not null"`
+	ExpiresAt    time.Time `gorm:"not null;index"`
+	CreatedAt    time.Time `gorm:"not null"`
+}
+
+type SocialLoginAuthorization struct {
+	URL   string
+	State string
+}
+
+func NewSocialLoginState(provider, stateHash, nonce, codeVerifier string, expiresAt time.Time) *SocialLoginState {
+	return &SocialLoginState{
+		ID:           uuid.New(),
+		Provider:     provider,
+		StateHash:    stateHash,
+		Nonce:        nonce,
+		CodeVerifier: codeVerifier,
+		ExpiresAt:    expiresAt,
+		CreatedAt:    time.Now(),
+	}
+}
+
+func (s *SocialLoginState) IsExpired() bool {
+	return time.Now().After(s.ExpiresAt)
+}
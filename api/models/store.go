@@ -45,6 +45,7 @@ type CreateStoreResponse struct {
 type StoreResponse struct {
 	ID        uuid.UUID `json:"id"`
 	Name      string    `json:"name"`
+	Role      StoreRole `json:"role,omitempty"`
 	CreatedAt time.Time `json:"createdAt"`
 }
 
@@ -0,0 +1,147 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrAPIKeyInvalid           = errors.New("api key invalid")
+	ErrAPIKeyNotFound          = errors.New("api key not found")
+	ErrInvalidAPIKeyScope      = errors.New("invalid api key scope")
+	ErrInvalidAPIKeyExpiration = errors.New("invalid api key expiration")
+)
+
+type APIKeyScope string
+
+const (
+	APIKeyScopeStoreRead    APIKeyScope = "store:read"
+	APIKeyScopeCatalogWrite APIKeyScope = "catalog:write"
+	APIKeyScopeOrdersWrite  APIKeyScope = "orders:write"
+)
+
+// Membros, convites e chaves só são geridos por uma sessão de usuário
+var apiKeyScopePermissions = map[APIKeyScope][]StorePermission{
+	APIKeyScopeStoreRead:    {PermissionViewStore},
+	APIKeyScopeCatalogWrite: {PermissionViewStore, PermissionEditCatalog},
+	APIKeyScopeOrdersWrite:  {PermissionViewStore, PermissionManageOrders},
+}
+
+type StoreAPIKey struct {
+	ID         uuid.UUID     `gorm:"type:uuid;primaryKey"`
+	Name       string        `gorm:"not null"`
+	Prefix     string        `gorm:"not null;unique"`
+	SecretHash string        `gorm:"not null"`
+	Scopes     []APIKeyScope `gorm:"type:jsonb;serializer:json;not null"`
+	ExpiresAt  sql.NullTime  `gorm:"default:null"`
+	LastUsedAt sql.NullTime  `gorm:"default:null"`
+	RevokedAt  sql.NullTime  `gorm:"default:null"`
+	CreatedAt  time.Time     `gorm:"not null"`
+
+	StoreID uuid.UUID `gorm:"type:uuid;not null;index"`
+	Store   Store     `gorm:"foreignKey:StoreID;constraint:OnDelete:CASCADE"`
+
+	CreatedByID uuid.UUID `gorm:"type:uuid;not null"`
+}
+
+type CreateStoreAPIKeyPayload struct {
+	Name      string        `json:"name" binding:"required"`
+	Scopes    []APIKeyScope `json:"scopes" binding:"required"`
+	ExpiresAt *time.Time    `json:"expiresAt"`
+}
+
+type StoreAPIKeyResponse struct {
+	ID         uuid.UUID     `json:"id"`
+	Name       string        `json:"name"`
+	Prefix     string        `json:"prefix"`
+	Scopes     []APIKeyScope `json:"scopes"`
+	ExpiresAt  *time.Time    `json:"expiresAt"`
+	LastUsedAt *time.Time    `json:"lastUsedAt"`
+	RevokedAt  *time.Time    `json:"revokedAt"`
+	CreatedAt  time.Time     `json:"createdAt"`
+}
+
+type CreateStoreAPIKeyResponse struct {
+	StoreAPIKeyResponse
+	Key string `json:"key"`
+}
+
+func (s APIKeyScope) IsValid() bool {
+	_, ok := apiKeyScopePermissions[s]
+	return ok
+}
+
+func APIKeyScopesAllow(scopes []string, permission StorePermission) bool {
+	for _, scope := range scopes {
+		for _, allowed := range apiKeyScopePermissions[APIKeyScope(scope)] {
+			if allowed == permission {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func NewStoreAPIKey(storeID, createdByID uuid.UUID, name, prefix, secretHash string, scopes []APIKeyScope, expiresAt *time.Time) *StoreAPIKey {
+	key := &StoreAPIKey{
+		ID:          uuid.New(),
+		Name:        name,
+		Prefix:      prefix,
+		SecretHash:  secretHash,
+		Scopes:      scopes,
+		CreatedAt:   time.Now(),
+		StoreID:     storeID,
+		CreatedByID: createdByID,
+	}
+
+	if expiresAt != nil {
+		key.ExpiresAt = sql.NullTime{Time: *expiresAt, Valid: true}
+	}
+
+	return key
+}
+
+func (k *StoreAPIKey) IsActive() bool {
+	if k.RevokedAt.Valid {
+		return false
+	}
+
+	return !k.ExpiresAt.Valid || time.Now().Before(k.ExpiresAt.Time)
+}
+
+func (k *StoreAPIKey) ScopeNames() []string {
+	scopes := make([]string, len(k.Scopes))
+	for i, scope := range k.Scopes {
+		scopes[i] = string(scope)
+	}
+
+	return scopes
+}
+
+func (k *StoreAPIKey) ToStoreAPIKeyResponse() StoreAPIKeyResponse {
+	resp := StoreAPIKeyResponse{
+		ID:        k.ID,
+		Name:      k.Name,
+		Prefix:    k.Prefix,
+		Scopes:    k.Scopes,
+		CreatedAt: k.CreatedAt,
+	}
+
+	if k.ExpiresAt.Valid {
+		resp.ExpiresAt = &k.ExpiresAt.Time
+	}
+
+	if k.LastUsedAt.Valid {
+		resp.LastUsedAt = &k.LastUsedAt.Time
+	}
+
+	if k.RevokedAt.Valid {
+		resp.RevokedAt = &k.RevokedAt.Time
+	}
+
+	return resp
+}
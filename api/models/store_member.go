@@ -0,0 +1,186 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrStorePermissionDenied    = errors.New("store permission denied")
+	ErrInvalidStoreRole         = errors.New("invalid store role")
+	ErrStoreMemberNotFound      = errors.New("store member not found")
+	ErrStoreMemberAlreadyExists = errors.New("store member already exists")
+	ErrStoreOwnerImmutable      = errors.New("store owner cannot be changed")
+	ErrInvitationNotFound       = errors.New("invitation not found")
+	ErrInvitationExpired        = errors.New("invitation expired")
+	ErrInvitationEmailMismatch  = errors.New("invitation email mismatch")
+)
+
+type StoreRole string
+
+const (
+	StoreRoleOwner  StoreRole = "owner"
+	StoreRoleAdmin  StoreRole = "admin"
+	StoreRoleEditor StoreRole = "editor"
+	StoreRoleViewer StoreRole = "viewer"
+)
+
+type StorePermission string
+
+const (
+	PermissionViewStore    StorePermission = "store:view"
+	PermissionEditCatalog  StorePermission = "catalog:edit"
+	PermissionManageOrders StorePermission = "orders:manage"
+	PermissionManageStore  StorePermission = "store:manage"
+	PermissionDeleteStore  StorePermission = "store:delete"
+)
+
+var storeRolePermissions = map[StoreRole][]StorePermission{
+	StoreRoleOwner:  {PermissionViewStore, PermissionEditCatalog, PermissionManageOrders, PermissionManageStore, PermissionDeleteStore},
+	StoreRoleAdmin:  {PermissionViewStore, PermissionEditCatalog, PermissionManageOrders, PermissionManageStore},
+	StoreRoleEditor: {PermissionViewStore, PermissionEditCatalog},
+	StoreRoleViewer: {PermissionViewStore},
+}
+
+type StoreMember struct {
+	ID        uuid.UUID    `gorm:"type:uuid;primaryKey"`
+	Role      StoreRole    `gorm:"type:varchar(20);not null"`
+	CreatedAt time.Time    `gorm:"not null"`
+	UpdatedAt sql.NullTime `gorm:"default:null"`
+
+	StoreID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_store_member"`
+	Store   Store     `gorm:"foreignKey:StoreID;constraint:OnDelete:CASCADE"`
+
+	UserID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_store_member;index"`
+	User   User      `gorm:"foreignKey:UserID"`
+}
+
+type StoreInvitation struct {
+	ID         uuid.UUID    `gorm:"type:uuid;primaryKey"`
+	Email      string       `gorm:"not null;index"`
+	Role       StoreRole    `gorm:"type:varchar(20);not null"`
+	TokenHash  string       `gorm:"not null;unique"`
+	ExpiresAt  time.Time    `gorm:"not null"`
+	AcceptedAt sql.NullTime `gorm:"default:null"`
+	RevokedAt  sql.NullTime `gorm:"default:null"`
+	CreatedAt  time.Time    `gorm:"not null"`
+
+	StoreID uuid.UUID `gorm:"type:uuid;not null;index"`
+	Store   Store     `gorm:"foreignKey:StoreID;constraint:OnDelete:CASCADE"`
+
+	InvitedByID uuid.UUID `gorm:"type:uuid;not null"`
+}
+
+type InviteStoreMemberPayload struct {
+	Email string    `json:"email" binding:"required"`
+	Role  StoreRole `json:"role" binding:"required"`
+}
+
+type UpdateStoreMemberPayload struct {
+	Role StoreRole `json:"role" binding:"required"`
+}
+
+type AcceptInvitationPayload struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type StoreMemberResponse struct {
+	ID        uuid.UUID `json:"id"`
+	UserID    uuid.UUID `json:"userId"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	Role      StoreRole `json:"role"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type StoreInvitationResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Email     string    `json:"email"`
+	Role      StoreRole `json:"role"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func (r StoreRole) IsValid() bool {
+	_, ok := storeRolePermissions[r]
+	return ok
+}
+
+func (r StoreRole) Can(permission StorePermission) bool {
+	for _, allowed := range storeRolePermissions[r] {
+		if allowed == permission {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Ninguém atribui owner e apenas o dono atribui admin
+func (r StoreRole) CanAssign(target StoreRole) bool {
+	if !target.IsValid() || target == StoreRoleOwner {
+		return false
+	}
+
+	if target == StoreRoleAdmin {
+		return r == StoreRoleOwner
+	}
+
+	return r.Can(PermissionManageStore)
+}
+
+func NewStoreMember(storeID, userID uuid.UUID, role StoreRole) *StoreMember {
+	return &StoreMember{
+		ID:        uuid.New(),
+		Role:      role,
+		CreatedAt: time.Now(),
+		StoreID:   storeID,
+		UserID:    userID,
+	}
+}
+
+func NewStoreInvitation(storeID, invitedByID uuid.UUID, email string, role StoreRole, tokenHash string, expiresAt time.Time) *StoreInvitation {
+	return &StoreInvitation{
+		ID:          uuid.New(),
+		Email:       strings.ToLower(strings.TrimSpace(email)),
+		Role:        role,
+		TokenHash:   tokenHash,
+		ExpiresAt:   expiresAt,
+		CreatedAt:   time.Now(),
+		StoreID:     storeID,
+		InvitedByID: invitedByID,
+	}
+}
+
+func (i *StoreInvitation) IsPending() bool {
+	return !i.AcceptedAt.Valid && !i.RevokedAt.Valid
+}
+
+func (i *StoreInvitation) IsExpired() bool {
+	return time.Now().After(i.ExpiresAt)
+}
+
+func (m *StoreMember) ToStoreMemberResponse() StoreMemberResponse {
+	return StoreMemberResponse{
+		ID:        m.ID,
+		UserID:    m.UserID,
+		Name:      m.User.Name,
+		Email:     m.User.Email,
+		Role:      m.Role,
+		CreatedAt: m.CreatedAt,
+	}
+}
+
+func (i *StoreInvitation) ToStoreInvitationResponse() StoreInvitationResponse {
+	return StoreInvitationResponse{
+		ID:        i.ID,
+		Email:     i.Email,
+		Role:      i.Role,
+		ExpiresAt: i.ExpiresAt,
+		CreatedAt: i.CreatedAt,
+	}
+}
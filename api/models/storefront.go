@@ -0,0 +1,157 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+type PublicStoreResponse struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+}
+
+type PublicBillboardResponse struct {
+	ID         uuid.UUID       `json:"id"`
+	Label      string          `json:"label"`
+	ImageURL   string          `json:"imageUrl"`
+	Renditions ImageRenditions `json:"renditions"`
+}
+
+type PublicCategoryResponse struct {
+	ID        uuid.UUID               `json:"id"`
+	Name      string                  `json:"name"`
+	ParentID  *uuid.UUID              `json:"parentId"`
+	Billboard PublicBillboardResponse `json:"billboard"`
+}
+
+type PublicColorResponse struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+	Hex  string    `json:"hex"`
+}
+
+type PublicSizeResponse struct {
+	ID    uuid.UUID `json:"id"`
+	Name  string    `json:"name"`
+	Value string    `json:"value"`
+}
+
+type PublicProductImageResponse struct {
+	ID         uuid.UUID       `json:"id"`
+	ImageURL   string          `json:"imageUrl"`
+	Renditions ImageRenditions `json:"renditions"`
+	IsPrimary  bool            `json:"isPrimary"`
+}
+
+type PublicProductResponse struct {
+	ID         uuid.UUID `json:"id"`
+	Name       string    `json:"name"`
+	Price      float64   `json:"price"`
+	IsFeatured bool      `json:"isFeatured"`
+	InStock    bool      `json:"inStock"`
+
+	Category PublicCategoryBasicResponse    `json:"category"`
+	Colors   []PublicColorResponse          `json:"colors"`
+	Sizes    []PublicSizeResponse           `json:"sizes"`
+	Images   []PublicProductImageResponse   `json:"images"`
+	Variants []PublicProductVariantResponse `json:"variants"`
+}
+
+type PublicCategoryBasicResponse struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+}
+
+func (s *Store) ToPublicStoreResponse() PublicStoreResponse {
+	return PublicStoreResponse{
+		ID:   s.ID,
+		Name: s.Name,
+	}
+}
+
+func (b *Billboard) ToPublicBillboardResponse() PublicBillboardResponse {
+	return PublicBillboardResponse{
+		ID:         b.ID,
+		Label:      b.Label,
+		ImageURL:   b.ImageURL.String,
+		Renditions: b.Renditions,
+	}
+}
+
+func (c *Category) ToPublicCategoryResponse() PublicCategoryResponse {
+	resp := PublicCategoryResponse{
+		ID:        c.ID,
+		Name:      c.Name,
+		Billboard: c.Billboard.ToPublicBillboardResponse(),
+	}
+
+	if c.ParentID.Valid {
+		resp.ParentID = &c.ParentID.UUID
+	}
+
+	return resp
+}
+
+func (c *Color) ToPublicColorResponse() PublicColorResponse {
+	return PublicColorResponse{
+		ID:   c.ID,
+		Name: c.Name,
+		Hex:  c.Hex,
+	}
+}
+
+func (s *Size) ToPublicSizeResponse() PublicSizeResponse {
+	return PublicSizeResponse{
+		ID:    s.ID,
+		Name:  s.Name,
+		Value: s.Value,
+	}
+}
+
+func (p *Product) ToPublicProductResponse() PublicProductResponse {
+	images := make([]PublicProductImageResponse, 0, len(p.ProductImages))
+	for _, image := range p.ProductImages {
+		if !image.IsReady() {
+			continue
+		}
+
+		images = append(images, PublicProductImageResponse{
+			ID:         image.ID,
+			ImageURL:   image.ImageURL,
+			Renditions: image.Renditions,
+			IsPrimary:  image.IsPrimary,
+		})
+	}
+
+	variants := make([]PublicProductVariantResponse, len(p.Variants))
+	for i, variant := range p.Variants {
+		variants[i] = variant.ToPublicProductVariantResponse(p)
+	}
+
+	colors := p.Colors()
+	colorResponses := make([]PublicColorResponse, len(colors))
+	for i := range colors {
+		colorResponses[i] = colors[i].ToPublicColorResponse()
+	}
+
+	sizes := p.Sizes()
+	sizeResponses := make([]PublicSizeResponse, len(sizes))
+	for i := range sizes {
+		sizeResponses[i] = sizes[i].ToPublicSizeResponse()
+	}
+
+	return PublicProductResponse{
+		ID:         p.ID,
+		Name:       p.Name,
+		Price:      float64(p.PriceInCents) / 100,
+		IsFeatured: p.IsFeatured,
+		InStock:    p.TotalStock() > 0,
+		Category: PublicCategoryBasicResponse{
+			ID:   p.Category.ID,
+			Name: p.Category.Name,
+		},
+		Colors:   colorResponses,
+		Sizes:    sizeResponses,
+		Images:   images,
+		Variants: variants,
+	}
+}
@@ -1,14 +1,53 @@
 package models
 
 import (
+	"slices"
+	"time"
+
 	"github.com/golang-jwt/jwt/v5"
 )
 
+type AuthMethod string
+
+const (
+	AuthMethodEmail        AuthMethod = "email"
+	AuthMethodSSO          AuthMethod = "sso"
+	AuthMethodTOTP         AuthMethod = "totp"
+	AuthMethodRecoveryCode AuthMethod = "recovery_code"
+)
+
 type TokenClaims struct {
 	Sub        string           `json:"sub"`
 	Sid        string           `json:"sid"`
 	Email      string           `json:"email"`
 	Name       string           `json:"name"`
 	VerifiedAt *jwt.NumericDate `json:"vyf"`
+	AMR        []AuthMethod     `json:"amr"`
+	MFAAt      *jwt.NumericDate `json:"mfa_at"`
+	jwt.RegisteredClaims
+}
+
+// Tokens de sessão não têm aud e nunca são aceitos como link
+const MagicLinkAudience = "magic_link"
+
+type MagicLinkClaims struct {
+	OTPID string `json:"otp"`
+	Code  string `json:"code"`
 	jwt.RegisteredClaims
 }
+
+func (m AuthMethod) IsSecondFactor() bool {
+	return m == AuthMethodTOTP || m == AuthMethodRecoveryCode
+}
+
+func (m AuthMethod) IsFirstFactor() bool {
+	return m == AuthMethodEmail || m == AuthMethodSSO
+}
+
+func (c *TokenClaims) SecondFactorAt() (time.Time, bool) {
+	if c.MFAAt == nil || !slices.ContainsFunc(c.AMR, AuthMethod.IsSecondFactor) {
+		return time.Time{}, false
+	}
+
+	return c.MFAAt.Time, true
+}
@@ -0,0 +1,97 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrTOTPNotEnabled      = errors.New("totp not enabled")
+	ErrTOTPAlreadyEnabled  = errors.New("totp already enabled")
+	ErrTOTPInvalid         = errors.New("totp code invalid")
+	ErrTOTPLocked          = errors.New("totp locked after too many failed attempts")
+	ErrRecoveryCodeInvalid = errors.New("recovery code invalid")
+	ErrMFARequired         = errors.New("second factor required")
+	ErrFirstFactorRequired = errors.New("first factor required")
+)
+
+type UserTOTP struct {
+	ID             uuid.UUID    `gorm:"type:uuid;primaryKey"`
+	Secret         string       `gorm:"not null"`
+	ConfirmedAt    sql.NullTime `gorm:"default:null"`
+	LastUsedStep   int64        `gorm:"not null;default:0"`
+	FailedAttempts int          `gorm:"not null;default:0"`
+	LockedUntil    sql.NullTime `gorm:"default:null"`
+	CreatedAt      time.Time    `gorm:"not null"`
+	UpdatedAt      sql.NullTime `gorm:"default:null"`
+
+	UserID uuid.UUID `gorm:"type:uuid;not null;unique"`
+	User   User      `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+}
+
+type RecoveryCode struct {
+	ID        uuid.UUID    `gorm:"type:uuid;primaryKey"`
+	CodeHash  string       `gorm:"not null"`
+	UsedAt    sql.NullTime `gorm:"default:null"`
+	CreatedAt time.Time    `gorm:"not null"`
+
+	UserID uuid.UUID `gorm:"type:uuid;not null;index"`
+	User   User      `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+}
+
+type ConfirmTOTPPayload struct {
+	Code string `json:"code"`
+}
+
+type VerifySecondFactorPayload struct {
+	Code         string `json:"code"`
+	RecoveryCode string `json:"recoveryCode"`
+}
+
+type TOTPEnrollmentResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioningUri"`
+}
+
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+type MFARequiredResponse struct {
+	MFARequired bool `json:"mfaRequired"`
+}
+
+type TwoFactorStatusResponse struct {
+	Enabled                bool       `json:"enabled"`
+	ConfirmedAt            *time.Time `json:"confirmedAt"`
+	RemainingRecoveryCodes int64      `json:"remainingRecoveryCodes"`
+}
+
+func NewUserTOTP(userID uuid.UUID, secret string) *UserTOTP {
+	return &UserTOTP{
+		ID:        uuid.New(),
+		Secret:    secret,
+		CreatedAt: time.Now(),
+		UserID:    userID,
+	}
+}
+
+func NewRecoveryCode(userID uuid.UUID, codeHash string) RecoveryCode {
+	return RecoveryCode{
+		ID:        uuid.New(),
+		CodeHash:  codeHash,
+		CreatedAt: time.Now(),
+		UserID:    userID,
+	}
+}
+
+func (t *UserTOTP) IsEnabled() bool {
+	return t.ConfirmedAt.Valid
+}
+
+func (t *UserTOTP) IsLocked() bool {
+	return t.LockedUntil.Valid && time.Now().Before(t.LockedUntil.Time)
+}
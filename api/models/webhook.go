@@ -0,0 +1,200 @@
+package models
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrWebhookNotFound         = errors.New("webhook not found")
+	ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+	ErrInvalidWebhookURL       = errors.New("invalid webhook url")
+	ErrInvalidWebhookEvent     = errors.New("invalid webhook event")
+)
+
+type WebhookEventType string
+
+const (
+	WebhookEventProductCreated     WebhookEventType = "product.created"
+	WebhookEventProductUpdated     WebhookEventType = "product.updated"
+	WebhookEventProductArchived    WebhookEventType = "product.archived"
+	WebhookEventProductDeleted     WebhookEventType = "product.deleted"
+	WebhookEventBillboardCreated   WebhookEventType = "billboard.created"
+	WebhookEventBillboardUpdated   WebhookEventType = "billboard.updated"
+	WebhookEventBillboardDeleted   WebhookEventType = "billboard.deleted"
+	WebhookEventOrderCreated       WebhookEventType = "order.created"
+	WebhookEventOrderStatusChanged WebhookEventType = "order.status_changed"
+	WebhookEventInventoryAdjusted  WebhookEventType = "inventory.adjusted"
+)
+
+var webhookEventTypes = map[WebhookEventType]bool{
+	WebhookEventProductCreated:     true,
+	WebhookEventProductUpdated:     true,
+	WebhookEventProductArchived:    true,
+	WebhookEventProductDeleted:     true,
+	WebhookEventBillboardCreated:   true,
+	WebhookEventBillboardUpdated:   true,
+	WebhookEventBillboardDeleted:   true,
+	WebhookEventOrderCreated:       true,
+	WebhookEventOrderStatusChanged: true,
+	WebhookEventInventoryAdjusted:  true,
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed"
+)
+
+type Webhook struct {
+	ID          uuid.UUID          `gorm:"type:uuid;primaryKey"`
+	URL         string             `gorm:"not null"`
+	Description string             `gorm:"not null;default:''"`
+	Secret      string             `gorm:"not null"`
+	Events      []WebhookEventType `gorm:"type:jsonb;serializer:json;not null"`
+	CreatedAt   time.Time          `gorm:"not null"`
+
+	StoreID uuid.UUID `gorm:"type:uuid;not null;index"`
+	Store   Store     `gorm:"foreignKey:StoreID;constraint:OnDelete:CASCADE"`
+}
+
+type WebhookDelivery struct {
+	ID             uuid.UUID             `gorm:"type:uuid;primaryKey"`
+	EventID        uuid.UUID             `gorm:"type:uuid;not null;index"`
+	EventType      WebhookEventType      `gorm:"not null"`
+	Payload        json.RawMessage       `gorm:"type:jsonb;not null"`
+	Status         WebhookDeliveryStatus `gorm:"not null;index:idx_webhook_deliveries_due,priority:1"`
+	Attempts       int                   `gorm:"not null;default:0"`
+	NextAttemptAt  time.Time             `gorm:"not null;index:idx_webhook_deliveries_due,priority:2"`
+	ResponseStatus int                   `gorm:"not null;default:0"`
+	LastError      string                `gorm:"not null;default:''"`
+	DeliveredAt    sql.NullTime          `gorm:"default:null"`
+	CreatedAt      time.Time             `gorm:"not null"`
+
+	WebhookID uuid.UUID `gorm:"type:uuid;not null;index"`
+	Webhook   Webhook   `gorm:"foreignKey:WebhookID;constraint:OnDelete:CASCADE"`
+}
+
+type WebhookEvent struct {
+	ID        uuid.UUID        `json:"id"`
+	Type      WebhookEventType `json:"type"`
+	StoreID   uuid.UUID        `json:"storeId"`
+	CreatedAt time.Time        `json:"createdAt"`
+	Data      any              `json:"data"`
+}
+
+type WebhookDeletedData struct {
+	ID uuid.UUID `json:"id"`
+}
+
+type CreateWebhookPayload struct {
+	URL         string             `json:"url" binding:"required"`
+	Description string             `json:"description"`
+	Events      []WebhookEventType `json:"events" binding:"required"`
+}
+
+type WebhookResponse struct {
+	ID          uuid.UUID          `json:"id"`
+	URL         string             `json:"url"`
+	Description string             `json:"description"`
+	Events      []WebhookEventType `json:"events"`
+	CreatedAt   time.Time          `json:"createdAt"`
+}
+
+type CreateWebhookResponse struct {
+	WebhookResponse
+	Secret string `json:"secret"`
+}
+
+type WebhookDeliveryResponse struct {
+	ID             uuid.UUID             `json:"id"`
+	EventID        uuid.UUID             `json:"eventId"`
+	EventType      WebhookEventType      `json:"eventType"`
+	Status         WebhookDeliveryStatus `json:"status"`
+	Attempts       int                   `json:"attempts"`
+	NextAttemptAt  *time.Time            `json:"nextAttemptAt"`
+	ResponseStatus int                   `json:"responseStatus,omitempty"`
+	LastError      string                `json:"lastError,omitempty"`
+	DeliveredAt    *time.Time            `json:"deliveredAt"`
+	CreatedAt      time.Time             `json:"createdAt"`
+}
+
+func (e WebhookEventType) IsValid() bool {
+	return webhookEventTypes[e]
+}
+
+func NewWebhook(storeID uuid.UUID, url, description, secret string, events []WebhookEventType) *Webhook {
+	return &Webhook{
+		ID:          uuid.New(),
+		URL:         url,
+		Description: description,
+		Secret:      secret,
+		Events:      events,
+		CreatedAt:   time.Now(),
+		StoreID:     storeID,
+	}
+}
+
+func (w *Webhook) IsSubscribed(eventType WebhookEventType) bool {
+	for _, event := range w.Events {
+		if event == eventType {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (w *Webhook) ToWebhookResponse() WebhookResponse {
+	return WebhookResponse{
+		ID:          w.ID,
+		URL:         w.URL,
+		Description: w.Description,
+		Events:      w.Events,
+		CreatedAt:   w.CreatedAt,
+	}
+}
+
+func NewWebhookDelivery(webhookID, eventID uuid.UUID, eventType WebhookEventType, payload json.RawMessage) *WebhookDelivery {
+	now := time.Now()
+
+	return &WebhookDelivery{
+		ID:            uuid.New(),
+		EventID:       eventID,
+		EventType:     eventType,
+		Payload:       payload,
+		Status:        WebhookDeliveryPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+		WebhookID:     webhookID,
+	}
+}
+
+func (d *WebhookDelivery) ToWebhookDeliveryResponse() WebhookDeliveryResponse {
+	resp := WebhookDeliveryResponse{
+		ID:             d.ID,
+		EventID:        d.EventID,
+		EventType:      d.EventType,
+		Status:         d.Status,
+		Attempts:       d.Attempts,
+		ResponseStatus: d.ResponseStatus,
+		LastError:      d.LastError,
+		CreatedAt:      d.CreatedAt,
+	}
+
+	if d.Status == WebhookDeliveryPending {
+		resp.NextAttemptAt = &d.NextAttemptAt
+	}
+
+	if d.DeliveredAt.Valid {
+		resp.DeliveredAt = &d.DeliveredAt.Time
+	}
+
+	return resp
+}
@@ -14,6 +14,8 @@ import (
 
 type EmailNotification interface {
 	SendVerificationEmail(ctx context.Context, email, code string) error
+	SendMagicLinkEmail(ctx context.Context, email, link string) error
+	SendStoreInvitationEmail(ctx context.Context, email string, data models.StoreInvitationEmailData) error
 }
 
 type emailNotification struct {
@@ -54,3 +56,43 @@ func (e *emailNotification) SendVerificationEmail(ctx context.Context, email str
 
 	return nil
 }
+
+func (e *emailNotification) SendMagicLinkEmail(ctx context.Context, email, link string) error {
+	tmpl, err := template.ParseFiles("notifications/templates/magic-link-email.html")
+	if err != nil {
+		return fmt.Errorf("parse template: %w", err)
+	}
+
+	var htmlBuffer bytes.Buffer
+	data := models.MagicLinkEmailData{
+		Link: link,
+	}
+
+	if err := tmpl.Execute(&htmlBuffer, data); err != nil {
+		return fmt.Errorf("execute template: %w", err)
+	}
+
+	if err := e.sc.SendEmail(ctx, email, "XP Life - Sign In Link", htmlBuffer.String()); err != nil {
+		return fmt.Errorf("send email: %w", err)
+	}
+
+	return nil
+}
+
+func (e *emailNotification) SendStoreInvitationEmail(ctx context.Context, email string, data models.StoreInvitationEmailData) error {
+	tmpl, err := template.ParseFiles("notifications/templates/store-invitation-email.html")
+	if err != nil {
+		return fmt.Errorf("parse template: %w", err)
+	}
+
+	var htmlBuffer bytes.Buffer
+	if err := tmpl.Execute(&htmlBuffer, data); err != nil {
+		return fmt.Errorf("execute template: %w", err)
+	}
+
+	if err := e.sc.SendEmail(ctx, email, "XP Life - Store Invitation", htmlBuffer.String()); err != nil {
+		return fmt.Errorf("send email: %w", err)
+	}
+
+	return nil
+}
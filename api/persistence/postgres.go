@@ -8,6 +8,7 @@ import (
 	"github.com/g-villarinho/flash-buy-api/models"
 	"github.com/g-villarinho/flash-buy-api/pkgs"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type PostgresRepository struct {
@@ -15,6 +16,8 @@ type PostgresRepository struct {
 	db *gorm.DB
 }
 
+type txKey struct{}
+
 func NewPostgresRepository(di *pkgs.Di) (Repository, error) {
 	db, err := pkgs.Invoke[*gorm.DB](di)
 	if err != nil {
@@ -27,6 +30,15 @@ func NewPostgresRepository(di *pkgs.Di) (Repository, error) {
 	}, nil
 }
 
+func NewTransactor(di *pkgs.Di) (Transactor, error) {
+	repo, err := pkgs.Invoke[Repository](di)
+	if err != nil {
+		return nil, fmt.Errorf("invoke repository: %w", err)
+	}
+
+	return repo, nil
+}
+
 func WithConditions(query any, args ...any) QueryOption {
 	return func(db *gorm.DB) *gorm.DB {
 		return db.Where(query, args...)
@@ -52,12 +64,55 @@ func WithPreload(association string) QueryOption {
 	}
 }
 
+func WithOrderedPreload(association, order string) QueryOption {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Preload(association, func(db *gorm.DB) *gorm.DB {
+			return db.Order(order)
+		})
+	}
+}
+
+func WithOmit(columns ...string) QueryOption {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Omit(columns...)
+	}
+}
+
+func WithReturning(columns ...string) QueryOption {
+	return func(db *gorm.DB) *gorm.DB {
+		returning := clause.Returning{}
+		for _, column := range columns {
+			returning.Columns = append(returning.Columns, clause.Column{Name: column})
+		}
+		return db.Clauses(returning)
+	}
+}
+
+func Expr(expr string, args ...any) any {
+	return gorm.Expr(expr, args...)
+}
+
+func (r *PostgresRepository) conn(ctx context.Context) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+
+	return r.db.WithContext(ctx)
+}
+
+// Aninhado vira um savepoint; o ctx de fn não deve ir para outras goroutines
+func (r *PostgresRepository) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return r.conn(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
 func (r *PostgresRepository) Create(ctx context.Context, entity any) error {
-	return r.db.WithContext(ctx).Create(entity).Error
+	return r.conn(ctx).Create(entity).Error
 }
 
 func (r *PostgresRepository) FindByID(ctx context.Context, id string, out any) error {
-	err := r.db.WithContext(ctx).Where("id = ?", id).First(out).Error
+	err := r.conn(ctx).Where("id = ?", id).First(out).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return ErrRecordNotFound
@@ -67,12 +122,28 @@ func (r *PostgresRepository) FindByID(ctx context.Context, id string, out any) e
 	return nil
 }
 
-func (r *PostgresRepository) Update(ctx context.Context, entity any) error {
-	return r.db.WithContext(ctx).Save(entity).Error
+func (r *PostgresRepository) Update(ctx context.Context, entity any, opts ...QueryOption) error {
+	db := r.conn(ctx)
+	for _, opt := range opts {
+		db = opt(db)
+	}
+	return db.Save(entity).Error
+}
+
+func (r *PostgresRepository) UpdateColumns(ctx context.Context, model any, values map[string]any, opts ...QueryOption) (int64, error) {
+	db := r.conn(ctx).Model(model)
+	for _, opt := range opts {
+		db = opt(db)
+	}
+	result := db.UpdateColumns(values)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
 }
 
 func (r *PostgresRepository) Delete(ctx context.Context, id string, model any) error {
-	result := r.db.WithContext(ctx).Where("id = ?", id).Delete(model)
+	result := r.conn(ctx).Where("id = ?", id).Delete(model)
 	if result.Error != nil {
 		return result.Error
 	}
@@ -82,8 +153,16 @@ func (r *PostgresRepository) Delete(ctx context.Context, id string, model any) e
 	return nil
 }
 
+func (r *PostgresRepository) DeleteAll(ctx context.Context, model any, opts ...QueryOption) error {
+	db := r.conn(ctx)
+	for _, opt := range opts {
+		db = opt(db)
+	}
+	return db.Delete(model).Error
+}
+
 func (r *PostgresRepository) FindAll(ctx context.Context, out any, opts ...QueryOption) error {
-	db := r.db.WithContext(ctx)
+	db := r.conn(ctx)
 	for _, opt := range opts {
 		db = opt(db)
 	}
@@ -91,7 +170,7 @@ func (r *PostgresRepository) FindAll(ctx context.Context, out any, opts ...Query
 }
 
 func (r *PostgresRepository) FindOne(ctx context.Context, out any, opts ...QueryOption) error {
-	db := r.db.WithContext(ctx)
+	db := r.conn(ctx)
 	for _, opt := range opts {
 		db = opt(db)
 	}
@@ -105,8 +184,48 @@ func (r *PostgresRepository) FindOne(ctx context.Context, out any, opts ...Query
 	return nil
 }
 
+func (r *PostgresRepository) Count(ctx context.Context, model any, opts ...QueryOption) (int64, error) {
+	db := r.conn(ctx).Model(model)
+	for _, opt := range opts {
+		db = opt(db)
+	}
+
+	var count int64
+	if err := db.Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *PostgresRepository) Exec(ctx context.Context, query string, args ...any) (int64, error) {
+	result := r.conn(ctx).Exec(query, args...)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
+func (r *PostgresRepository) Raw(ctx context.Context, out any, query string, args ...any) error {
+	return r.conn(ctx).Raw(query, args...).Scan(out).Error
+}
+
+// Os eventos vão para a outbox antes do commit, então existem se e somente se a alteração existe
+func (r *PostgresRepository) WithEvents(ctx context.Context, events []*models.DomainEvent, fn func(ctx context.Context) error) error {
+	if len(events) == 0 {
+		return fn(ctx)
+	}
+
+	return r.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := fn(ctx); err != nil {
+			return err
+		}
+
+		return r.conn(ctx).Create(events).Error
+	})
+}
+
 func (r *PostgresRepository) Paginate(ctx context.Context, out any, pagination models.Pagination, opts ...QueryOption) (*models.PaginatedResponse, error) {
-	db := r.db.WithContext(ctx)
+	db := r.conn(ctx)
 
 	for _, opt := range opts {
 		db = opt(db)
@@ -12,12 +12,23 @@ var ErrRecordNotFound = errors.New("record not found")
 
 type QueryOption func(*gorm.DB) *gorm.DB
 
+type Transactor interface {
+	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
 type Repository interface {
+	Transactor
 	Create(ctx context.Context, entity any) error
 	FindByID(ctx context.Context, id string, out any) error
-	Update(ctx context.Context, entity any) error
+	Update(ctx context.Context, entity any, opts ...QueryOption) error
+	UpdateColumns(ctx context.Context, model any, values map[string]any, opts ...QueryOption) (int64, error)
 	Delete(ctx context.Context, id string, model any) error
+	DeleteAll(ctx context.Context, model any, opts ...QueryOption) error
 	FindAll(ctx context.Context, out any, opts ...QueryOption) error
 	FindOne(ctx context.Context, out any, opts ...QueryOption) error
+	Count(ctx context.Context, model any, opts ...QueryOption) (int64, error)
+	Exec(ctx context.Context, query string, args ...any) (int64, error)
+	Raw(ctx context.Context, out any, query string, args ...any) error
 	Paginate(ctx context.Context, out any, pagination models.Pagination, opts ...QueryOption) (*models.PaginatedResponse, error)
+	WithEvents(ctx context.Context, events []*models.DomainEvent, fn func(ctx context.Context) error) error
 }
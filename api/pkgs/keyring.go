@@ -0,0 +1,213 @@
+package pkgs
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/g-villarinho/flash-buy-api/config"
+)
+
+const (
+	KeyRingActiveFile = "active"
+	keyRingKeyExt     = ".pem"
+)
+
+var ErrKeyNotFound = errors.New("signing key not found")
+
+type SigningKey struct {
+	ID         string
+	PublicKey  *ecdsa.PublicKey
+	PrivateKey *ecdsa.PrivateKey
+}
+
+type KeyRing interface {
+	ActiveKey() SigningKey
+	PublicKey(kid string) (*ecdsa.PublicKey, error)
+	PublicKeys() []SigningKey
+}
+
+type keyRing struct {
+	di     *Di
+	active SigningKey
+	keys   map[string]SigningKey
+}
+
+// O par de KEY_ECDSA_* assina enquanto nenhuma rotação tiver sido feita
+func NewKeyRing(di *Di) (KeyRing, error) {
+	ep, err := Invoke[EcdsaKeyPair](di)
+	if err != nil {
+		return nil, err
+	}
+
+	kr := &keyRing{
+		di:   di,
+		keys: make(map[string]SigningKey),
+	}
+
+	var envKeyID string
+	if config.Env.Key.PrivateKey != "" {
+		privateKey, err := ep.ParseECDSAPrivateKey(config.Env.Key.PrivateKey)
+		if err != nil {
+			return nil, fmt.Errorf("parse env private key: %w", err)
+		}
+
+		envKey := NewSigningKey(privateKey)
+		envKeyID = envKey.ID
+		kr.add(envKey)
+	}
+
+	activeID, err := LoadKeyRingDir(config.Env.Key.Dir, ep, kr.add)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case activeID != "":
+		active, ok := kr.keys[activeID]
+		if !ok || active.PrivateKey == nil {
+			return nil, fmt.Errorf("active key %s: %w", activeID, ErrKeyNotFound)
+		}
+		kr.active = active
+	case envKeyID != "":
+		kr.active = kr.keys[envKeyID]
+	default:
+		return nil, errors.New("no signing key configured")
+	}
+
+	return kr, nil
+}
+
+func (k *keyRing) ActiveKey() SigningKey {
+	return k.active
+}
+
+func (k *keyRing) PublicKey(kid string) (*ecdsa.PublicKey, error) {
+	key, ok := k.keys[kid]
+	if !ok {
+		return nil, ErrKeyNotFound
+	}
+
+	return key.PublicKey, nil
+}
+
+func (k *keyRing) PublicKeys() []SigningKey {
+	keys := make([]SigningKey, 0, len(k.keys))
+	for _, key := range k.keys {
+		keys = append(keys, SigningKey{ID: key.ID, PublicKey: key.PublicKey})
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].ID < keys[j].ID
+	})
+
+	return keys
+}
+
+func (k *keyRing) add(key SigningKey) {
+	k.keys[key.ID] = key
+}
+
+func NewSigningKey(privateKey *ecdsa.PrivateKey) SigningKey {
+	return SigningKey{
+		ID:         KeyID(&privateKey.PublicKey),
+		PublicKey:  &privateKey.PublicKey,
+		PrivateKey: privateKey,
+	}
+}
+
+func LoadKeyRingDir(dir string, ep EcdsaKeyPair, add func(SigningKey)) (string, error) {
+	if dir == "" {
+		return "", nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+
+		return "", fmt.Errorf("read key dir: %w", err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != keyRingKeyExt {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return "", fmt.Errorf("read key %s: %w", entry.Name(), err)
+		}
+
+		privateKey, err := ep.ParseECDSAPrivateKey(string(data))
+		if err != nil {
+			return "", fmt.Errorf("parse key %s: %w", entry.Name(), err)
+		}
+
+		add(NewSigningKey(privateKey))
+	}
+
+	active, err := os.ReadFile(filepath.Join(dir, KeyRingActiveFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+
+		return "", fmt.Errorf("read active key: %w", err)
+	}
+
+	return strings.TrimSpace(string(active)), nil
+}
+
+func GenerateSigningKey() (SigningKey, string, error) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return SigningKey{}, "", fmt.Errorf("generate key: %w", err)
+	}
+
+	der, err := x509.MarshalECPrivateKey(privateKey)
+	if err != nil {
+		return SigningKey{}, "", fmt.Errorf("marshal key: %w", err)
+	}
+
+	pemKey := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+
+	return NewSigningKey(privateKey), string(pemKey), nil
+}
+
+// Thumbprint RFC 7638, independente do nome do arquivo
+func KeyID(publicKey *ecdsa.PublicKey) string {
+	x, y, err := JWKCoordinates(publicKey)
+	if err != nil {
+		return ""
+	}
+
+	thumbprint := fmt.Sprintf(`{"crv":"P-256","kty":"EC","x":"%s","y":"%s"}`, x, y)
+	sum := sha256.Sum256([]byte(thumbprint))
+
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func JWKCoordinates(publicKey *ecdsa.PublicKey) (string, string, error) {
+	ecdhKey, err := publicKey.ECDH()
+	if err != nil {
+		return "", "", err
+	}
+
+	// Formato não comprimido: 0x04 || X || Y
+	point := ecdhKey.Bytes()[1:]
+	size := len(point) / 2
+
+	return base64.RawURLEncoding.EncodeToString(point[:size]), base64.RawURLEncoding.EncodeToString(point[size:]), nil
+}
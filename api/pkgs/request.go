@@ -2,6 +2,7 @@ package pkgs
 
 import (
 	"context"
+	"time"
 )
 
 type contextKey string
@@ -11,17 +12,29 @@ const (
 	TokenKey     contextKey = "user_token"
 	UserEmailKey contextKey = "user_email"
 	SessionIDKey contextKey = "session_id"
+	MFAAtKey     contextKey = "mfa_at"
+	APIKeyKey    contextKey = "api_key"
 )
 
+type APIKeyPrincipal struct {
+	KeyID   string
+	StoreID string
+	Scopes  []string
+}
+
 type RequestDataCtx interface {
 	SetUserID(ctx context.Context, userID string) context.Context
 	SetToken(ctx context.Context, token string) context.Context
 	SetEmail(ctx context.Context, email string) context.Context
 	SetSessionID(ctx context.Context, sessionID string) context.Context
+	SetMFAAt(ctx context.Context, mfaAt time.Time) context.Context
+	SetAPIKey(ctx context.Context, principal APIKeyPrincipal) context.Context
 	GetUserID(ctx context.Context) (string, bool)
 	GetToken(ctx context.Context) (string, bool)
 	GetEmail(ctx context.Context) (string, bool)
 	GetSessionID(ctx context.Context) (string, bool)
+	GetMFAAt(ctx context.Context) (time.Time, bool)
+	GetAPIKey(ctx context.Context) (APIKeyPrincipal, bool)
 }
 
 type requestDataCtx struct {
@@ -30,6 +43,8 @@ type requestDataCtx struct {
 	TokenKey     contextKey
 	UserEmailKey contextKey
 	SessionIDKey contextKey
+	MFAAtKey     contextKey
+	APIKeyKey    contextKey
 }
 
 func NewRequestInfoCtx(di *Di) (RequestDataCtx, error) {
@@ -39,6 +54,8 @@ func NewRequestInfoCtx(di *Di) (RequestDataCtx, error) {
 		TokenKey:     TokenKey,
 		UserEmailKey: UserEmailKey,
 		SessionIDKey: SessionIDKey,
+		MFAAtKey:     MFAAtKey,
+		APIKeyKey:    APIKeyKey,
 	}, nil
 }
 
@@ -58,6 +75,14 @@ func (r *requestDataCtx) SetSessionID(ctx context.Context, sessionID string) con
 	return context.WithValue(ctx, r.SessionIDKey, sessionID)
 }
 
+func (r *requestDataCtx) SetMFAAt(ctx context.Context, mfaAt time.Time) context.Context {
+	return context.WithValue(ctx, r.MFAAtKey, mfaAt)
+}
+
+func (r *requestDataCtx) SetAPIKey(ctx context.Context, principal APIKeyPrincipal) context.Context {
+	return context.WithValue(ctx, r.APIKeyKey, principal)
+}
+
 func (r *requestDataCtx) GetUserID(ctx context.Context) (string, bool) {
 	UserID, ok := ctx.Value(r.UserIDKey).(string)
 	return UserID, ok
@@ -77,3 +102,13 @@ func (r *requestDataCtx) GetSessionID(ctx context.Context) (string, bool) {
 	sessionID, ok := ctx.Value(r.SessionIDKey).(string)
 	return sessionID, ok
 }
+
+func (r *requestDataCtx) GetMFAAt(ctx context.Context) (time.Time, bool) {
+	mfaAt, ok := ctx.Value(r.MFAAtKey).(time.Time)
+	return mfaAt, ok
+}
+
+func (r *requestDataCtx) GetAPIKey(ctx context.Context) (APIKeyPrincipal, bool) {
+	principal, ok := ctx.Value(r.APIKeyKey).(APIKeyPrincipal)
+	return principal, ok
+}
@@ -3,6 +3,7 @@ package repositories
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/g-villarinho/flash-buy-api/models"
 	"github.com/g-villarinho/flash-buy-api/persistence"
@@ -10,11 +11,13 @@ import (
 )
 
 type BillboardRepository interface {
-	CreateBillboard(ctx context.Context, billboard *models.Billboard) error
+	CreateBillboard(ctx context.Context, billboard *models.Billboard, events ...*models.DomainEvent) error
 	GetBillboardsPagedList(ctx context.Context, storeID string, pag models.BillboardPagination) (*models.PaginatedResponse, error)
-	DeleteBillboard(ctx context.Context, ID string) error
+	UpdateBillboard(ctx context.Context, billboard *models.Billboard, events ...*models.DomainEvent) error
+	DeleteBillboard(ctx context.Context, ID string, events ...*models.DomainEvent) error
 	GetBillboardByID(ctx context.Context, ID string) (*models.Billboard, error)
 	GetAllByStoreID(ctx context.Context, storeID string) ([]models.Billboard, error)
+	GetActiveByStoreID(ctx context.Context, storeID string, now time.Time) ([]models.Billboard, error)
 }
 
 type billboardRepository struct {
@@ -34,12 +37,10 @@ func NewBillboardRepository(di *pkgs.Di) (BillboardRepository, error) {
 	}, nil
 }
 
-func (b *billboardRepository) CreateBillboard(ctx context.Context, billboard *models.Billboard) error {
-	if err := b.repo.Create(ctx, billboard); err != nil {
-		return err
-	}
-
-	return nil
+func (b *billboardRepository) CreateBillboard(ctx context.Context, billboard *models.Billboard, events ...*models.DomainEvent) error {
+	return b.repo.WithEvents(ctx, events, func(ctx context.Context) error {
+		return b.repo.Create(ctx, billboard)
+	})
 }
 
 func (b *billboardRepository) GetBillboardsPagedList(ctx context.Context, storeID string, pag models.BillboardPagination) (*models.PaginatedResponse, error) {
@@ -65,12 +66,16 @@ func (b *billboardRepository) GetBillboardsPagedList(ctx context.Context, storeI
 	return result, nil
 }
 
-func (b *billboardRepository) DeleteBillboard(ctx context.Context, ID string) error {
-	if err := b.repo.Delete(ctx, ID, &models.Billboard{}); err != nil {
-		return err
-	}
+func (b *billboardRepository) UpdateBillboard(ctx context.Context, billboard *models.Billboard, events ...*models.DomainEvent) error {
+	return b.repo.WithEvents(ctx, events, func(ctx context.Context) error {
+		return b.repo.Update(ctx, billboard)
+	})
+}
 
-	return nil
+func (b *billboardRepository) DeleteBillboard(ctx context.Context, ID string, events ...*models.DomainEvent) error {
+	return b.repo.WithEvents(ctx, events, func(ctx context.Context) error {
+		return b.repo.Delete(ctx, ID, &models.Billboard{})
+	})
 }
 
 func (b *billboardRepository) GetBillboardByID(ctx context.Context, ID string) (*models.Billboard, error) {
@@ -95,3 +100,19 @@ func (b *billboardRepository) GetAllByStoreID(ctx context.Context, storeID strin
 
 	return billboards, nil
 }
+
+func (b *billboardRepository) GetActiveByStoreID(ctx context.Context, storeID string, now time.Time) ([]models.Billboard, error) {
+	var billboards []models.Billboard
+
+	err := b.repo.FindAll(ctx, &billboards,
+		persistence.WithConditions("store_id = ?", storeID),
+		persistence.WithConditions("(starts_at IS NULL OR starts_at <= ?)", now),
+		persistence.WithConditions("(ends_at IS NULL OR ends_at > ?)", now),
+		persistence.WithOrder("starts_at DESC NULLS LAST, created_at DESC"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return billboards, nil
+}
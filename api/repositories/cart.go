@@ -0,0 +1,139 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/g-villarinho/flash-buy-api/models"
+	"github.com/g-villarinho/flash-buy-api/persistence"
+	"github.com/g-villarinho/flash-buy-api/pkgs"
+)
+
+type CartRepository interface {
+	CreateCart(ctx context.Context, cart *models.Cart) error
+	GetCartByToken(ctx context.Context, storeID, token string) (*models.Cart, error)
+	GetCartByCustomerID(ctx context.Context, storeID, customerID string) (*models.Cart, error)
+	UpdateCart(ctx context.Context, cart *models.Cart) error
+	DeleteCart(ctx context.Context, ID string) error
+	DeleteExpiredCarts(ctx context.Context, now time.Time) error
+	CreateCartItem(ctx context.Context, item *models.CartItem) error
+	UpdateCartItem(ctx context.Context, item *models.CartItem) error
+	DeleteCartItem(ctx context.Context, ID string) error
+}
+
+type cartRepository struct {
+	di   *pkgs.Di
+	repo persistence.Repository
+}
+
+func NewCartRepository(di *pkgs.Di) (CartRepository, error) {
+	repo, err := pkgs.Invoke[persistence.Repository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cartRepository{
+		di:   di,
+		repo: repo,
+	}, nil
+}
+
+func (c *cartRepository) CreateCart(ctx context.Context, cart *models.Cart) error {
+	if err := c.repo.Create(ctx, cart); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *cartRepository) GetCartByToken(ctx context.Context, storeID, token string) (*models.Cart, error) {
+	return c.findCart(ctx, persistence.WithConditions("store_id = ? AND token = ?", storeID, token))
+}
+
+func (c *cartRepository) GetCartByCustomerID(ctx context.Context, storeID, customerID string) (*models.Cart, error) {
+	return c.findCart(ctx,
+		persistence.WithConditions("store_id = ? AND customer_id = ?", storeID, customerID),
+		persistence.WithOrder("created_at DESC"),
+	)
+}
+
+func (c *cartRepository) UpdateCart(ctx context.Context, cart *models.Cart) error {
+	// O Save regravaria as associações carregadas no preload
+	cartWithoutItems := *cart
+	cartWithoutItems.Items = nil
+
+	if err := c.repo.Update(ctx, &cartWithoutItems); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *cartRepository) DeleteCart(ctx context.Context, ID string) error {
+	if err := c.repo.Delete(ctx, ID, &models.Cart{}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *cartRepository) DeleteExpiredCarts(ctx context.Context, now time.Time) error {
+	if err := c.repo.DeleteAll(ctx, &models.Cart{}, persistence.WithConditions("expires_at < ?", now)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *cartRepository) CreateCartItem(ctx context.Context, item *models.CartItem) error {
+	if err := c.repo.Create(ctx, item); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *cartRepository) UpdateCartItem(ctx context.Context, item *models.CartItem) error {
+	itemWithoutProduct := *item
+	itemWithoutProduct.Product = models.Product{}
+	itemWithoutProduct.Variant = nil
+
+	if err := c.repo.Update(ctx, &itemWithoutProduct); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *cartRepository) DeleteCartItem(ctx context.Context, ID string) error {
+	if err := c.repo.Delete(ctx, ID, &models.CartItem{}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *cartRepository) findCart(ctx context.Context, opts ...persistence.QueryOption) (*models.Cart, error) {
+	var cart models.Cart
+
+	opts = append(opts,
+		persistence.WithPreload("Items.Product.Category"),
+		persistence.WithPreload("Items.Product.Color"),
+		persistence.WithPreload("Items.Product.Size"),
+		persistence.WithOrderedPreload("Items.Product.ProductImages", models.ProductImageOrder),
+		persistence.WithPreload("Items.Product.Variants.Size"),
+		persistence.WithPreload("Items.Product.Variants.Color"),
+		persistence.WithPreload("Items.Variant.Size"),
+		persistence.WithPreload("Items.Variant.Color"),
+	)
+
+	if err := c.repo.FindOne(ctx, &cart, opts...); err != nil {
+		if err == persistence.ErrRecordNotFound {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &cart, nil
+}
@@ -3,10 +3,12 @@ package repositories
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/g-villarinho/flash-buy-api/models"
 	"github.com/g-villarinho/flash-buy-api/persistence"
 	"github.com/g-villarinho/flash-buy-api/pkgs"
+	"github.com/google/uuid"
 )
 
 type CategoryRepository interface {
@@ -14,6 +16,14 @@ type CategoryRepository interface {
 	GetCategoriesPagedList(ctx context.Context, storeID string, pag models.CategoryPagination) (*models.PaginatedResponse, error)
 	GetCategoryByID(ctx context.Context, ID string) (*models.Category, error)
 	DeleteCategory(ctx context.Context, ID string) error
+	GetAllByStoreID(ctx context.Context, storeID string) ([]models.Category, error)
+	GetCategoryTree(ctx context.Context, storeID string) ([]models.Category, error)
+	GetChildCategories(ctx context.Context, storeID string, parentID uuid.NullUUID) ([]models.Category, error)
+	GetSubtreeIDs(ctx context.Context, ID string) ([]uuid.UUID, error)
+	HasChildCategories(ctx context.Context, ID string) (bool, error)
+	GetNextCategoryPosition(ctx context.Context, storeID string, parentID uuid.NullUUID) (int, error)
+	MoveCategory(ctx context.Context, ID string, parentID uuid.NullUUID, position int) error
+	LockStoreCategories(ctx context.Context, storeID string) error
 }
 
 type categoryRepository struct {
@@ -90,3 +100,122 @@ func (c *categoryRepository) DeleteCategory(ctx context.Context, ID string) erro
 
 	return nil
 }
+
+func (c *categoryRepository) GetAllByStoreID(ctx context.Context, storeID string) ([]models.Category, error) {
+	var categories []models.Category
+
+	err := c.repo.FindAll(ctx, &categories,
+		persistence.WithConditions("store_id = ?", storeID),
+		persistence.WithPreload("Billboard"),
+		persistence.WithOrder("name ASC"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return categories, nil
+}
+
+func (c *categoryRepository) GetCategoryTree(ctx context.Context, storeID string) ([]models.Category, error) {
+	var categories []models.Category
+
+	err := c.repo.Raw(ctx, &categories, `
+		WITH RECURSIVE tree AS (
+			SELECT categories.*, 0 AS depth
+			FROM categories
+			WHERE store_id = ? AND parent_id IS NULL
+			UNION ALL
+			SELECT child.*, tree.depth + 1
+			FROM categories child
+			JOIN tree ON child.parent_id = tree.id
+		)
+		SELECT * FROM tree
+		ORDER BY depth, position, name
+	`, storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	return categories, nil
+}
+
+func (c *categoryRepository) GetChildCategories(ctx context.Context, storeID string, parentID uuid.NullUUID) ([]models.Category, error) {
+	var categories []models.Category
+
+	err := c.repo.FindAll(ctx, &categories,
+		persistence.WithConditions("store_id = ? AND parent_id IS NOT DISTINCT FROM ?", storeID, parentID),
+		persistence.WithPreload("Billboard"),
+		persistence.WithOrder(models.CategoryOrder),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return categories, nil
+}
+
+func (c *categoryRepository) GetSubtreeIDs(ctx context.Context, ID string) ([]uuid.UUID, error) {
+	var IDs []uuid.UUID
+
+	err := c.repo.Raw(ctx, &IDs, `
+		WITH RECURSIVE subtree AS (
+			SELECT id FROM categories WHERE id = ?
+			UNION ALL
+			SELECT child.id FROM categories child JOIN subtree ON child.parent_id = subtree.id
+		)
+		SELECT id FROM subtree
+	`, ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return IDs, nil
+}
+
+func (c *categoryRepository) HasChildCategories(ctx context.Context, ID string) (bool, error) {
+	count, err := c.repo.Count(ctx, &models.Category{}, persistence.WithConditions("parent_id = ?", ID))
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func (c *categoryRepository) GetNextCategoryPosition(ctx context.Context, storeID string, parentID uuid.NullUUID) (int, error) {
+	var position int
+
+	err := c.repo.Raw(ctx, &position,
+		"SELECT COALESCE(MAX(position) + 1, 0) FROM categories WHERE store_id = ? AND parent_id IS NOT DISTINCT FROM ?",
+		storeID, parentID,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	return position, nil
+}
+
+func (c *categoryRepository) MoveCategory(ctx context.Context, ID string, parentID uuid.NullUUID, position int) error {
+	_, err := c.repo.UpdateColumns(ctx, &models.Category{},
+		map[string]any{
+			"parent_id":  parentID,
+			"position":   position,
+			"updated_at": time.Now(),
+		},
+		persistence.WithConditions("id = ?", ID),
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Sem a trava, dois movimentos simultâneos podem formar um ciclo
+func (c *categoryRepository) LockStoreCategories(ctx context.Context, storeID string) error {
+	if _, err := c.repo.Exec(ctx, "SELECT pg_advisory_xact_lock(hashtext(?))", "categories:"+storeID); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,183 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/g-villarinho/flash-buy-api/models"
+	"github.com/g-villarinho/flash-buy-api/persistence"
+	"github.com/g-villarinho/flash-buy-api/pkgs"
+	"github.com/google/uuid"
+)
+
+type FlashSaleRepository interface {
+	CreateFlashSale(ctx context.Context, sale *models.FlashSale) error
+	GetFlashSalesPagedList(ctx context.Context, storeID string, pag models.FlashSalePagination) (*models.PaginatedResponse, error)
+	GetFlashSaleByID(ctx context.Context, ID string) (*models.FlashSale, error)
+	GetUpcomingFlashSalesByStoreID(ctx context.Context, storeID string, now time.Time) ([]models.FlashSale, error)
+	GetActiveFlashSaleItems(ctx context.Context, storeID string, productIDs []uuid.UUID, now time.Time) ([]models.FlashSaleItem, error)
+	DeleteFlashSale(ctx context.Context, ID string) error
+	IncrementSoldQuantity(ctx context.Context, ID uuid.UUID, quantity int64, now time.Time) (bool, error)
+	DecrementSoldQuantity(ctx context.Context, ID uuid.UUID, quantity int64) error
+	IncrementCustomerPurchase(ctx context.Context, ID uuid.UUID, customerKey string, quantity, limit int64) (bool, error)
+	DecrementCustomerPurchase(ctx context.Context, ID uuid.UUID, customerKey string, quantity int64) error
+}
+
+type flashSaleRepository struct {
+	di   *pkgs.Di
+	repo persistence.Repository
+}
+
+func NewFlashSaleRepository(di *pkgs.Di) (FlashSaleRepository, error) {
+	repo, err := pkgs.Invoke[persistence.Repository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &flashSaleRepository{
+		di:   di,
+		repo: repo,
+	}, nil
+}
+
+func (f *flashSaleRepository) CreateFlashSale(ctx context.Context, sale *models.FlashSale) error {
+	if err := f.repo.Create(ctx, sale); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (f *flashSaleRepository) GetFlashSalesPagedList(ctx context.Context, storeID string, pag models.FlashSalePagination) (*models.PaginatedResponse, error) {
+	var sales []models.FlashSale
+
+	opts := []persistence.QueryOption{}
+
+	opts = append(opts, persistence.WithConditions("store_id = ?", storeID))
+	opts = append(opts, persistence.WithPreload("Items.Product"))
+	opts = append(opts, persistence.WithOrder("starts_at DESC"))
+
+	if pag.Name != nil {
+		opts = append(opts, persistence.WithConditions("name LIKE ?", fmt.Sprintf("%%%s%%", *pag.Name)))
+	}
+
+	result, err := f.repo.Paginate(ctx, &sales, *pag.Pagination, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (f *flashSaleRepository) GetFlashSaleByID(ctx context.Context, ID string) (*models.FlashSale, error) {
+	var sale models.FlashSale
+
+	err := f.repo.FindOne(ctx, &sale,
+		persistence.WithConditions("id = ?", ID),
+		persistence.WithPreload("Items.Product"),
+	)
+	if err != nil {
+		if err == persistence.ErrRecordNotFound {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &sale, nil
+}
+
+func (f *flashSaleRepository) GetUpcomingFlashSalesByStoreID(ctx context.Context, storeID string, now time.Time) ([]models.FlashSale, error) {
+	var sales []models.FlashSale
+
+	err := f.repo.FindAll(ctx, &sales,
+		persistence.WithConditions("store_id = ? AND ends_at > ?", storeID, now),
+		persistence.WithPreload("Items.Product"),
+		persistence.WithOrder("starts_at ASC"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return sales, nil
+}
+
+func (f *flashSaleRepository) GetActiveFlashSaleItems(ctx context.Context, storeID string, productIDs []uuid.UUID, now time.Time) ([]models.FlashSaleItem, error) {
+	var items []models.FlashSaleItem
+
+	err := f.repo.FindAll(ctx, &items,
+		persistence.WithConditions("product_id IN ?", productIDs),
+		persistence.WithConditions(
+			"flash_sale_id IN (SELECT id FROM flash_sales WHERE store_id = ? AND starts_at <= ? AND ends_at > ?)",
+			storeID, now, now,
+		),
+		persistence.WithPreload("FlashSale"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+func (f *flashSaleRepository) DeleteFlashSale(ctx context.Context, ID string) error {
+	if err := f.repo.Delete(ctx, ID, &models.FlashSale{}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (f *flashSaleRepository) IncrementSoldQuantity(ctx context.Context, ID uuid.UUID, quantity int64, now time.Time) (bool, error) {
+	affected, err := f.repo.UpdateColumns(ctx, &models.FlashSale{ID: ID},
+		map[string]any{"sold_quantity": persistence.Expr("sold_quantity + ?", quantity)},
+		persistence.WithConditions("sold_quantity + ? <= quantity_limit", quantity),
+		persistence.WithConditions("starts_at <= ? AND ends_at > ?", now, now),
+	)
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+func (f *flashSaleRepository) DecrementSoldQuantity(ctx context.Context, ID uuid.UUID, quantity int64) error {
+	_, err := f.repo.UpdateColumns(ctx, &models.FlashSale{ID: ID},
+		map[string]any{"sold_quantity": persistence.Expr("GREATEST(sold_quantity - ?, 0)", quantity)},
+	)
+
+	return err
+}
+
+func (f *flashSaleRepository) IncrementCustomerPurchase(ctx context.Context, ID uuid.UUID, customerKey string, quantity, limit int64) (bool, error) {
+	if quantity > limit {
+		return false, nil
+	}
+
+	now := time.Now()
+	affected, err := f.repo.Exec(ctx, `
+		INSERT INTO flash_sale_purchases (id, flash_sale_id, customer_key, quantity, created_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (flash_sale_id, customer_key) DO UPDATE
+		SET quantity = flash_sale_purchases.quantity + EXCLUDED.quantity, updated_at = ?
+		WHERE flash_sale_purchases.quantity + EXCLUDED.quantity <= ?`,
+		uuid.New(), ID, customerKey, quantity, now, now, limit,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+func (f *flashSaleRepository) DecrementCustomerPurchase(ctx context.Context, ID uuid.UUID, customerKey string, quantity int64) error {
+	_, err := f.repo.Exec(ctx, `
+		UPDATE flash_sale_purchases
+		SET quantity = GREATEST(quantity - ?, 0), updated_at = ?
+		WHERE flash_sale_id = ? AND customer_key = ?`,
+		quantity, time.Now(), ID, customerKey,
+	)
+
+	return err
+}
@@ -0,0 +1,129 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/g-villarinho/flash-buy-api/models"
+	"github.com/g-villarinho/flash-buy-api/persistence"
+	"github.com/g-villarinho/flash-buy-api/pkgs"
+)
+
+type JobRepository interface {
+	CreateJob(ctx context.Context, job *models.Job) error
+	ClaimJobs(ctx context.Context, types []models.JobType, limit int, now, lockedUntil time.Time) ([]models.Job, error)
+	CompleteJob(ctx context.Context, ID string, attempts int) error
+	RetryJob(ctx context.Context, ID string, attempts int, runAt time.Time, lastError string) error
+	DeadLetterJob(ctx context.Context, ID string, attempts int, lastError string) (bool, error)
+	RequeueDeadJob(ctx context.Context, ID string, runAt time.Time) (bool, error)
+}
+
+type jobRepository struct {
+	di   *pkgs.Di
+	repo persistence.Repository
+}
+
+func NewJobRepository(di *pkgs.Di) (JobRepository, error) {
+	repo, err := pkgs.Invoke[persistence.Repository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &jobRepository{
+		di:   di,
+		repo: repo,
+	}, nil
+}
+
+func (j *jobRepository) CreateJob(ctx context.Context, job *models.Job) error {
+	if err := j.repo.Create(ctx, job); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Um job running com a reserva vencida é de um worker que caiu
+func (j *jobRepository) ClaimJobs(ctx context.Context, types []models.JobType, limit int, now, lockedUntil time.Time) ([]models.Job, error) {
+	var jobs []models.Job
+
+	err := j.repo.Raw(ctx, &jobs, `
+		UPDATE jobs SET status = ?, attempts = attempts + 1, locked_until = ?
+		WHERE id IN (
+			SELECT id FROM jobs
+			WHERE type IN ?
+			  AND ((status = ? AND run_at <= ?) OR (status = ? AND locked_until <= ?))
+			ORDER BY run_at
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *
+	`,
+		models.JobStatusRunning, lockedUntil,
+		types,
+		models.JobStatusPending, now, models.JobStatusRunning, now,
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+// attempts identifica a reserva; se outro worker reassumiu o job nada é alterado
+func (j *jobRepository) CompleteJob(ctx context.Context, ID string, attempts int) error {
+	return j.repo.DeleteAll(ctx, &models.Job{},
+		persistence.WithConditions("id = ? AND status = ? AND attempts = ?", ID, models.JobStatusRunning, attempts),
+	)
+}
+
+func (j *jobRepository) RetryJob(ctx context.Context, ID string, attempts int, runAt time.Time, lastError string) error {
+	_, err := j.repo.UpdateColumns(ctx, &models.Job{},
+		map[string]any{
+			"status":       models.JobStatusPending,
+			"run_at":       runAt,
+			"locked_until": nil,
+			"last_error":   lastError,
+		},
+		persistence.WithConditions("id = ? AND status = ? AND attempts = ?", ID, models.JobStatusRunning, attempts),
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (j *jobRepository) DeadLetterJob(ctx context.Context, ID string, attempts int, lastError string) (bool, error) {
+	affected, err := j.repo.UpdateColumns(ctx, &models.Job{},
+		map[string]any{
+			"status":       models.JobStatusDead,
+			"locked_until": nil,
+			"last_error":   lastError,
+		},
+		persistence.WithConditions("id = ? AND status = ? AND attempts = ?", ID, models.JobStatusRunning, attempts),
+	)
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+func (j *jobRepository) RequeueDeadJob(ctx context.Context, ID string, runAt time.Time) (bool, error) {
+	affected, err := j.repo.UpdateColumns(ctx, &models.Job{},
+		map[string]any{
+			"status":     models.JobStatusPending,
+			"attempts":   0,
+			"run_at":     runAt,
+			"last_error": "",
+		},
+		persistence.WithConditions("id = ? AND status = ?", ID, models.JobStatusDead),
+	)
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
@@ -0,0 +1,157 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/g-villarinho/flash-buy-api/models"
+	"github.com/g-villarinho/flash-buy-api/persistence"
+	"github.com/g-villarinho/flash-buy-api/pkgs"
+)
+
+var errOrderStatusChanged = errors.New("order status changed")
+
+type OrderRepository interface {
+	CreateOrder(ctx context.Context, order *models.Order, events ...*models.DomainEvent) error
+	GetOrdersPagedList(ctx context.Context, storeID string, pag models.OrderPagination) (*models.PaginatedResponse, error)
+	GetOrderByID(ctx context.Context, ID string) (*models.Order, error)
+	GetOrderByPaymentID(ctx context.Context, paymentID string) (*models.Order, error)
+	UpdateOrder(ctx context.Context, order *models.Order) error
+	UpdateOrderStatus(ctx context.Context, order *models.Order, from models.OrderStatus, events ...*models.DomainEvent) (bool, error)
+	GetPendingOrdersCreatedBefore(ctx context.Context, before time.Time, limit int) ([]models.Order, error)
+}
+
+type orderRepository struct {
+	di   *pkgs.Di
+	repo persistence.Repository
+}
+
+func NewOrderRepository(di *pkgs.Di) (OrderRepository, error) {
+	repo, err := pkgs.Invoke[persistence.Repository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &orderRepository{
+		di:   di,
+		repo: repo,
+	}, nil
+}
+
+func (o *orderRepository) CreateOrder(ctx context.Context, order *models.Order, events ...*models.DomainEvent) error {
+	return o.repo.WithEvents(ctx, events, func(ctx context.Context) error {
+		return o.repo.Create(ctx, order)
+	})
+}
+
+func (o *orderRepository) GetOrdersPagedList(ctx context.Context, storeID string, pag models.OrderPagination) (*models.PaginatedResponse, error) {
+	var orders []models.Order
+
+	opts := []persistence.QueryOption{}
+
+	opts = append(opts, persistence.WithConditions("store_id = ?", storeID))
+	opts = append(opts, persistence.WithPreload("Items"))
+	opts = append(opts, persistence.WithOrder("created_at DESC"))
+
+	if pag.Status != nil {
+		opts = append(opts, persistence.WithConditions("status = ?", *pag.Status))
+	}
+
+	if pag.CustomerEmail != nil {
+		opts = append(opts, persistence.WithConditions("customer_email LIKE ?", fmt.Sprintf("%%%s%%", *pag.CustomerEmail)))
+	}
+
+	result, err := o.repo.Paginate(ctx, &orders, *pag.Pagination, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (o *orderRepository) GetOrderByID(ctx context.Context, ID string) (*models.Order, error) {
+	var order models.Order
+
+	err := o.repo.FindOne(ctx, &order, persistence.WithConditions("id = ?", ID), persistence.WithPreload("Items"))
+	if err != nil {
+		if err == persistence.ErrRecordNotFound {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &order, nil
+}
+
+func (o *orderRepository) GetOrderByPaymentID(ctx context.Context, paymentID string) (*models.Order, error) {
+	var order models.Order
+
+	err := o.repo.FindOne(ctx, &order, persistence.WithConditions("payment_id = ?", paymentID), persistence.WithPreload("Items"))
+	if err != nil {
+		if err == persistence.ErrRecordNotFound {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &order, nil
+}
+
+func (o *orderRepository) UpdateOrder(ctx context.Context, order *models.Order) error {
+	orderWithoutItems := *order
+	orderWithoutItems.Items = nil
+
+	if err := o.repo.Update(ctx, &orderWithoutItems); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Só grava se o pedido ainda estiver em from, a requisição concorrente que perdeu desfaz a transação
+func (o *orderRepository) UpdateOrderStatus(ctx context.Context, order *models.Order, from models.OrderStatus, events ...*models.DomainEvent) (bool, error) {
+	err := o.repo.WithEvents(ctx, events, func(ctx context.Context) error {
+		affected, err := o.repo.UpdateColumns(ctx, &models.Order{ID: order.ID},
+			map[string]any{"status": order.Status, "updated_at": order.UpdatedAt},
+			persistence.WithConditions("status = ?", from),
+		)
+		if err != nil {
+			return err
+		}
+
+		if affected == 0 {
+			return errOrderStatusChanged
+		}
+
+		return nil
+	})
+	if err == errOrderStatusChanged {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (o *orderRepository) GetPendingOrdersCreatedBefore(ctx context.Context, before time.Time, limit int) ([]models.Order, error) {
+	var orders []models.Order
+
+	err := o.repo.FindAll(ctx, &orders,
+		persistence.WithConditions("status = ? AND created_at < ?", models.OrderStatusPending, before),
+		persistence.WithPreload("Items"),
+		persistence.WithOrder("created_at ASC"),
+		persistence.WithPagination(1, limit),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return orders, nil
+}
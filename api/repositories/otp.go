@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"github.com/g-villarinho/flash-buy-api/models"
 	"github.com/g-villarinho/flash-buy-api/persistence"
@@ -14,6 +15,10 @@ type OTPRepository interface {
 	DeleteOTP(ctx context.Context, ID string) error
 	GetOTPByVerificationToken(ctx context.Context, token string) (*models.OTP, error)
 	UpdateOTP(ctx context.Context, otp models.OTP) error
+	GetOTPByID(ctx context.Context, ID string) (*models.OTP, error)
+	ConsumeOTP(ctx context.Context, ID string) (bool, error)
+	IncrementFailedAttempts(ctx context.Context, ID string, maxAttempts int) (int, error)
+	ResendOTP(ctx context.Context, ID, code string, expiresAt, sentAt, cooldownCutoff time.Time) (bool, error)
 }
 
 type otpRepository struct {
@@ -79,3 +84,61 @@ func (o *otpRepository) UpdateOTP(ctx context.Context, otp models.OTP) error {
 
 	return nil
 }
+
+func (o *otpRepository) GetOTPByID(ctx context.Context, ID string) (*models.OTP, error) {
+	var otp models.OTP
+	if err := o.repo.FindByID(ctx, ID, &otp); err != nil {
+		if err == persistence.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &otp, nil
+}
+
+func (o *otpRepository) ConsumeOTP(ctx context.Context, ID string) (bool, error) {
+	affected, err := o.repo.Exec(ctx, "DELETE FROM otps WHERE id = ?", ID)
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+// Retorna 0 quando o OTP já estava bloqueado
+func (o *otpRepository) IncrementFailedAttempts(ctx context.Context, ID string, maxAttempts int) (int, error) {
+	otp := models.OTP{}
+
+	affected, err := o.repo.UpdateColumns(ctx, &otp,
+		map[string]any{"failed_attempts": persistence.Expr("failed_attempts + 1")},
+		persistence.WithConditions("id = ? AND failed_attempts < ?", ID, maxAttempts),
+		persistence.WithReturning("failed_attempts"),
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	if affected == 0 {
+		return 0, nil
+	}
+
+	return otp.FailedAttempts, nil
+}
+
+func (o *otpRepository) ResendOTP(ctx context.Context, ID, code string, expiresAt, sentAt, cooldownCutoff time.Time) (bool, error) {
+	affected, err := o.repo.UpdateColumns(ctx, &models.OTP{},
+		map[string]any{
+			"code":            code,
+			"expires_at":      expiresAt,
+			"last_sent_at":    sentAt,
+			"failed_attempts": 0,
+		},
+		persistence.WithConditions("id = ? AND last_sent_at <= ?", ID, cooldownCutoff),
+	)
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
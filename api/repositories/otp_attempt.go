@@ -0,0 +1,51 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/g-villarinho/flash-buy-api/models"
+	"github.com/g-villarinho/flash-buy-api/persistence"
+	"github.com/g-villarinho/flash-buy-api/pkgs"
+)
+
+type OTPAttemptRepository interface {
+	CreateOTPAttempt(ctx context.Context, attempt *models.OTPAttempt) error
+	CountOTPAttemptsSince(ctx context.Context, userID string, attemptType models.OTPAttemptType, since time.Time) (int64, error)
+}
+
+type otpAttemptRepository struct {
+	di   *pkgs.Di
+	repo persistence.Repository
+}
+
+func NewOTPAttemptRepository(di *pkgs.Di) (OTPAttemptRepository, error) {
+	repo, err := pkgs.Invoke[persistence.Repository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &otpAttemptRepository{
+		di:   di,
+		repo: repo,
+	}, nil
+}
+
+func (o *otpAttemptRepository) CreateOTPAttempt(ctx context.Context, attempt *models.OTPAttempt) error {
+	if err := o.repo.Create(ctx, attempt); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (o *otpAttemptRepository) CountOTPAttemptsSince(ctx context.Context, userID string, attemptType models.OTPAttemptType, since time.Time) (int64, error) {
+	count, err := o.repo.Count(ctx, &models.OTPAttempt{},
+		persistence.WithConditions("user_id = ? AND type = ? AND created_at >= ?", userID, attemptType, since),
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
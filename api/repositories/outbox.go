@@ -0,0 +1,77 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/g-villarinho/flash-buy-api/models"
+	"github.com/g-villarinho/flash-buy-api/persistence"
+	"github.com/g-villarinho/flash-buy-api/pkgs"
+)
+
+type OutboxRepository interface {
+	ClaimEvents(ctx context.Context, limit int, now, lockedUntil time.Time) ([]models.DomainEvent, error)
+	DeleteEvent(ctx context.Context, ID string) error
+	RetryEvent(ctx context.Context, ID string, availableAt time.Time, lastError string) error
+}
+
+type outboxRepository struct {
+	di   *pkgs.Di
+	repo persistence.Repository
+}
+
+func NewOutboxRepository(di *pkgs.Di) (OutboxRepository, error) {
+	repo, err := pkgs.Invoke[persistence.Repository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &outboxRepository{
+		di:   di,
+		repo: repo,
+	}, nil
+}
+
+func (o *outboxRepository) ClaimEvents(ctx context.Context, limit int, now, lockedUntil time.Time) ([]models.DomainEvent, error) {
+	var events []models.DomainEvent
+
+	err := o.repo.Raw(ctx, &events, `
+		UPDATE outbox_events SET attempts = attempts + 1, available_at = ?
+		WHERE id IN (
+			SELECT id FROM outbox_events
+			WHERE available_at <= ?
+			ORDER BY created_at
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *
+	`, lockedUntil, now, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func (o *outboxRepository) DeleteEvent(ctx context.Context, ID string) error {
+	if err := o.repo.Delete(ctx, ID, &models.DomainEvent{}); err != nil && err != persistence.ErrRecordNotFound {
+		return err
+	}
+
+	return nil
+}
+
+func (o *outboxRepository) RetryEvent(ctx context.Context, ID string, availableAt time.Time, lastError string) error {
+	_, err := o.repo.UpdateColumns(ctx, &models.DomainEvent{},
+		map[string]any{
+			"available_at": availableAt,
+			"last_error":   lastError,
+		},
+		persistence.WithConditions("id = ?", ID),
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
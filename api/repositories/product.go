@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/g-villarinho/flash-buy-api/models"
 	"github.com/g-villarinho/flash-buy-api/persistence"
@@ -9,7 +10,13 @@ import (
 )
 
 type ProductRepository interface {
-	CreateProduct(ctx context.Context, product *models.Product) error
+	CreateProduct(ctx context.Context, product *models.Product, events ...*models.DomainEvent) error
+	GetProductsPagedList(ctx context.Context, storeID string, pag models.ProductPagination) (*models.PaginatedResponse, error)
+	GetProductByID(ctx context.Context, ID string) (*models.Product, error)
+	GetProductDetailsByID(ctx context.Context, ID string) (*models.Product, error)
+	UpdateProduct(ctx context.Context, product *models.Product, events ...*models.DomainEvent) error
+	DeleteProduct(ctx context.Context, ID string, events ...*models.DomainEvent) error
+	AdjustStock(ctx context.Context, product *models.Product, delta int64) (bool, error)
 }
 
 type productRepository struct {
@@ -29,10 +36,131 @@ func NewProductRepository(di *pkgs.Di) (ProductRepository, error) {
 	}, nil
 }
 
-func (p *productRepository) CreateProduct(ctx context.Context, product *models.Product) error {
-	if err := p.repo.Create(ctx, product); err != nil {
-		return err
+func (p *productRepository) CreateProduct(ctx context.Context, product *models.Product, events ...*models.DomainEvent) error {
+	return p.repo.WithEvents(ctx, events, func(ctx context.Context) error {
+		return p.repo.Create(ctx, product)
+	})
+}
+
+func (p *productRepository) GetProductsPagedList(ctx context.Context, storeID string, pag models.ProductPagination) (*models.PaginatedResponse, error) {
+	var products []models.Product
+
+	opts := []persistence.QueryOption{}
+
+	opts = append(opts, persistence.WithConditions("store_id = ?", storeID))
+	opts = append(opts, persistence.WithPreload("Category"))
+	opts = append(opts, persistence.WithPreload("Color"))
+	opts = append(opts, persistence.WithPreload("Size"))
+	opts = append(opts, persistence.WithOrderedPreload("ProductImages", models.ProductImageOrder))
+	opts = append(opts, persistence.WithPreload("Variants.Size"))
+	opts = append(opts, persistence.WithPreload("Variants.Color"))
+	opts = append(opts, persistence.WithOrder("created_at DESC"))
+
+	if pag.Name != nil {
+		opts = append(opts, persistence.WithConditions("name LIKE ?", fmt.Sprintf("%%%s%%", *pag.Name)))
+	}
+
+	if pag.CategoryID != nil && pag.IncludeSubcategories {
+		opts = append(opts, persistence.WithConditions(`category_id IN (
+			WITH RECURSIVE subtree AS (
+				SELECT id FROM categories WHERE id = ?
+				UNION ALL
+				SELECT child.id FROM categories child JOIN subtree ON child.parent_id = subtree.id
+			)
+			SELECT id FROM subtree
+		)`, *pag.CategoryID))
+	} else if pag.CategoryID != nil {
+		opts = append(opts, persistence.WithConditions("category_id = ?", *pag.CategoryID))
+	}
+
+	if pag.ColorID != nil {
+		opts = append(opts, persistence.WithConditions(
+			"(color_id = ? OR id IN (SELECT product_id FROM product_variants WHERE color_id = ?))",
+			*pag.ColorID, *pag.ColorID,
+		))
+	}
+
+	if pag.SizeID != nil {
+		opts = append(opts, persistence.WithConditions(
+			"(size_id = ? OR id IN (SELECT product_id FROM product_variants WHERE size_id = ?))",
+			*pag.SizeID, *pag.SizeID,
+		))
+	}
+
+	if pag.IsFeatured != nil {
+		opts = append(opts, persistence.WithConditions("is_featured = ?", *pag.IsFeatured))
+	}
+
+	if pag.IsArchived != nil {
+		opts = append(opts, persistence.WithConditions("is_archived = ?", *pag.IsArchived))
+	}
+
+	result, err := p.repo.Paginate(ctx, &products, *pag.Pagination, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (p *productRepository) GetProductByID(ctx context.Context, ID string) (*models.Product, error) {
+	var product models.Product
+	if err := p.repo.FindByID(ctx, ID, &product); err != nil {
+		if err == persistence.ErrRecordNotFound {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &product, nil
+}
+
+func (p *productRepository) GetProductDetailsByID(ctx context.Context, ID string) (*models.Product, error) {
+	var product models.Product
+
+	err := p.repo.FindOne(ctx, &product,
+		persistence.WithConditions("id = ?", ID),
+		persistence.WithPreload("Category"),
+		persistence.WithPreload("Color"),
+		persistence.WithPreload("Size"),
+		persistence.WithOrderedPreload("ProductImages", models.ProductImageOrder),
+		persistence.WithPreload("Variants.Size"),
+		persistence.WithPreload("Variants.Color"),
+	)
+	if err != nil {
+		if err == persistence.ErrRecordNotFound {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &product, nil
+}
+
+// O estoque só muda através do AdjustStock
+func (p *productRepository) UpdateProduct(ctx context.Context, product *models.Product, events ...*models.DomainEvent) error {
+	return p.repo.WithEvents(ctx, events, func(ctx context.Context) error {
+		return p.repo.Update(ctx, product, persistence.WithOmit("stock"))
+	})
+}
+
+func (p *productRepository) DeleteProduct(ctx context.Context, ID string, events ...*models.DomainEvent) error {
+	return p.repo.WithEvents(ctx, events, func(ctx context.Context) error {
+		return p.repo.Delete(ctx, ID, &models.Product{})
+	})
+}
+
+func (p *productRepository) AdjustStock(ctx context.Context, product *models.Product, delta int64) (bool, error) {
+	affected, err := p.repo.UpdateColumns(ctx, product,
+		map[string]any{"stock": persistence.Expr("stock + ?", delta)},
+		persistence.WithConditions("stock + ? >= 0", delta),
+		persistence.WithReturning("stock"),
+	)
+	if err != nil {
+		return false, err
 	}
 
-	return nil
+	return affected > 0, nil
 }
@@ -2,14 +2,27 @@ package repositories
 
 import (
 	"context"
+	"encoding/json"
+	"time"
 
 	"github.com/g-villarinho/flash-buy-api/models"
 	"github.com/g-villarinho/flash-buy-api/persistence"
 	"github.com/g-villarinho/flash-buy-api/pkgs"
+	"github.com/google/uuid"
 )
 
 type ProductImageRepository interface {
 	CreateProductImage(ctx context.Context, productImage *models.ProductImage) error
+	GetProductImageByID(ctx context.Context, ID string) (*models.ProductImage, error)
+	GetProductImagesByProductID(ctx context.Context, productID string) ([]models.ProductImage, error)
+	GetNextProductImagePosition(ctx context.Context, productID string) (int, error)
+	UpdateProductImagePosition(ctx context.Context, ID string, position int) error
+	SetPrimaryProductImage(ctx context.Context, productID, ID string) error
+	MarkProductImageReady(ctx context.Context, ID string, renditions models.ImageRenditions) (bool, error)
+	MarkProductImageFailed(ctx context.Context, ID string, jobID uuid.UUID, lastError string) error
+	MarkProductImagePending(ctx context.Context, ID string) error
+	DeleteProductImage(ctx context.Context, ID string) error
+	DeleteProductImagesByProductID(ctx context.Context, productID string) ([]models.ProductImage, error)
 }
 
 type productImageRepository struct {
@@ -36,3 +49,155 @@ func (p *productImageRepository) CreateProductImage(ctx context.Context, product
 
 	return nil
 }
+
+func (p *productImageRepository) GetProductImageByID(ctx context.Context, ID string) (*models.ProductImage, error) {
+	var productImage models.ProductImage
+	if err := p.repo.FindByID(ctx, ID, &productImage); err != nil {
+		if err == persistence.ErrRecordNotFound {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &productImage, nil
+}
+
+func (p *productImageRepository) GetProductImagesByProductID(ctx context.Context, productID string) ([]models.ProductImage, error) {
+	var productImages []models.ProductImage
+	err := p.repo.FindAll(ctx, &productImages,
+		persistence.WithConditions("product_id = ?", productID),
+		persistence.WithOrder(models.ProductImageOrder),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return productImages, nil
+}
+
+func (p *productImageRepository) GetNextProductImagePosition(ctx context.Context, productID string) (int, error) {
+	var position int
+	if err := p.repo.Raw(ctx, &position, "SELECT COALESCE(MAX(position) + 1, 0) FROM product_images WHERE product_id = ?", productID); err != nil {
+		return 0, err
+	}
+
+	return position, nil
+}
+
+func (p *productImageRepository) UpdateProductImagePosition(ctx context.Context, ID string, position int) error {
+	_, err := p.repo.UpdateColumns(ctx, &models.ProductImage{},
+		map[string]any{
+			"position":   position,
+			"updated_at": time.Now(),
+		},
+		persistence.WithConditions("id = ?", ID),
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *productImageRepository) SetPrimaryProductImage(ctx context.Context, productID, ID string) error {
+	return p.repo.WithTransaction(ctx, func(ctx context.Context) error {
+		now := time.Now()
+
+		_, err := p.repo.UpdateColumns(ctx, &models.ProductImage{},
+			map[string]any{"is_primary": false, "updated_at": now},
+			persistence.WithConditions("product_id = ? AND is_primary AND id <> ?", productID, ID),
+		)
+		if err != nil {
+			return err
+		}
+
+		_, err = p.repo.UpdateColumns(ctx, &models.ProductImage{},
+			map[string]any{"is_primary": true, "updated_at": now},
+			persistence.WithConditions("id = ?", ID),
+		)
+		return err
+	})
+}
+
+// Retorna false se a imagem foi excluída durante o processamento
+func (p *productImageRepository) MarkProductImageReady(ctx context.Context, ID string, renditions models.ImageRenditions) (bool, error) {
+	// UpdateColumns não passa pelo serializer do gorm
+	raw, err := json.Marshal(renditions)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := p.repo.UpdateColumns(ctx, &models.ProductImage{},
+		map[string]any{
+			"image_url":  renditions.Full,
+			"renditions": string(raw),
+			"status":     models.ProductImageStatusReady,
+			"last_error": "",
+			"job_id":     nil,
+			"updated_at": time.Now(),
+		},
+		persistence.WithConditions("id = ?", ID),
+	)
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+func (p *productImageRepository) MarkProductImageFailed(ctx context.Context, ID string, jobID uuid.UUID, lastError string) error {
+	_, err := p.repo.UpdateColumns(ctx, &models.ProductImage{},
+		map[string]any{
+			"status":     models.ProductImageStatusFailed,
+			"last_error": lastError,
+			"job_id":     jobID,
+			"updated_at": time.Now(),
+		},
+		persistence.WithConditions("id = ?", ID),
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *productImageRepository) MarkProductImagePending(ctx context.Context, ID string) error {
+	_, err := p.repo.UpdateColumns(ctx, &models.ProductImage{},
+		map[string]any{
+			"status":     models.ProductImageStatusPending,
+			"last_error": "",
+			"updated_at": time.Now(),
+		},
+		persistence.WithConditions("id = ?", ID),
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *productImageRepository) DeleteProductImage(ctx context.Context, ID string) error {
+	if err := p.repo.Delete(ctx, ID, &models.ProductImage{}); err != nil && err != persistence.ErrRecordNotFound {
+		return err
+	}
+
+	return nil
+}
+
+func (p *productImageRepository) DeleteProductImagesByProductID(ctx context.Context, productID string) ([]models.ProductImage, error) {
+	var productImages []models.ProductImage
+	if err := p.repo.FindAll(ctx, &productImages, persistence.WithConditions("product_id = ?", productID)); err != nil {
+		return nil, err
+	}
+
+	for _, productImage := range productImages {
+		if err := p.repo.Delete(ctx, productImage.ID.String(), &models.ProductImage{}); err != nil {
+			return nil, err
+		}
+	}
+
+	return productImages, nil
+}
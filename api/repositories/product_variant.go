@@ -0,0 +1,128 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/g-villarinho/flash-buy-api/models"
+	"github.com/g-villarinho/flash-buy-api/persistence"
+	"github.com/g-villarinho/flash-buy-api/pkgs"
+)
+
+type ProductVariantRepository interface {
+	CreateProductVariants(ctx context.Context, variants []models.ProductVariant) error
+	GetProductVariantsByProductID(ctx context.Context, productID string) ([]models.ProductVariant, error)
+	GetProductVariantByID(ctx context.Context, ID string) (*models.ProductVariant, error)
+	GetProductVariantBySKU(ctx context.Context, storeID, sku string) (*models.ProductVariant, error)
+	UpdateProductVariant(ctx context.Context, variant *models.ProductVariant) error
+	DeleteProductVariant(ctx context.Context, ID string) error
+	AdjustStock(ctx context.Context, variant *models.ProductVariant, delta int64) (bool, error)
+}
+
+type productVariantRepository struct {
+	di   *pkgs.Di
+	repo persistence.Repository
+}
+
+func NewProductVariantRepository(di *pkgs.Di) (ProductVariantRepository, error) {
+	repo, err := pkgs.Invoke[persistence.Repository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &productVariantRepository{
+		di:   di,
+		repo: repo,
+	}, nil
+}
+
+func (p *productVariantRepository) CreateProductVariants(ctx context.Context, variants []models.ProductVariant) error {
+	if err := p.repo.Create(ctx, &variants); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *productVariantRepository) GetProductVariantsByProductID(ctx context.Context, productID string) ([]models.ProductVariant, error) {
+	var variants []models.ProductVariant
+
+	err := p.repo.FindAll(ctx, &variants,
+		persistence.WithConditions("product_id = ?", productID),
+		persistence.WithPreload("Size"),
+		persistence.WithPreload("Color"),
+		persistence.WithOrder("sku ASC"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return variants, nil
+}
+
+func (p *productVariantRepository) GetProductVariantByID(ctx context.Context, ID string) (*models.ProductVariant, error) {
+	var variant models.ProductVariant
+
+	err := p.repo.FindOne(ctx, &variant,
+		persistence.WithConditions("id = ?", ID),
+		persistence.WithPreload("Size"),
+		persistence.WithPreload("Color"),
+	)
+	if err != nil {
+		if err == persistence.ErrRecordNotFound {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &variant, nil
+}
+
+func (p *productVariantRepository) GetProductVariantBySKU(ctx context.Context, storeID, sku string) (*models.ProductVariant, error) {
+	var variant models.ProductVariant
+
+	err := p.repo.FindOne(ctx, &variant, persistence.WithConditions("store_id = ? AND sku = ?", storeID, sku))
+	if err != nil {
+		if err == persistence.ErrRecordNotFound {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &variant, nil
+}
+
+// O estoque só muda através do AdjustStock
+func (p *productVariantRepository) UpdateProductVariant(ctx context.Context, variant *models.ProductVariant) error {
+	variantWithoutAssociations := *variant
+	variantWithoutAssociations.Size = models.Size{}
+	variantWithoutAssociations.Color = models.Color{}
+
+	if err := p.repo.Update(ctx, &variantWithoutAssociations, persistence.WithOmit("stock")); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *productVariantRepository) DeleteProductVariant(ctx context.Context, ID string) error {
+	if err := p.repo.Delete(ctx, ID, &models.ProductVariant{}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *productVariantRepository) AdjustStock(ctx context.Context, variant *models.ProductVariant, delta int64) (bool, error) {
+	affected, err := p.repo.UpdateColumns(ctx, variant,
+		map[string]any{"stock": persistence.Expr("stock + ?", delta)},
+		persistence.WithConditions("stock + ? >= 0", delta),
+		persistence.WithReturning("stock"),
+	)
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
@@ -0,0 +1,67 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/g-villarinho/flash-buy-api/models"
+	"github.com/g-villarinho/flash-buy-api/persistence"
+	"github.com/g-villarinho/flash-buy-api/pkgs"
+)
+
+type RecoveryCodeRepository interface {
+	CreateRecoveryCodes(ctx context.Context, codes []models.RecoveryCode) error
+	UseRecoveryCode(ctx context.Context, userID, codeHash string, usedAt time.Time) (bool, error)
+	CountUnusedRecoveryCodes(ctx context.Context, userID string) (int64, error)
+	DeleteRecoveryCodesByUserID(ctx context.Context, userID string) error
+}
+
+type recoveryCodeRepository struct {
+	di   *pkgs.Di
+	repo persistence.Repository
+}
+
+func NewRecoveryCodeRepository(di *pkgs.Di) (RecoveryCodeRepository, error) {
+	repo, err := pkgs.Invoke[persistence.Repository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &recoveryCodeRepository{
+		di:   di,
+		repo: repo,
+	}, nil
+}
+
+func (r *recoveryCodeRepository) CreateRecoveryCodes(ctx context.Context, codes []models.RecoveryCode) error {
+	if err := r.repo.Create(ctx, &codes); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *recoveryCodeRepository) UseRecoveryCode(ctx context.Context, userID, codeHash string, usedAt time.Time) (bool, error) {
+	affected, err := r.repo.UpdateColumns(ctx, &models.RecoveryCode{},
+		map[string]any{"used_at": sql.NullTime{Time: usedAt, Valid: true}},
+		persistence.WithConditions("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash),
+	)
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+func (r *recoveryCodeRepository) CountUnusedRecoveryCodes(ctx context.Context, userID string) (int64, error) {
+	return r.repo.Count(ctx, &models.RecoveryCode{}, persistence.WithConditions("user_id = ? AND used_at IS NULL", userID))
+}
+
+func (r *recoveryCodeRepository) DeleteRecoveryCodesByUserID(ctx context.Context, userID string) error {
+	if err := r.repo.DeleteAll(ctx, &models.RecoveryCode{}, persistence.WithConditions("user_id = ?", userID)); err != nil {
+		return err
+	}
+
+	return nil
+}
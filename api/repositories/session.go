@@ -2,6 +2,8 @@ package repositories
 
 import (
 	"context"
+	"database/sql"
+	"time"
 
 	"github.com/g-villarinho/flash-buy-api/models"
 	"github.com/g-villarinho/flash-buy-api/persistence"
@@ -12,6 +14,9 @@ type SessionRepository interface {
 	UpsertSession(ctx context.Context, session models.Session) error
 	GetSessionToken(ctx context.Context, token string) (*models.Session, error)
 	DeleteSession(ctx context.Context, ID string) error
+	GetSessionByID(ctx context.Context, ID string) (*models.Session, error)
+	GetActiveSessionsByUserID(ctx context.Context, userID string) ([]models.Session, error)
+	TouchSession(ctx context.Context, ID string, usedAt time.Time) error
 }
 
 type sessionRepository struct {
@@ -63,3 +68,42 @@ func (s *sessionRepository) DeleteSession(ctx context.Context, ID string) error
 
 	return nil
 }
+
+func (s *sessionRepository) GetSessionByID(ctx context.Context, ID string) (*models.Session, error) {
+	session := &models.Session{}
+	if err := s.repo.FindByID(ctx, ID, session); err != nil {
+		if err == persistence.ErrRecordNotFound {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return session, nil
+}
+
+func (s *sessionRepository) GetActiveSessionsByUserID(ctx context.Context, userID string) ([]models.Session, error) {
+	var sessions []models.Session
+
+	err := s.repo.FindAll(ctx, &sessions,
+		persistence.WithConditions("user_id = ? AND verified_at IS NOT NULL AND expires_at > ?", userID, time.Now()),
+		persistence.WithOrder("last_used_at DESC NULLS LAST, created_at DESC"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+func (s *sessionRepository) TouchSession(ctx context.Context, ID string, usedAt time.Time) error {
+	_, err := s.repo.UpdateColumns(ctx, &models.Session{},
+		map[string]any{"last_used_at": sql.NullTime{Time: usedAt, Valid: true}},
+		persistence.WithConditions("id = ?", ID),
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/g-villarinho/flash-buy-api/models"
+	"github.com/g-villarinho/flash-buy-api/persistence"
+	"github.com/g-villarinho/flash-buy-api/pkgs"
+)
+
+type SocialLoginStateRepository interface {
+	CreateSocialLoginState(ctx context.Context, state *models.SocialLoginState) error
+	GetSocialLoginStateByHash(ctx context.Context, stateHash string) (*models.SocialLoginState, error)
+	ConsumeSocialLoginState(ctx context.Context, ID string) (bool, error)
+	DeleteExpiredSocialLoginStates(ctx context.Context, before time.Time) error
+}
+
+type socialLoginStateRepository struct {
+	di   *pkgs.Di
+	repo persistence.Repository
+}
+
+func NewSocialLoginStateRepository(di *pkgs.Di) (SocialLoginStateRepository, error) {
+	repo, err := pkgs.Invoke[persistence.Repository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &socialLoginStateRepository{
+		di:   di,
+		repo: repo,
+	}, nil
+}
+
+func (s *socialLoginStateRepository) CreateSocialLoginState(ctx context.Context, state *models.SocialLoginState) error {
+	if err := s.repo.Create(ctx, state); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *socialLoginStateRepository) GetSocialLoginStateByHash(ctx context.Context, stateHash string) (*models.SocialLoginState, error) {
+	var state models.SocialLoginState
+
+	err := s.repo.FindOne(ctx, &state, persistence.WithConditions("state_hash = ?", stateHash))
+	if err != nil {
+		if err == persistence.ErrRecordNotFound {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &state, nil
+}
+
+func (s *socialLoginStateRepository) ConsumeSocialLoginState(ctx context.Context, ID string) (bool, error) {
+	affected, err := s.repo.Exec(ctx, "DELETE FROM social_login_states WHERE id = ?", ID)
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+func (s *socialLoginStateRepository) DeleteExpiredSocialLoginStates(ctx context.Context, before time.Time) error {
+	if err := s.repo.DeleteAll(ctx, &models.SocialLoginState{}, persistence.WithConditions("expires_at < ?", before)); err != nil {
+		return err
+	}
+
+	return nil
+}
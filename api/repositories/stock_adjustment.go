@@ -0,0 +1,54 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/g-villarinho/flash-buy-api/models"
+	"github.com/g-villarinho/flash-buy-api/persistence"
+	"github.com/g-villarinho/flash-buy-api/pkgs"
+)
+
+// O histórico de estoque é apenas de inserção
+type StockAdjustmentRepository interface {
+	CreateStockAdjustment(ctx context.Context, adjustment *models.StockAdjustment, events ...*models.DomainEvent) error
+	GetStockAdjustmentsPagedList(ctx context.Context, productID string, pag models.Pagination) (*models.PaginatedResponse, error)
+}
+
+type stockAdjustmentRepository struct {
+	di   *pkgs.Di
+	repo persistence.Repository
+}
+
+func NewStockAdjustmentRepository(di *pkgs.Di) (StockAdjustmentRepository, error) {
+	repo, err := pkgs.Invoke[persistence.Repository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &stockAdjustmentRepository{
+		di:   di,
+		repo: repo,
+	}, nil
+}
+
+func (s *stockAdjustmentRepository) CreateStockAdjustment(ctx context.Context, adjustment *models.StockAdjustment, events ...*models.DomainEvent) error {
+	return s.repo.WithEvents(ctx, events, func(ctx context.Context) error {
+		return s.repo.Create(ctx, adjustment)
+	})
+}
+
+func (s *stockAdjustmentRepository) GetStockAdjustmentsPagedList(ctx context.Context, productID string, pag models.Pagination) (*models.PaginatedResponse, error) {
+	var adjustments []models.StockAdjustment
+
+	opts := []persistence.QueryOption{}
+
+	opts = append(opts, persistence.WithConditions("product_id = ?", productID))
+	opts = append(opts, persistence.WithOrder("created_at DESC"))
+
+	result, err := s.repo.Paginate(ctx, &adjustments, pag, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -8,6 +8,8 @@ import (
 	"github.com/g-villarinho/flash-buy-api/pkgs"
 )
 
+const storeMembershipCondition = "user_id = ? OR id IN (SELECT store_id FROM store_members WHERE user_id = ?)"
+
 type StoreRepository interface {
 	CreateStore(ctx context.Context, store *models.Store) error
 	GetStoreByID(ctx context.Context, ID string) (*models.Store, error)
@@ -56,7 +58,11 @@ func (s *storeRepository) GetStoreByID(ctx context.Context, ID string) (*models.
 
 func (s *storeRepository) FindFirstStoreByUserID(ctx context.Context, userID string) (*models.Store, error) {
 	var store models.Store
-	if err := s.repo.FindOne(ctx, &store, persistence.WithConditions("user_id = ?", userID)); err != nil {
+	err := s.repo.FindOne(ctx, &store,
+		persistence.WithConditions(storeMembershipCondition, userID, userID),
+		persistence.WithOrder("created_at ASC"),
+	)
+	if err != nil {
 		if err == persistence.ErrRecordNotFound {
 			return nil, nil
 		}
@@ -69,7 +75,11 @@ func (s *storeRepository) FindFirstStoreByUserID(ctx context.Context, userID str
 
 func (s *storeRepository) GetStoresByUserID(ctx context.Context, userID string) ([]models.Store, error) {
 	var stores []models.Store
-	if err := s.repo.FindAll(ctx, &stores, persistence.WithConditions("user_id = ?", userID)); err != nil {
+	err := s.repo.FindAll(ctx, &stores,
+		persistence.WithConditions(storeMembershipCondition, userID, userID),
+		persistence.WithOrder("created_at ASC"),
+	)
+	if err != nil {
 		return nil, err
 	}
 
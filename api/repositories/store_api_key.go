@@ -0,0 +1,111 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/g-villarinho/flash-buy-api/models"
+	"github.com/g-villarinho/flash-buy-api/persistence"
+	"github.com/g-villarinho/flash-buy-api/pkgs"
+)
+
+type StoreAPIKeyRepository interface {
+	CreateStoreAPIKey(ctx context.Context, key *models.StoreAPIKey) error
+	GetStoreAPIKeysByStoreID(ctx context.Context, storeID string) ([]models.StoreAPIKey, error)
+	GetStoreAPIKeyByID(ctx context.Context, ID string) (*models.StoreAPIKey, error)
+	GetStoreAPIKeyByPrefix(ctx context.Context, prefix string) (*models.StoreAPIKey, error)
+	RevokeStoreAPIKey(ctx context.Context, ID string, revokedAt time.Time) (bool, error)
+	TouchStoreAPIKey(ctx context.Context, ID string, usedAt, staleBefore time.Time) error
+}
+
+type storeAPIKeyRepository struct {
+	di   *pkgs.Di
+	repo persistence.Repository
+}
+
+func NewStoreAPIKeyRepository(di *pkgs.Di) (StoreAPIKeyRepository, error) {
+	repo, err := pkgs.Invoke[persistence.Repository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &storeAPIKeyRepository{
+		di:   di,
+		repo: repo,
+	}, nil
+}
+
+func (s *storeAPIKeyRepository) CreateStoreAPIKey(ctx context.Context, key *models.StoreAPIKey) error {
+	if err := s.repo.Create(ctx, key); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *storeAPIKeyRepository) GetStoreAPIKeysByStoreID(ctx context.Context, storeID string) ([]models.StoreAPIKey, error) {
+	var keys []models.StoreAPIKey
+
+	err := s.repo.FindAll(ctx, &keys,
+		persistence.WithConditions("store_id = ?", storeID),
+		persistence.WithOrder("created_at DESC"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+func (s *storeAPIKeyRepository) GetStoreAPIKeyByID(ctx context.Context, ID string) (*models.StoreAPIKey, error) {
+	var key models.StoreAPIKey
+	if err := s.repo.FindByID(ctx, ID, &key); err != nil {
+		if err == persistence.ErrRecordNotFound {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &key, nil
+}
+
+func (s *storeAPIKeyRepository) GetStoreAPIKeyByPrefix(ctx context.Context, prefix string) (*models.StoreAPIKey, error) {
+	var key models.StoreAPIKey
+
+	err := s.repo.FindOne(ctx, &key, persistence.WithConditions("prefix = ?", prefix))
+	if err != nil {
+		if err == persistence.ErrRecordNotFound {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &key, nil
+}
+
+func (s *storeAPIKeyRepository) RevokeStoreAPIKey(ctx context.Context, ID string, revokedAt time.Time) (bool, error) {
+	affected, err := s.repo.UpdateColumns(ctx, &models.StoreAPIKey{},
+		map[string]any{"revoked_at": sql.NullTime{Time: revokedAt, Valid: true}},
+		persistence.WithConditions("id = ? AND revoked_at IS NULL", ID),
+	)
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+func (s *storeAPIKeyRepository) TouchStoreAPIKey(ctx context.Context, ID string, usedAt, staleBefore time.Time) error {
+	_, err := s.repo.UpdateColumns(ctx, &models.StoreAPIKey{},
+		map[string]any{"last_used_at": sql.NullTime{Time: usedAt, Valid: true}},
+		persistence.WithConditions("id = ? AND (last_used_at IS NULL OR last_used_at < ?)", ID, staleBefore),
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
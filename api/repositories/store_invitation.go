@@ -0,0 +1,112 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/g-villarinho/flash-buy-api/models"
+	"github.com/g-villarinho/flash-buy-api/persistence"
+	"github.com/g-villarinho/flash-buy-api/pkgs"
+)
+
+type StoreInvitationRepository interface {
+	CreateStoreInvitation(ctx context.Context, invitation *models.StoreInvitation, events ...*models.DomainEvent) error
+	GetPendingInvitationsByStoreID(ctx context.Context, storeID string) ([]models.StoreInvitation, error)
+	GetStoreInvitationByID(ctx context.Context, ID string) (*models.StoreInvitation, error)
+	UpdateStoreInvitationTokenHash(ctx context.Context, ID, tokenHash string) (bool, error)
+	GetStoreInvitationByTokenHash(ctx context.Context, tokenHash string) (*models.StoreInvitation, error)
+	UpdateStoreInvitation(ctx context.Context, invitation *models.StoreInvitation) error
+}
+
+type storeInvitationRepository struct {
+	di   *pkgs.Di
+	repo persistence.Repository
+}
+
+func NewStoreInvitationRepository(di *pkgs.Di) (StoreInvitationRepository, error) {
+	repo, err := pkgs.Invoke[persistence.Repository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &storeInvitationRepository{
+		di:   di,
+		repo: repo,
+	}, nil
+}
+
+func (s *storeInvitationRepository) CreateStoreInvitation(ctx context.Context, invitation *models.StoreInvitation, events ...*models.DomainEvent) error {
+	return s.repo.WithEvents(ctx, events, func(ctx context.Context) error {
+		return s.repo.Create(ctx, invitation)
+	})
+}
+
+func (s *storeInvitationRepository) GetPendingInvitationsByStoreID(ctx context.Context, storeID string) ([]models.StoreInvitation, error) {
+	var invitations []models.StoreInvitation
+
+	err := s.repo.FindAll(ctx, &invitations,
+		persistence.WithConditions("store_id = ?", storeID),
+		persistence.WithConditions("accepted_at IS NULL AND revoked_at IS NULL AND expires_at > ?", time.Now()),
+		persistence.WithOrder("created_at DESC"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return invitations, nil
+}
+
+func (s *storeInvitationRepository) GetStoreInvitationByID(ctx context.Context, ID string) (*models.StoreInvitation, error) {
+	var invitation models.StoreInvitation
+	if err := s.repo.FindByID(ctx, ID, &invitation); err != nil {
+		if err == persistence.ErrRecordNotFound {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &invitation, nil
+}
+
+func (s *storeInvitationRepository) GetStoreInvitationByTokenHash(ctx context.Context, tokenHash string) (*models.StoreInvitation, error) {
+	var invitation models.StoreInvitation
+
+	err := s.repo.FindOne(ctx, &invitation,
+		persistence.WithConditions("token_hash = ?", tokenHash),
+		persistence.WithPreload("Store"),
+	)
+	if err != nil {
+		if err == persistence.ErrRecordNotFound {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &invitation, nil
+}
+
+func (s *storeInvitationRepository) UpdateStoreInvitation(ctx context.Context, invitation *models.StoreInvitation) error {
+	invitationWithoutStore := *invitation
+	invitationWithoutStore.Store = models.Store{}
+
+	if err := s.repo.Update(ctx, &invitationWithoutStore); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Um convite aceito ou revogado no meio do envio continua como está
+func (s *storeInvitationRepository) UpdateStoreInvitationTokenHash(ctx context.Context, ID, tokenHash string) (bool, error) {
+	affected, err := s.repo.UpdateColumns(ctx, &models.StoreInvitation{},
+		map[string]any{"token_hash": tokenHash},
+		persistence.WithConditions("id = ? AND accepted_at IS NULL AND revoked_at IS NULL", ID),
+	)
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
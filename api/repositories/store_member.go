@@ -0,0 +1,106 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/g-villarinho/flash-buy-api/models"
+	"github.com/g-villarinho/flash-buy-api/persistence"
+	"github.com/g-villarinho/flash-buy-api/pkgs"
+)
+
+type StoreMemberRepository interface {
+	CreateStoreMember(ctx context.Context, member *models.StoreMember) error
+	GetStoreMembersByStoreID(ctx context.Context, storeID string) ([]models.StoreMember, error)
+	GetStoreMemberByID(ctx context.Context, ID string) (*models.StoreMember, error)
+	GetStoreMember(ctx context.Context, storeID, userID string) (*models.StoreMember, error)
+	UpdateStoreMember(ctx context.Context, member *models.StoreMember) error
+	DeleteStoreMember(ctx context.Context, ID string) error
+}
+
+type storeMemberRepository struct {
+	di   *pkgs.Di
+	repo persistence.Repository
+}
+
+func NewStoreMemberRepository(di *pkgs.Di) (StoreMemberRepository, error) {
+	repo, err := pkgs.Invoke[persistence.Repository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &storeMemberRepository{
+		di:   di,
+		repo: repo,
+	}, nil
+}
+
+func (s *storeMemberRepository) CreateStoreMember(ctx context.Context, member *models.StoreMember) error {
+	if err := s.repo.Create(ctx, member); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *storeMemberRepository) GetStoreMembersByStoreID(ctx context.Context, storeID string) ([]models.StoreMember, error) {
+	var members []models.StoreMember
+
+	err := s.repo.FindAll(ctx, &members,
+		persistence.WithConditions("store_id = ?", storeID),
+		persistence.WithPreload("User"),
+		persistence.WithOrder("created_at ASC"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+func (s *storeMemberRepository) GetStoreMemberByID(ctx context.Context, ID string) (*models.StoreMember, error) {
+	var member models.StoreMember
+	if err := s.repo.FindByID(ctx, ID, &member); err != nil {
+		if err == persistence.ErrRecordNotFound {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &member, nil
+}
+
+func (s *storeMemberRepository) GetStoreMember(ctx context.Context, storeID, userID string) (*models.StoreMember, error) {
+	var member models.StoreMember
+
+	err := s.repo.FindOne(ctx, &member, persistence.WithConditions("store_id = ? AND user_id = ?", storeID, userID))
+	if err != nil {
+		if err == persistence.ErrRecordNotFound {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &member, nil
+}
+
+func (s *storeMemberRepository) UpdateStoreMember(ctx context.Context, member *models.StoreMember) error {
+	memberWithoutAssociations := *member
+	memberWithoutAssociations.Store = models.Store{}
+	memberWithoutAssociations.User = models.User{}
+
+	if err := s.repo.Update(ctx, &memberWithoutAssociations); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *storeMemberRepository) DeleteStoreMember(ctx context.Context, ID string) error {
+	if err := s.repo.Delete(ctx, ID, &models.StoreMember{}); err != nil {
+		return err
+	}
+
+	return nil
+}
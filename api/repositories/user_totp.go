@@ -0,0 +1,117 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/g-villarinho/flash-buy-api/models"
+	"github.com/g-villarinho/flash-buy-api/persistence"
+	"github.com/g-villarinho/flash-buy-api/pkgs"
+)
+
+type UserTOTPRepository interface {
+	CreateUserTOTP(ctx context.Context, totp *models.UserTOTP) error
+	GetUserTOTPByUserID(ctx context.Context, userID string) (*models.UserTOTP, error)
+	ConfirmUserTOTP(ctx context.Context, ID string, step int64, confirmedAt time.Time) (bool, error)
+	UseTOTPStep(ctx context.Context, ID string, step int64) (bool, error)
+	RegisterTOTPFailure(ctx context.Context, ID string, maxAttempts int, lockedUntil time.Time) error
+	DeleteUserTOTPByUserID(ctx context.Context, userID string) error
+}
+
+type userTOTPRepository struct {
+	di   *pkgs.Di
+	repo persistence.Repository
+}
+
+func NewUserTOTPRepository(di *pkgs.Di) (UserTOTPRepository, error) {
+	repo, err := pkgs.Invoke[persistence.Repository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &userTOTPRepository{
+		di:   di,
+		repo: repo,
+	}, nil
+}
+
+func (u *userTOTPRepository) CreateUserTOTP(ctx context.Context, totp *models.UserTOTP) error {
+	if err := u.repo.Create(ctx, totp); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (u *userTOTPRepository) GetUserTOTPByUserID(ctx context.Context, userID string) (*models.UserTOTP, error) {
+	var totp models.UserTOTP
+
+	err := u.repo.FindOne(ctx, &totp, persistence.WithConditions("user_id = ?", userID))
+	if err != nil {
+		if err == persistence.ErrRecordNotFound {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &totp, nil
+}
+
+func (u *userTOTPRepository) ConfirmUserTOTP(ctx context.Context, ID string, step int64, confirmedAt time.Time) (bool, error) {
+	affected, err := u.repo.UpdateColumns(ctx, &models.UserTOTP{},
+		map[string]any{
+			"confirmed_at":    sql.NullTime{Time: confirmedAt, Valid: true},
+			"last_used_step":  step,
+			"failed_attempts": 0,
+			"updated_at":      sql.NullTime{Time: confirmedAt, Valid: true},
+		},
+		persistence.WithConditions("id = ? AND confirmed_at IS NULL", ID),
+	)
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+// Recusa o mesmo passo ou um anterior, impedindo o replay
+func (u *userTOTPRepository) UseTOTPStep(ctx context.Context, ID string, step int64) (bool, error) {
+	affected, err := u.repo.UpdateColumns(ctx, &models.UserTOTP{},
+		map[string]any{
+			"last_used_step":  step,
+			"failed_attempts": 0,
+		},
+		persistence.WithConditions("id = ? AND last_used_step < ?", ID, step),
+	)
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+// O SET usa os valores anteriores da linha
+func (u *userTOTPRepository) RegisterTOTPFailure(ctx context.Context, ID string, maxAttempts int, lockedUntil time.Time) error {
+	_, err := u.repo.UpdateColumns(ctx, &models.UserTOTP{},
+		map[string]any{
+			"failed_attempts": persistence.Expr("CASE WHEN failed_attempts + 1 >= ? THEN 0 ELSE failed_attempts + 1 END", maxAttempts),
+			"locked_until":    persistence.Expr("CASE WHEN failed_attempts + 1 >= ? THEN ?::timestamptz ELSE locked_until END", maxAttempts, lockedUntil),
+		},
+		persistence.WithConditions("id = ?", ID),
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (u *userTOTPRepository) DeleteUserTOTPByUserID(ctx context.Context, userID string) error {
+	if err := u.repo.DeleteAll(ctx, &models.UserTOTP{}, persistence.WithConditions("user_id = ?", userID)); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/g-villarinho/flash-buy-api/models"
+	"github.com/g-villarinho/flash-buy-api/persistence"
+	"github.com/g-villarinho/flash-buy-api/pkgs"
+)
+
+type WebhookRepository interface {
+	CreateWebhook(ctx context.Context, webhook *models.Webhook) error
+	GetWebhooksByStoreID(ctx context.Context, storeID string) ([]models.Webhook, error)
+	GetWebhookByID(ctx context.Context, ID string) (*models.Webhook, error)
+	DeleteWebhook(ctx context.Context, ID string) error
+}
+
+type webhookRepository struct {
+	di   *pkgs.Di
+	repo persistence.Repository
+}
+
+func NewWebhookRepository(di *pkgs.Di) (WebhookRepository, error) {
+	repo, err := pkgs.Invoke[persistence.Repository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &webhookRepository{
+		di:   di,
+		repo: repo,
+	}, nil
+}
+
+func (w *webhookRepository) CreateWebhook(ctx context.Context, webhook *models.Webhook) error {
+	if err := w.repo.Create(ctx, webhook); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (w *webhookRepository) GetWebhooksByStoreID(ctx context.Context, storeID string) ([]models.Webhook, error) {
+	var webhooks []models.Webhook
+
+	err := w.repo.FindAll(ctx, &webhooks,
+		persistence.WithConditions("store_id = ?", storeID),
+		persistence.WithOrder("created_at DESC"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+func (w *webhookRepository) GetWebhookByID(ctx context.Context, ID string) (*models.Webhook, error) {
+	var webhook models.Webhook
+	if err := w.repo.FindByID(ctx, ID, &webhook); err != nil {
+		if err == persistence.ErrRecordNotFound {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+func (w *webhookRepository) DeleteWebhook(ctx context.Context, ID string) error {
+	if err := w.repo.Delete(ctx, ID, &models.Webhook{}); err != nil {
+		return err
+	}
+
+	return nil
+}
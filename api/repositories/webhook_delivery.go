@@ -0,0 +1,154 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/g-villarinho/flash-buy-api/models"
+	"github.com/g-villarinho/flash-buy-api/persistence"
+	"github.com/g-villarinho/flash-buy-api/pkgs"
+)
+
+type WebhookDeliveryRepository interface {
+	CreateWebhookDeliveries(ctx context.Context, deliveries []models.WebhookDelivery) error
+	GetWebhookDeliveryByID(ctx context.Context, ID string) (*models.WebhookDelivery, error)
+	HasWebhookDeliveriesForEvent(ctx context.Context, eventID string) (bool, error)
+	GetWebhookDeliveriesPagedList(ctx context.Context, webhookID string, pag models.Pagination) (*models.PaginatedResponse, error)
+	GetDueWebhookDeliveries(ctx context.Context, now time.Time, limit int) ([]models.WebhookDelivery, error)
+	ClaimWebhookDelivery(ctx context.Context, ID string, now, leaseUntil time.Time) (bool, error)
+	CompleteWebhookDelivery(ctx context.Context, ID string, responseStatus int, deliveredAt time.Time) error
+	FailWebhookDelivery(ctx context.Context, ID string, responseStatus int, lastError string, nextAttemptAt *time.Time) error
+}
+
+type webhookDeliveryRepository struct {
+	di   *pkgs.Di
+	repo persistence.Repository
+}
+
+func NewWebhookDeliveryRepository(di *pkgs.Di) (WebhookDeliveryRepository, error) {
+	repo, err := pkgs.Invoke[persistence.Repository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &webhookDeliveryRepository{
+		di:   di,
+		repo: repo,
+	}, nil
+}
+
+func (w *webhookDeliveryRepository) CreateWebhookDeliveries(ctx context.Context, deliveries []models.WebhookDelivery) error {
+	if err := w.repo.Create(ctx, &deliveries); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (w *webhookDeliveryRepository) GetWebhookDeliveryByID(ctx context.Context, ID string) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	if err := w.repo.FindByID(ctx, ID, &delivery); err != nil {
+		if err == persistence.ErrRecordNotFound {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &delivery, nil
+}
+
+func (w *webhookDeliveryRepository) HasWebhookDeliveriesForEvent(ctx context.Context, eventID string) (bool, error) {
+	count, err := w.repo.Count(ctx, &models.WebhookDelivery{}, persistence.WithConditions("event_id = ?", eventID))
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func (w *webhookDeliveryRepository) GetWebhookDeliveriesPagedList(ctx context.Context, webhookID string, pag models.Pagination) (*models.PaginatedResponse, error) {
+	var deliveries []models.WebhookDelivery
+
+	opts := []persistence.QueryOption{}
+
+	opts = append(opts, persistence.WithConditions("webhook_id = ?", webhookID))
+	opts = append(opts, persistence.WithOrder("created_at DESC"))
+
+	result, err := w.repo.Paginate(ctx, &deliveries, pag, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (w *webhookDeliveryRepository) GetDueWebhookDeliveries(ctx context.Context, now time.Time, limit int) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+
+	err := w.repo.FindAll(ctx, &deliveries,
+		persistence.WithPreload("Webhook"),
+		persistence.WithConditions("status = ? AND next_attempt_at <= ?", models.WebhookDeliveryPending, now),
+		persistence.WithOrder("next_attempt_at ASC"),
+		persistence.WithPagination(1, limit),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (w *webhookDeliveryRepository) ClaimWebhookDelivery(ctx context.Context, ID string, now, leaseUntil time.Time) (bool, error) {
+	affected, err := w.repo.UpdateColumns(ctx, &models.WebhookDelivery{},
+		map[string]any{
+			"next_attempt_at": leaseUntil,
+			"attempts":        persistence.Expr("attempts + 1"),
+		},
+		persistence.WithConditions("id = ? AND status = ? AND next_attempt_at <= ?", ID, models.WebhookDeliveryPending, now),
+	)
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+func (w *webhookDeliveryRepository) CompleteWebhookDelivery(ctx context.Context, ID string, responseStatus int, deliveredAt time.Time) error {
+	_, err := w.repo.UpdateColumns(ctx, &models.WebhookDelivery{},
+		map[string]any{
+			"status":          models.WebhookDeliverySucceeded,
+			"response_status": responseStatus,
+			"last_error":      "",
+			"delivered_at":    sql.NullTime{Time: deliveredAt, Valid: true},
+		},
+		persistence.WithConditions("id = ?", ID),
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// nextAttemptAt nil encerra as tentativas
+func (w *webhookDeliveryRepository) FailWebhookDelivery(ctx context.Context, ID string, responseStatus int, lastError string, nextAttemptAt *time.Time) error {
+	values := map[string]any{
+		"response_status": responseStatus,
+		"last_error":      lastError,
+	}
+
+	if nextAttemptAt != nil {
+		values["next_attempt_at"] = *nextAttemptAt
+	} else {
+		values["status"] = models.WebhookDeliveryFailed
+	}
+
+	_, err := w.repo.UpdateColumns(ctx, &models.WebhookDelivery{}, values, persistence.WithConditions("id = ?", ID))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
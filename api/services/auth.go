@@ -3,25 +3,34 @@ package services
 import (
 	"context"
 	"fmt"
-	"log/slog"
+	"net/url"
+	"strings"
 
+	"github.com/g-villarinho/flash-buy-api/config"
 	"github.com/g-villarinho/flash-buy-api/models"
-	"github.com/g-villarinho/flash-buy-api/notifications"
+	"github.com/g-villarinho/flash-buy-api/persistence"
 	"github.com/g-villarinho/flash-buy-api/pkgs"
 )
 
 type AuthService interface {
-	Login(ctx context.Context, email string, ssi models.SessionSecurityInfo) (*models.Session, error)
-	VerifyCode(ctx context.Context, code, token string) (*models.Session, error)
-	ResendCode(ctx context.Context, email, token string) error
+	Login(ctx context.Context, email string, method models.LoginMethod, ssi models.SessionSecurityInfo) (*models.Session, error)
+	VerifyCode(ctx context.Context, code, token string, ssi models.SessionSecurityInfo) (*models.Session, error)
+	VerifyMagicLink(ctx context.Context, linkToken string, ssi models.SessionSecurityInfo) (*models.Session, error)
+	ResendCode(ctx context.Context, email, token string, ssi models.SessionSecurityInfo) error
+	CompleteFirstFactor(ctx context.Context, token string, method models.AuthMethod) (*models.Session, error)
+	VerifySecondFactor(ctx context.Context, token string, payload models.VerifySecondFactorPayload) (*models.Session, error)
+	StepUp(ctx context.Context, userID, sessionID string, payload models.VerifySecondFactorPayload) (*models.Session, error)
 }
 
 type authService struct {
-	di *pkgs.Di
-	us UserService
-	ss SessionService
-	os OTPService
-	en notifications.EmailNotification
+	di  *pkgs.Di
+	us  UserService
+	ss  SessionService
+	os  OTPService
+	ts  TokenService
+	tfs TwoFactorService
+	eqs EmailQueueService
+	tr  persistence.Transactor
 }
 
 func NewAuthService(di *pkgs.Di) (AuthService, error) {
@@ -35,7 +44,7 @@ func NewAuthService(di *pkgs.Di) (AuthService, error) {
 		return nil, err
 	}
 
-	emailNotification, err := pkgs.Invoke[notifications.EmailNotification](di)
+	emailQueueService, err := pkgs.Invoke[EmailQueueService](di)
 	if err != nil {
 		return nil, err
 	}
@@ -45,64 +54,162 @@ func NewAuthService(di *pkgs.Di) (AuthService, error) {
 		return nil, err
 	}
 
+	tokenService, err := pkgs.Invoke[TokenService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	twoFactorService, err := pkgs.Invoke[TwoFactorService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	transactor, err := pkgs.Invoke[persistence.Transactor](di)
+	if err != nil {
+		return nil, err
+	}
+
 	return &authService{
-		di: di,
-		us: userService,
-		ss: sessionService,
-		os: otpService,
-		en: emailNotification,
+		di:  di,
+		us:  userService,
+		ss:  sessionService,
+		os:  otpService,
+		ts:  tokenService,
+		tfs: twoFactorService,
+		eqs: emailQueueService,
+		tr:  transactor,
 	}, nil
 }
 
-func (a *authService) Login(ctx context.Context, email string, ssi models.SessionSecurityInfo) (*models.Session, error) {
+func (a *authService) Login(ctx context.Context, email string, method models.LoginMethod, ssi models.SessionSecurityInfo) (*models.Session, error) {
+	flow, ok := method.Flow()
+	if !ok {
+		return nil, models.ErrInvalidLoginMethod
+	}
+
 	user, err := a.us.GetUserByEmail(ctx, email)
 	if err != nil {
 		return nil, models.ErrUserNotFound
 	}
 
-	session, err := a.ss.CreateSession(ctx, user, ssi)
+	var session *models.Session
+
+	err = a.tr.WithTransaction(ctx, func(ctx context.Context) error {
+		session, err = a.ss.CreateSession(ctx, user, ssi)
+		if err != nil {
+			return fmt.Errorf("create session: %w", err)
+		}
+
+		otp, err := a.os.GeneratOTP(ctx, user.ID.String(), flow, session.Token, ssi)
+		if err != nil {
+			return err
+		}
+
+		return a.sendOTP(ctx, user.Email, otp)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("create session: %w", err)
+		return nil, err
+	}
+
+	return session, nil
+}
+
+func (a *authService) VerifyCode(ctx context.Context, code, token string, ssi models.SessionSecurityInfo) (*models.Session, error) {
+	if err := a.os.VerifyOTP(ctx, code, token, ssi); err != nil {
+		return nil, err
 	}
 
-	code, err := a.os.GeneratOTP(ctx, user.ID.String(), models.UserVerificationFLow, session.Token)
+	return a.CompleteFirstFactor(ctx, token, models.AuthMethodEmail)
+}
+
+// O link pode ser aberto em outro navegador, sem o cookie da sessão pendente
+func (a *authService) VerifyMagicLink(ctx context.Context, linkToken string, ssi models.SessionSecurityInfo) (*models.Session, error) {
+	claims, err := a.ts.ParseMagicLinkToken(ctx, linkToken)
 	if err != nil {
-		return nil, fmt.Errorf("create otp: %w", err)
+		return nil, err
 	}
 
-	go func() {
-		if err := a.en.SendVerificationEmail(ctx, user.Email, code); err != nil {
-			slog.Error("send verification email", "error", err)
-		}
-	}()
+	otp, err := a.os.VerifyMagicLink(ctx, claims.OTPID, claims.Code, ssi)
+	if err != nil {
+		return nil, err
+	}
 
-	return session, nil
+	return a.CompleteFirstFactor(ctx, otp.VerificationToken, models.AuthMethodEmail)
 }
 
-func (a *authService) VerifyCode(ctx context.Context, code, token string) (*models.Session, error) {
-	if err := a.os.VerifyOTP(ctx, code, token); err != nil {
+func (a *authService) ResendCode(ctx context.Context, email string, token string, ssi models.SessionSecurityInfo) error {
+	otp, err := a.os.UpdateCode(ctx, token, ssi)
+	if err != nil {
+		return err
+	}
+
+	return a.sendOTP(ctx, email, otp)
+}
+
+func (a *authService) CompleteFirstFactor(ctx context.Context, token string, method models.AuthMethod) (*models.Session, error) {
+	session, err := a.ss.GetPendingSession(ctx, token)
+	if err != nil {
 		return nil, err
 	}
 
-	session, err := a.ss.ValidSession(ctx, token)
+	enabled, err := a.tfs.IsEnabled(ctx, session.UserID.String())
 	if err != nil {
 		return nil, err
 	}
 
-	return session, nil
+	if enabled {
+		return a.ss.RecordAuthMethod(ctx, token, method)
+	}
+
+	return a.ss.ValidSession(ctx, token, method)
 }
 
-func (a *authService) ResendCode(ctx context.Context, email string, token string) error {
-	code, err := a.os.UpdateCode(ctx, token)
+func (a *authService) VerifySecondFactor(ctx context.Context, token string, payload models.VerifySecondFactorPayload) (*models.Session, error) {
+	session, err := a.ss.GetPendingSession(ctx, token)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	if !session.IsPendingMFA() {
+		return nil, models.ErrFirstFactorRequired
+	}
+
+	method, err := a.tfs.Verify(ctx, session.UserID.String(), payload)
+	if err != nil {
+		return nil, err
 	}
 
-	go func() {
-		if err := a.en.SendVerificationEmail(ctx, email, code); err != nil {
-			slog.Error("send verification email", "error", err)
+	return a.ss.ValidSession(ctx, token, method)
+}
+
+func (a *authService) StepUp(ctx context.Context, userID, sessionID string, payload models.VerifySecondFactorPayload) (*models.Session, error) {
+	method, err := a.tfs.Verify(ctx, userID, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.ss.StepUp(ctx, userID, sessionID, method)
+}
+
+func (a *authService) sendOTP(ctx context.Context, email string, otp *models.OTP) error {
+	if otp.Flow != models.MagicLinkFlow {
+		if err := a.eqs.QueueVerificationEmail(ctx, email, otp.Code); err != nil {
+			return fmt.Errorf("queue verification email: %w", err)
 		}
-	}()
+
+		return nil
+	}
+
+	linkToken, err := a.ts.CreateMagicLinkToken(ctx, otp)
+	if err != nil {
+		return fmt.Errorf("create magic link token: %w", err)
+	}
+
+	link := fmt.Sprintf("%s/magic-link?token=%s", strings.TrimRight(config.Env.Frontend.URL, "/"), url.QueryEscape(linkToken))
+
+	if err := a.eqs.QueueMagicLinkEmail(ctx, email, link); err != nil {
+		return fmt.Errorf("queue magic link email: %w", err)
+	}
 
 	return nil
 }
@@ -2,8 +2,10 @@ package services
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"mime/multipart"
+	"time"
 
 	"github.com/g-villarinho/flash-buy-api/models"
 	"github.com/g-villarinho/flash-buy-api/pkgs"
@@ -11,10 +13,11 @@ import (
 )
 
 type BillboardService interface {
-	CreateBillboard(ctx context.Context, storeID, userID string, file *multipart.FileHeader, label string) error
-	GetBillboardsPagedList(ctx context.Context, storeID string, pag models.BillboardPagination) (*models.PaginatedResponse, error)
+	CreateBillboard(ctx context.Context, storeID, userID string, file *multipart.FileHeader, payload models.BillboardPayload) error
+	UpdateBillboard(ctx context.Context, storeID, userID, billboardID string, file *multipart.FileHeader, payload models.BillboardPayload) (*models.BillboardResponse, error)
+	GetBillboardsPagedList(ctx context.Context, userID, storeID string, pag models.BillboardPagination) (*models.PaginatedResponse, error)
 	DeleteBillboard(ctx context.Context, storeID, userID, billboardID string) error
-	GetBillboardByID(ctx context.Context, storeId, billboardID string) (*models.BillboardResponse, error)
+	GetBillboardByID(ctx context.Context, userID, storeId, billboardID string) (*models.BillboardResponse, error)
 	GetAllByStoreID(ctx context.Context, storeID string) ([]models.BillboardResponse, error)
 }
 
@@ -49,30 +52,106 @@ func NewBillboardService(di *pkgs.Di) (BillboardService, error) {
 	}, nil
 }
 
-func (b *billboardService) CreateBillboard(ctx context.Context, storeID string, userID string, file *multipart.FileHeader, label string) error {
-	store, err := b.ss.GetStoreByStoreID(ctx, storeID, userID)
+func (b *billboardService) CreateBillboard(ctx context.Context, storeID string, userID string, file *multipart.FileHeader, payload models.BillboardPayload) error {
+	if err := payload.Validate(); err != nil {
+		return err
+	}
+
+	store, err := b.ss.CheckPermission(ctx, storeID, userID, models.PermissionEditCatalog)
+	if err != nil {
+		return err
+	}
+
+	content, err := b.is.ReadImage(file)
 	if err != nil {
 		return err
 	}
 
-	imageURL, err := b.is.UploadImage(ctx, file, file.Filename)
+	renditions, err := b.is.UploadImage(ctx, content)
 	if err != nil {
 		return err
 	}
 
-	billboard, err := models.NewBillboard(label, store.ID.String(), imageURL)
+	billboard, err := models.NewBillboard(payload, store.ID.String(), *renditions)
 	if err != nil {
 		return fmt.Errorf("new billboard: %w", err)
 	}
 
-	if err := b.br.CreateBillboard(ctx, billboard); err != nil {
+	event, err := models.NewStoreEvent(billboard.StoreID, models.WebhookEventBillboardCreated, billboard.ToBillboardResponse())
+	if err != nil {
+		return err
+	}
+
+	if err := b.br.CreateBillboard(ctx, billboard, event); err != nil {
 		return fmt.Errorf("create billboard: %w", err)
 	}
 
 	return nil
 }
 
-func (b *billboardService) GetBillboardsPagedList(ctx context.Context, storeID string, pag models.BillboardPagination) (*models.PaginatedResponse, error) {
+func (b *billboardService) UpdateBillboard(ctx context.Context, storeID, userID, billboardID string, file *multipart.FileHeader, payload models.BillboardPayload) (*models.BillboardResponse, error) {
+	if err := payload.Validate(); err != nil {
+		return nil, err
+	}
+
+	store, err := b.ss.CheckPermission(ctx, storeID, userID, models.PermissionEditCatalog)
+	if err != nil {
+		return nil, err
+	}
+
+	billboard, err := b.br.GetBillboardByID(ctx, billboardID)
+	if err != nil {
+		return nil, fmt.Errorf("get billboard by id %s: %w", billboardID, err)
+	}
+
+	if billboard == nil || billboard.StoreID != store.ID {
+		return nil, models.ErrBillboardNotFound
+	}
+
+	var content []byte
+	if file != nil {
+		if content, err = b.is.ReadImage(file); err != nil {
+			return nil, err
+		}
+	}
+
+	previous := billboard.Renditions
+	billboard.Apply(payload)
+	billboard.UpdatedAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+	var renditions *models.ImageRenditions
+	if content != nil {
+		if renditions, err = b.is.UploadImage(ctx, content); err != nil {
+			return nil, err
+		}
+		billboard.SetImage(*renditions)
+	}
+
+	event, err := models.NewStoreEvent(billboard.StoreID, models.WebhookEventBillboardUpdated, billboard.ToBillboardResponse())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := b.br.UpdateBillboard(ctx, billboard, event); err != nil {
+		if renditions != nil {
+			b.is.DeleteImage(ctx, *renditions)
+		}
+		return nil, fmt.Errorf("update billboard: %w", err)
+	}
+
+	if renditions != nil {
+		b.is.DeleteImage(ctx, previous)
+	}
+
+	resp := billboard.ToBillboardResponse()
+	return &resp, nil
+}
+
+func (b *billboardService) GetBillboardsPagedList(ctx context.Context, userID, storeID string, pag models.BillboardPagination) (*models.PaginatedResponse, error) {
+	if _, err := b.ss.CheckPermission(ctx, storeID, userID, models.PermissionViewStore); err != nil {
+		return nil, err
+	}
+
 	result, err := b.br.GetBillboardsPagedList(ctx, storeID, pag)
 	if err != nil {
 		return nil, err
@@ -88,7 +167,7 @@ func (b *billboardService) GetBillboardsPagedList(ctx context.Context, storeID s
 }
 
 func (b *billboardService) DeleteBillboard(ctx context.Context, storeID string, userID string, billboardID string) error {
-	store, err := b.ss.GetStoreByStoreID(ctx, storeID, userID)
+	store, err := b.ss.CheckPermission(ctx, storeID, userID, models.PermissionEditCatalog)
 	if err != nil {
 		return err
 	}
@@ -98,18 +177,33 @@ func (b *billboardService) DeleteBillboard(ctx context.Context, storeID string,
 		return err
 	}
 
+	if billboard == nil {
+		return models.ErrBillboardNotFound
+	}
+
 	if billboard.StoreID.String() != store.ID.String() {
 		return models.ErrBillboardNotPertenence
 	}
 
-	if err := b.br.DeleteBillboard(ctx, billboardID); err != nil {
+	event, err := models.NewStoreEvent(billboard.StoreID, models.WebhookEventBillboardDeleted, models.WebhookDeletedData{ID: billboard.ID})
+	if err != nil {
 		return err
 	}
 
+	if err := b.br.DeleteBillboard(ctx, billboardID, event); err != nil {
+		return err
+	}
+
+	b.is.DeleteImage(ctx, billboard.Renditions)
+
 	return nil
 }
 
-func (b *billboardService) GetBillboardByID(ctx context.Context, storeId, billboardID string) (*models.BillboardResponse, error) {
+func (b *billboardService) GetBillboardByID(ctx context.Context, userID, storeId, billboardID string) (*models.BillboardResponse, error) {
+	if _, err := b.ss.CheckPermission(ctx, storeId, userID, models.PermissionViewStore); err != nil {
+		return nil, err
+	}
+
 	billboard, err := b.br.GetBillboardByID(ctx, billboardID)
 	if err != nil {
 		return nil, err
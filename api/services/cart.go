@@ -0,0 +1,319 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/g-villarinho/flash-buy-api/models"
+	"github.com/g-villarinho/flash-buy-api/pkgs"
+	"github.com/g-villarinho/flash-buy-api/repositories"
+	"github.com/g-villarinho/flash-buy-api/utils"
+	"github.com/google/uuid"
+)
+
+const (
+	cartExpiration = 7 * 24 * time.Hour
+	cartTokenSize  = 32
+)
+
+type CartService interface {
+	CreateCart(ctx context.Context, storeID, customerID string) (*models.CartResponse, error)
+	GetCart(ctx context.Context, storeID string, identity models.CartIdentity) (*models.CartResponse, error)
+	AddItem(ctx context.Context, storeID string, identity models.CartIdentity, payload models.AddCartItemPayload) (*models.CartResponse, error)
+	UpdateItem(ctx context.Context, storeID string, identity models.CartIdentity, itemID string, quantity int) (*models.CartResponse, error)
+	RemoveItem(ctx context.Context, storeID string, identity models.CartIdentity, itemID string) (*models.CartResponse, error)
+	ResolveCart(ctx context.Context, storeID string, identity models.CartIdentity) (*models.Cart, error)
+	DeleteCart(ctx context.Context, cartID string) error
+	DeleteExpiredCarts(ctx context.Context) error
+}
+
+type cartService struct {
+	di  *pkgs.Di
+	cr  repositories.CartRepository
+	pr  repositories.ProductRepository
+	pvr repositories.ProductVariantRepository
+	sr  repositories.StoreRepository
+}
+
+func NewCartService(di *pkgs.Di) (CartService, error) {
+	cr, err := pkgs.Invoke[repositories.CartRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	pr, err := pkgs.Invoke[repositories.ProductRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	pvr, err := pkgs.Invoke[repositories.ProductVariantRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	sr, err := pkgs.Invoke[repositories.StoreRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cartService{
+		di:  di,
+		cr:  cr,
+		pr:  pr,
+		pvr: pvr,
+		sr:  sr,
+	}, nil
+}
+
+func (c *cartService) CreateCart(ctx context.Context, storeID, customerID string) (*models.CartResponse, error) {
+	store, err := c.sr.GetStoreByID(ctx, storeID)
+	if err != nil {
+		return nil, fmt.Errorf("get store by id %s: %w", storeID, err)
+	}
+
+	if store == nil {
+		return nil, models.ErrStoreNotFound
+	}
+
+	if customerID != "" {
+		cart, err := c.cr.GetCartByCustomerID(ctx, storeID, customerID)
+		if err != nil {
+			return nil, fmt.Errorf("get cart by customer id %s: %w", customerID, err)
+		}
+
+		if cart != nil {
+			return cart.ToCartResponse(), nil
+		}
+	}
+
+	token, err := utils.GenerateRandomToken(cartTokenSize)
+	if err != nil {
+		return nil, fmt.Errorf("generate cart token: %w", err)
+	}
+
+	cart, err := models.NewCart(storeID, token, customerID, time.Now().Add(cartExpiration))
+	if err != nil {
+		return nil, fmt.Errorf("new cart: %w", err)
+	}
+
+	if err := c.cr.CreateCart(ctx, cart); err != nil {
+		return nil, fmt.Errorf("create cart: %w", err)
+	}
+
+	return cart.ToCartResponse(), nil
+}
+
+func (c *cartService) GetCart(ctx context.Context, storeID string, identity models.CartIdentity) (*models.CartResponse, error) {
+	cart, err := c.ResolveCart(ctx, storeID, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	return cart.ToCartResponse(), nil
+}
+
+func (c *cartService) AddItem(ctx context.Context, storeID string, identity models.CartIdentity, payload models.AddCartItemPayload) (*models.CartResponse, error) {
+	cart, err := c.ResolveCart(ctx, storeID, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	product, err := c.getAvailableProduct(ctx, storeID, payload.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	variantID, err := c.getProductVariantID(ctx, product, payload.VariantID)
+	if err != nil {
+		return nil, err
+	}
+
+	item := cart.FindItem(product.ID, variantID)
+	if item == nil {
+		if !isValidCartQuantity(payload.Quantity) {
+			return nil, models.ErrInvalidCartQuantity
+		}
+
+		if err := c.cr.CreateCartItem(ctx, models.NewCartItem(cart.ID, product.ID, variantID, payload.Quantity)); err != nil {
+			return nil, fmt.Errorf("create cart item: %w", err)
+		}
+	} else {
+		quantity := item.Quantity + payload.Quantity
+		if payload.Quantity < 1 || !isValidCartQuantity(quantity) {
+			return nil, models.ErrInvalidCartQuantity
+		}
+
+		item.Quantity = quantity
+		item.UpdatedAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+		if err := c.cr.UpdateCartItem(ctx, item); err != nil {
+			return nil, fmt.Errorf("update cart item: %w", err)
+		}
+	}
+
+	return c.touchCart(ctx, cart, identity)
+}
+
+func (c *cartService) UpdateItem(ctx context.Context, storeID string, identity models.CartIdentity, itemID string, quantity int) (*models.CartResponse, error) {
+	if !isValidCartQuantity(quantity) {
+		return nil, models.ErrInvalidCartQuantity
+	}
+
+	cart, err := c.ResolveCart(ctx, storeID, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	item := cart.FindItemByID(itemID)
+	if item == nil {
+		return nil, models.ErrCartItemNotFound
+	}
+
+	if item.Product.IsArchived {
+		return nil, models.ErrProductArchived
+	}
+
+	item.Quantity = quantity
+	item.UpdatedAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+	if err := c.cr.UpdateCartItem(ctx, item); err != nil {
+		return nil, fmt.Errorf("update cart item: %w", err)
+	}
+
+	return c.touchCart(ctx, cart, identity)
+}
+
+func (c *cartService) RemoveItem(ctx context.Context, storeID string, identity models.CartIdentity, itemID string) (*models.CartResponse, error) {
+	cart, err := c.ResolveCart(ctx, storeID, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	if item := cart.FindItemByID(itemID); item == nil {
+		return nil, models.ErrCartItemNotFound
+	}
+
+	if err := c.cr.DeleteCartItem(ctx, itemID); err != nil {
+		return nil, fmt.Errorf("delete cart item: %w", err)
+	}
+
+	return c.touchCart(ctx, cart, identity)
+}
+
+func (c *cartService) ResolveCart(ctx context.Context, storeID string, identity models.CartIdentity) (*models.Cart, error) {
+	var cart *models.Cart
+	var err error
+
+	if identity.CustomerID != "" {
+		cart, err = c.cr.GetCartByCustomerID(ctx, storeID, identity.CustomerID)
+		if err != nil {
+			return nil, fmt.Errorf("get cart by customer id %s: %w", identity.CustomerID, err)
+		}
+	}
+
+	if cart == nil && identity.Token != "" {
+		cart, err = c.cr.GetCartByToken(ctx, storeID, identity.Token)
+		if err != nil {
+			return nil, fmt.Errorf("get cart by token: %w", err)
+		}
+	}
+
+	if cart == nil {
+		return nil, models.ErrCartNotFound
+	}
+
+	if cart.IsExpired() {
+		if err := c.cr.DeleteCart(ctx, cart.ID.String()); err != nil {
+			return nil, fmt.Errorf("delete expired cart: %w", err)
+		}
+
+		return nil, models.ErrCartNotFound
+	}
+
+	return cart, nil
+}
+
+func (c *cartService) DeleteExpiredCarts(ctx context.Context) error {
+	if err := c.cr.DeleteExpiredCarts(ctx, time.Now()); err != nil {
+		return fmt.Errorf("delete expired carts: %w", err)
+	}
+
+	return nil
+}
+
+func (c *cartService) DeleteCart(ctx context.Context, cartID string) error {
+	if err := c.cr.DeleteCart(ctx, cartID); err != nil {
+		return fmt.Errorf("delete cart %s: %w", cartID, err)
+	}
+
+	return nil
+}
+
+func (c *cartService) touchCart(ctx context.Context, cart *models.Cart, identity models.CartIdentity) (*models.CartResponse, error) {
+	now := time.Now()
+	cart.ExpiresAt = now.Add(cartExpiration)
+	cart.UpdatedAt = sql.NullTime{Time: now, Valid: true}
+
+	if err := c.cr.UpdateCart(ctx, cart); err != nil {
+		return nil, fmt.Errorf("update cart: %w", err)
+	}
+
+	return c.GetCart(ctx, cart.StoreID.String(), identity)
+}
+
+func (c *cartService) getAvailableProduct(ctx context.Context, storeID, productID string) (*models.Product, error) {
+	if _, err := uuid.Parse(productID); err != nil {
+		return nil, models.ErrProductNotFound
+	}
+
+	product, err := c.pr.GetProductByID(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("get product by id %s: %w", productID, err)
+	}
+
+	if product == nil || product.StoreID.String() != storeID {
+		return nil, models.ErrProductNotFound
+	}
+
+	if product.IsArchived {
+		return nil, models.ErrProductArchived
+	}
+
+	return product, nil
+}
+
+func (c *cartService) getProductVariantID(ctx context.Context, product *models.Product, variantID string) (uuid.NullUUID, error) {
+	if variantID == "" {
+		variants, err := c.pvr.GetProductVariantsByProductID(ctx, product.ID.String())
+		if err != nil {
+			return uuid.NullUUID{}, fmt.Errorf("get product variants: %w", err)
+		}
+
+		if len(variants) > 0 {
+			return uuid.NullUUID{}, models.ErrProductVariantRequired
+		}
+
+		return uuid.NullUUID{}, nil
+	}
+
+	if _, err := uuid.Parse(variantID); err != nil {
+		return uuid.NullUUID{}, models.ErrProductVariantNotFound
+	}
+
+	variant, err := c.pvr.GetProductVariantByID(ctx, variantID)
+	if err != nil {
+		return uuid.NullUUID{}, fmt.Errorf("get product variant by id %s: %w", variantID, err)
+	}
+
+	if variant == nil || variant.ProductID != product.ID {
+		return uuid.NullUUID{}, models.ErrProductVariantNotFound
+	}
+
+	return uuid.NullUUID{UUID: variant.ID, Valid: true}, nil
+}
+
+func isValidCartQuantity(quantity int) bool {
+	return quantity >= 1 && quantity <= models.MaxCartItemQuantity
+}
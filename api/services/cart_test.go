@@ -0,0 +1,321 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/g-villarinho/flash-buy-api/models"
+	"github.com/g-villarinho/flash-buy-api/repositories"
+	"github.com/google/uuid"
+)
+
+type fakeProductRepository struct {
+	repositories.ProductRepository
+	products map[uuid.UUID]*models.Product
+}
+
+type fakeProductVariantRepository struct {
+	repositories.ProductVariantRepository
+	variants map[uuid.UUID]*models.ProductVariant
+}
+
+func (f *fakeProductRepository) GetProductByID(ctx context.Context, ID string) (*models.Product, error) {
+	product, ok := f.products[uuid.MustParse(ID)]
+	if !ok {
+		return nil, nil
+	}
+
+	copied := *product
+	return &copied, nil
+}
+
+func (f *fakeProductVariantRepository) GetProductVariantsByProductID(ctx context.Context, productID string) ([]models.ProductVariant, error) {
+	var variants []models.ProductVariant
+	for _, variant := range f.variants {
+		if variant.ProductID.String() == productID {
+			variants = append(variants, *variant)
+		}
+	}
+	return variants, nil
+}
+
+func (f *fakeProductVariantRepository) GetProductVariantByID(ctx context.Context, ID string) (*models.ProductVariant, error) {
+	variant, ok := f.variants[uuid.MustParse(ID)]
+	if !ok {
+		return nil, nil
+	}
+
+	copied := *variant
+	return &copied, nil
+}
+
+type fakeCartRepository struct {
+	products *fakeProductRepository
+	variants *fakeProductVariantRepository
+	carts    map[uuid.UUID]models.Cart
+	items    map[uuid.UUID]models.CartItem
+}
+
+func (f *fakeCartRepository) CreateCart(ctx context.Context, cart *models.Cart) error {
+	f.carts[cart.ID] = *cart
+	return nil
+}
+
+func (f *fakeCartRepository) GetCartByToken(ctx context.Context, storeID, token string) (*models.Cart, error) {
+	for _, cart := range f.carts {
+		if cart.StoreID.String() == storeID && cart.Token == token {
+			return f.load(cart), nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeCartRepository) GetCartByCustomerID(ctx context.Context, storeID, customerID string) (*models.Cart, error) {
+	for _, cart := range f.carts {
+		if cart.StoreID.String() == storeID && cart.CustomerID.Valid && cart.CustomerID.UUID.String() == customerID {
+			return f.load(cart), nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeCartRepository) UpdateCart(ctx context.Context, cart *models.Cart) error {
+	stored := *cart
+	stored.Items = nil
+	f.carts[cart.ID] = stored
+	return nil
+}
+
+func (f *fakeCartRepository) DeleteCart(ctx context.Context, ID string) error {
+	delete(f.carts, uuid.MustParse(ID))
+	return nil
+}
+
+func (f *fakeCartRepository) DeleteExpiredCarts(ctx context.Context, now time.Time) error {
+	for id, cart := range f.carts {
+		if cart.ExpiresAt.Before(now) {
+			delete(f.carts, id)
+		}
+	}
+	return nil
+}
+
+func (f *fakeCartRepository) CreateCartItem(ctx context.Context, item *models.CartItem) error {
+	f.items[item.ID] = *item
+	return nil
+}
+
+func (f *fakeCartRepository) UpdateCartItem(ctx context.Context, item *models.CartItem) error {
+	f.items[item.ID] = *item
+	return nil
+}
+
+func (f *fakeCartRepository) DeleteCartItem(ctx context.Context, ID string) error {
+	delete(f.items, uuid.MustParse(ID))
+	return nil
+}
+
+func (f *fakeCartRepository) load(cart models.Cart) *models.Cart {
+	cart.Items = nil
+	for _, item := range f.items {
+		if item.CartID != cart.ID {
+			continue
+		}
+
+		item.Product = *f.products.products[item.ProductID]
+		if item.VariantID.Valid {
+			variant := *f.variants.variants[item.VariantID.UUID]
+			item.Variant = &variant
+		}
+
+		cart.Items = append(cart.Items, item)
+	}
+
+	return &cart
+}
+
+type cartFixture struct {
+	service  *cartService
+	products *fakeProductRepository
+	variants *fakeProductVariantRepository
+	identity models.CartIdentity
+	storeID  uuid.UUID
+}
+
+func newCartFixture(t *testing.T) *cartFixture {
+	t.Helper()
+
+	products := &fakeProductRepository{products: make(map[uuid.UUID]*models.Product)}
+	variants := &fakeProductVariantRepository{variants: make(map[uuid.UUID]*models.ProductVariant)}
+	carts := &fakeCartRepository{
+		products: products,
+		variants: variants,
+		carts:    make(map[uuid.UUID]models.Cart),
+		items:    make(map[uuid.UUID]models.CartItem),
+	}
+
+	storeID := uuid.New()
+	cart, err := models.NewCart(storeID.String(), "cart-token", "", time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	carts.carts[cart.ID] = *cart
+
+	return &cartFixture{
+		service:  &cartService{cr: carts, pr: products, pvr: variants},
+		products: products,
+		variants: variants,
+		identity: models.CartIdentity{Token: "cart-token"},
+		storeID:  storeID,
+	}
+}
+
+func (f *cartFixture) addProduct(priceInCents int64) *models.Product {
+	product := &models.Product{ID: uuid.New(), Name: "Camiseta", PriceInCents: priceInCents, StoreID: f.storeID}
+	f.products.products[product.ID] = product
+	return product
+}
+
+func (f *cartFixture) addVariant(product *models.Product, price sql.NullInt64) *models.ProductVariant {
+	variant := &models.ProductVariant{ID: uuid.New(), SKU: uuid.NewString(), PriceInCents: price, StoreID: f.storeID, ProductID: product.ID}
+	f.variants.variants[variant.ID] = variant
+	return variant
+}
+
+func (f *cartFixture) addItem(t *testing.T, productID, variantID string, quantity int) *models.CartResponse {
+	t.Helper()
+
+	resp, err := f.service.AddItem(context.Background(), f.storeID.String(), f.identity, models.AddCartItemPayload{
+		ProductID: productID,
+		VariantID: variantID,
+		Quantity:  quantity,
+	})
+	if err != nil {
+		t.Fatalf("AddItem() error = %v", err)
+	}
+
+	return resp
+}
+
+func TestCartTotals(t *testing.T) {
+	f := newCartFixture(t)
+
+	plain := f.addProduct(1990)
+	shirt := f.addProduct(5000)
+	priced := f.addVariant(shirt, sql.NullInt64{Int64: 5500, Valid: true})
+	inherited := f.addVariant(shirt, sql.NullInt64{})
+
+	f.addItem(t, plain.ID.String(), "", 3)
+	f.addItem(t, shirt.ID.String(), priced.ID.String(), 1)
+	resp := f.addItem(t, shirt.ID.String(), inherited.ID.String(), 2)
+
+	// 3 x 19,90 + 1 x 55,00 + 2 x 50,00
+	if resp.Total != 214.70 {
+		t.Errorf("Total = %v, want 214.70", resp.Total)
+	}
+
+	if resp.ItemsCount != 6 {
+		t.Errorf("ItemsCount = %d, want 6", resp.ItemsCount)
+	}
+
+	for _, item := range resp.Items {
+		want := math.Round(item.UnitPrice*100) * float64(item.Quantity)
+		if math.Round(item.Subtotal*100) != want {
+			t.Errorf("item %s subtotal = %v, want %v", item.ID, item.Subtotal, want/100)
+		}
+	}
+}
+
+func TestCartAddItemMergesQuantities(t *testing.T) {
+	f := newCartFixture(t)
+	product := f.addProduct(1000)
+
+	f.addItem(t, product.ID.String(), "", 2)
+	resp := f.addItem(t, product.ID.String(), "", 3)
+
+	if len(resp.Items) != 1 || resp.Items[0].Quantity != 5 {
+		t.Fatalf("AddItem() twice = %+v, want one item with quantity 5", resp.Items)
+	}
+
+	if resp.Total != 50 {
+		t.Errorf("Total = %v, want 50", resp.Total)
+	}
+}
+
+func TestCartAddItemQuantityLimits(t *testing.T) {
+	ctx := context.Background()
+	f := newCartFixture(t)
+	product := f.addProduct(1000)
+
+	for _, quantity := range []int{0, -1, models.MaxCartItemQuantity + 1} {
+		_, err := f.service.AddItem(ctx, f.storeID.String(), f.identity, models.AddCartItemPayload{ProductID: product.ID.String(), Quantity: quantity})
+		if err != models.ErrInvalidCartQuantity {
+			t.Errorf("AddItem() with quantity %d = %v, want %v", quantity, err, models.ErrInvalidCartQuantity)
+		}
+	}
+
+	f.addItem(t, product.ID.String(), "", models.MaxCartItemQuantity)
+
+	_, err := f.service.AddItem(ctx, f.storeID.String(), f.identity, models.AddCartItemPayload{ProductID: product.ID.String(), Quantity: 1})
+	if err != models.ErrInvalidCartQuantity {
+		t.Errorf("AddItem() above the item limit = %v, want %v", err, models.ErrInvalidCartQuantity)
+	}
+}
+
+func TestCartAddItemVariantRules(t *testing.T) {
+	ctx := context.Background()
+	f := newCartFixture(t)
+
+	plain := f.addProduct(1000)
+	shirt := f.addProduct(5000)
+	f.addVariant(shirt, sql.NullInt64{})
+	other := f.addVariant(f.addProduct(100), sql.NullInt64{})
+
+	tests := []struct {
+		name    string
+		payload models.AddCartItemPayload
+		want    error
+	}{
+		{name: "variant required", payload: models.AddCartItemPayload{ProductID: shirt.ID.String(), Quantity: 1}, want: models.ErrProductVariantRequired},
+		{name: "variant of another product", payload: models.AddCartItemPayload{ProductID: shirt.ID.String(), VariantID: other.ID.String(), Quantity: 1}, want: models.ErrProductVariantNotFound},
+		{name: "variant on product without variants", payload: models.AddCartItemPayload{ProductID: plain.ID.String(), VariantID: other.ID.String(), Quantity: 1}, want: models.ErrProductVariantNotFound},
+		{name: "unknown product", payload: models.AddCartItemPayload{ProductID: uuid.NewString(), Quantity: 1}, want: models.ErrProductNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := f.service.AddItem(ctx, f.storeID.String(), f.identity, tt.payload); err != tt.want {
+				t.Errorf("AddItem() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCartArchivedProductLeavesTotal(t *testing.T) {
+	ctx := context.Background()
+	f := newCartFixture(t)
+
+	kept := f.addProduct(1000)
+	archived := f.addProduct(2500)
+
+	f.addItem(t, kept.ID.String(), "", 1)
+	f.addItem(t, archived.ID.String(), "", 2)
+
+	f.products.products[archived.ID].IsArchived = true
+
+	resp, err := f.service.GetCart(ctx, f.storeID.String(), f.identity)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.Total != 10 || resp.ItemsCount != 1 {
+		t.Errorf("GetCart() total = %v, items = %d, want 10 and 1", resp.Total, resp.ItemsCount)
+	}
+
+	if _, err := f.service.AddItem(ctx, f.storeID.String(), f.identity, models.AddCartItemPayload{ProductID: archived.ID.String(), Quantity: 1}); err != models.ErrProductArchived {
+		t.Errorf("AddItem() with archived product = %v, want %v", err, models.ErrProductArchived)
+	}
+}
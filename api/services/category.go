@@ -5,15 +5,20 @@ import (
 	"fmt"
 
 	"github.com/g-villarinho/flash-buy-api/models"
+	"github.com/g-villarinho/flash-buy-api/persistence"
 	"github.com/g-villarinho/flash-buy-api/pkgs"
 	"github.com/g-villarinho/flash-buy-api/repositories"
+	"github.com/google/uuid"
 )
 
 type CategoryService interface {
 	CreateCategory(ctx context.Context, userID string, category models.Category) error
-	GetCategoriesPagedList(ctx context.Context, storeID string, pag models.CategoryPagination) (*models.PaginatedResponse, error)
-	GetCategoryByID(ctx context.Context, storeID, categoryID string) (*models.CategoryResponse, error)
+	GetCategoriesPagedList(ctx context.Context, userID, storeID string, pag models.CategoryPagination) (*models.PaginatedResponse, error)
+	GetCategoryByID(ctx context.Context, userID, storeID, categoryID string) (*models.CategoryResponse, error)
 	DeleteCategory(ctx context.Context, userID, storeID, categoryID string) error
+	GetCategoryTree(ctx context.Context, userID, storeID string) ([]models.CategoryTreeResponse, error)
+	MoveCategory(ctx context.Context, userID, storeID, categoryID string, payload models.MoveCategoryPayload) (*models.CategoryResponse, error)
+	ReorderCategories(ctx context.Context, userID, storeID string, payload models.ReorderCategoriesPayload) ([]models.CategoryResponse, error)
 }
 
 type categoryService struct {
@@ -21,6 +26,7 @@ type categoryService struct {
 	ss StoreService
 	bs BillboardService
 	cr repositories.CategoryRepository
+	tr persistence.Transactor
 }
 
 func NewCategoryService(di *pkgs.Di) (CategoryService, error) {
@@ -39,33 +45,62 @@ func NewCategoryService(di *pkgs.Di) (CategoryService, error) {
 		return nil, err
 	}
 
+	tr, err := pkgs.Invoke[persistence.Transactor](di)
+	if err != nil {
+		return nil, err
+	}
+
 	return &categoryService{
 		di: di,
 		ss: ss,
 		bs: bs,
 		cr: cr,
+		tr: tr,
 	}, nil
 }
 
 func (c *categoryService) CreateCategory(ctx context.Context, userID string, category models.Category) error {
-	_, err := c.ss.GetStoreByStoreID(ctx, category.StoreID.String(), userID)
+	_, err := c.ss.CheckPermission(ctx, category.StoreID.String(), userID, models.PermissionEditCatalog)
 	if err != nil {
 		return err
 	}
 
-	_, err = c.bs.GetBillboardByID(ctx, category.StoreID.String(), category.BillboardID.String())
+	_, err = c.bs.GetBillboardByID(ctx, userID, category.StoreID.String(), category.BillboardID.String())
 	if err != nil {
 		return err
 	}
 
-	if err := c.cr.CreateCategory(ctx, &category); err != nil {
-		return err
-	}
+	return c.tr.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := c.cr.LockStoreCategories(ctx, category.StoreID.String()); err != nil {
+			return fmt.Errorf("lock store categories: %w", err)
+		}
 
-	return nil
+		if category.ParentID.Valid {
+			if _, err := c.getParentCategory(ctx, category.StoreID.String(), category.ParentID.UUID); err != nil {
+				return err
+			}
+		}
+
+		position, err := c.cr.GetNextCategoryPosition(ctx, category.StoreID.String(), category.ParentID)
+		if err != nil {
+			return fmt.Errorf("get next category position: %w", err)
+		}
+
+		category.Position = position
+
+		if err := c.cr.CreateCategory(ctx, &category); err != nil {
+			return err
+		}
+
+		return nil
+	})
 }
 
-func (c *categoryService) GetCategoriesPagedList(ctx context.Context, storeID string, pag models.CategoryPagination) (*models.PaginatedResponse, error) {
+func (c *categoryService) GetCategoriesPagedList(ctx context.Context, userID, storeID string, pag models.CategoryPagination) (*models.PaginatedResponse, error) {
+	if _, err := c.ss.CheckPermission(ctx, storeID, userID, models.PermissionViewStore); err != nil {
+		return nil, err
+	}
+
 	result, err := c.cr.GetCategoriesPagedList(ctx, storeID, pag)
 	if err != nil {
 		return nil, err
@@ -80,7 +115,11 @@ func (c *categoryService) GetCategoriesPagedList(ctx context.Context, storeID st
 	}, nil
 }
 
-func (c *categoryService) GetCategoryByID(ctx context.Context, storeID string, categoryID string) (*models.CategoryResponse, error) {
+func (c *categoryService) GetCategoryByID(ctx context.Context, userID, storeID string, categoryID string) (*models.CategoryResponse, error) {
+	if _, err := c.ss.CheckPermission(ctx, storeID, userID, models.PermissionViewStore); err != nil {
+		return nil, err
+	}
+
 	category, err := c.cr.GetCategoryByID(ctx, categoryID)
 	if err != nil {
 		return nil, err
@@ -98,7 +137,7 @@ func (c *categoryService) GetCategoryByID(ctx context.Context, storeID string, c
 }
 
 func (c *categoryService) DeleteCategory(ctx context.Context, userID, storeID string, categoryID string) error {
-	store, err := c.ss.GetStoreByStoreID(ctx, storeID, userID)
+	store, err := c.ss.CheckPermission(ctx, storeID, userID, models.PermissionEditCatalog)
 	if err != nil {
 		return err
 	}
@@ -112,12 +151,235 @@ func (c *categoryService) DeleteCategory(ctx context.Context, userID, storeID st
 		return models.ErrCategoryNotFound
 	}
 
-	if store.ID.String() != storeID {
+	if category.StoreID != store.ID {
 		return models.ErrCategoryNotFound
 	}
 
-	if err := c.cr.DeleteCategory(ctx, categoryID); err != nil {
-		return fmt.Errorf("delete category: %w", err)
+	return c.tr.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := c.cr.LockStoreCategories(ctx, storeID); err != nil {
+			return fmt.Errorf("lock store categories: %w", err)
+		}
+
+		// Apagar em cascata esconderia os produtos das subcategorias
+		hasChildren, err := c.cr.HasChildCategories(ctx, categoryID)
+		if err != nil {
+			return fmt.Errorf("has child categories: %w", err)
+		}
+
+		if hasChildren {
+			return models.ErrCategoryHasChildren
+		}
+
+		if err := c.cr.DeleteCategory(ctx, categoryID); err != nil {
+			return fmt.Errorf("delete category: %w", err)
+		}
+
+		return c.renumberCategories(ctx, storeID, category.ParentID)
+	})
+}
+
+func (c *categoryService) GetCategoryTree(ctx context.Context, userID, storeID string) ([]models.CategoryTreeResponse, error) {
+	if _, err := c.ss.CheckPermission(ctx, storeID, userID, models.PermissionViewStore); err != nil {
+		return nil, err
+	}
+
+	categories, err := c.cr.GetCategoryTree(ctx, storeID)
+	if err != nil {
+		return nil, fmt.Errorf("get category tree by store id %s: %w", storeID, err)
+	}
+
+	return models.BuildCategoryTree(categories), nil
+}
+
+func (c *categoryService) MoveCategory(ctx context.Context, userID, storeID, categoryID string, payload models.MoveCategoryPayload) (*models.CategoryResponse, error) {
+	if payload.Position != nil && *payload.Position < 0 {
+		return nil, models.ErrInvalidCategoryOrder
+	}
+
+	if _, err := c.ss.CheckPermission(ctx, storeID, userID, models.PermissionEditCatalog); err != nil {
+		return nil, err
+	}
+
+	var category *models.Category
+
+	err := c.tr.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := c.cr.LockStoreCategories(ctx, storeID); err != nil {
+			return fmt.Errorf("lock store categories: %w", err)
+		}
+
+		var err error
+		category, err = c.getStoreCategory(ctx, storeID, categoryID)
+		if err != nil {
+			return err
+		}
+
+		var parentID uuid.NullUUID
+		if payload.ParentID != nil {
+			if _, err := c.getParentCategory(ctx, storeID, *payload.ParentID); err != nil {
+				return err
+			}
+
+			subtree, err := c.cr.GetSubtreeIDs(ctx, categoryID)
+			if err != nil {
+				return fmt.Errorf("get subtree ids of category %s: %w", categoryID, err)
+			}
+
+			for _, ID := range subtree {
+				if ID == *payload.ParentID {
+					return models.ErrCategoryCycle
+				}
+			}
+
+			parentID = uuid.NullUUID{UUID: *payload.ParentID, Valid: true}
+		}
+
+		siblings, err := c.cr.GetChildCategories(ctx, storeID, parentID)
+		if err != nil {
+			return fmt.Errorf("get child categories: %w", err)
+		}
+
+		ordered := make([]models.Category, 0, len(siblings)+1)
+		for _, sibling := range siblings {
+			if sibling.ID != category.ID {
+				ordered = append(ordered, sibling)
+			}
+		}
+
+		position := len(ordered)
+		if payload.Position != nil && *payload.Position < position {
+			position = *payload.Position
+		}
+
+		oldParentID := category.ParentID
+		category.ParentID = parentID
+
+		ordered = append(ordered[:position], append([]models.Category{*category}, ordered[position:]...)...)
+		for i := range ordered {
+			if ordered[i].ID != category.ID && ordered[i].Position == i {
+				continue
+			}
+
+			if err := c.cr.MoveCategory(ctx, ordered[i].ID.String(), parentID, i); err != nil {
+				return fmt.Errorf("move category: %w", err)
+			}
+		}
+
+		category.Position = position
+
+		if oldParentID != parentID {
+			return c.renumberCategories(ctx, storeID, oldParentID)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return category.ToCategoryResponse(), nil
+}
+
+func (c *categoryService) ReorderCategories(ctx context.Context, userID, storeID string, payload models.ReorderCategoriesPayload) ([]models.CategoryResponse, error) {
+	if _, err := c.ss.CheckPermission(ctx, storeID, userID, models.PermissionEditCatalog); err != nil {
+		return nil, err
+	}
+
+	var categories []models.Category
+
+	err := c.tr.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := c.cr.LockStoreCategories(ctx, storeID); err != nil {
+			return fmt.Errorf("lock store categories: %w", err)
+		}
+
+		var parentID uuid.NullUUID
+		if payload.ParentID != nil {
+			if _, err := c.getParentCategory(ctx, storeID, *payload.ParentID); err != nil {
+				return err
+			}
+			parentID = uuid.NullUUID{UUID: *payload.ParentID, Valid: true}
+		}
+
+		current, err := c.cr.GetChildCategories(ctx, storeID, parentID)
+		if err != nil {
+			return fmt.Errorf("get child categories: %w", err)
+		}
+
+		if len(payload.CategoryIDs) != len(current) {
+			return models.ErrInvalidCategoryOrder
+		}
+
+		byID := make(map[uuid.UUID]*models.Category, len(current))
+		for i := range current {
+			byID[current[i].ID] = &current[i]
+		}
+
+		categories = make([]models.Category, len(payload.CategoryIDs))
+		for position, categoryID := range payload.CategoryIDs {
+			category, ok := byID[categoryID]
+			if !ok {
+				return models.ErrInvalidCategoryOrder
+			}
+
+			delete(byID, categoryID)
+
+			if category.Position != position {
+				if err := c.cr.MoveCategory(ctx, categoryID.String(), parentID, position); err != nil {
+					return fmt.Errorf("move category: %w", err)
+				}
+				category.Position = position
+			}
+
+			categories[position] = *category
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toCaretegoryResponseList(categories), nil
+}
+
+func (c *categoryService) getStoreCategory(ctx context.Context, storeID, categoryID string) (*models.Category, error) {
+	category, err := c.cr.GetCategoryByID(ctx, categoryID)
+	if err != nil {
+		return nil, fmt.Errorf("get category by id %s: %w", categoryID, err)
+	}
+
+	if category == nil || category.StoreID.String() != storeID {
+		return nil, models.ErrCategoryNotFound
+	}
+
+	return category, nil
+}
+
+func (c *categoryService) getParentCategory(ctx context.Context, storeID string, parentID uuid.UUID) (*models.Category, error) {
+	parent, err := c.getStoreCategory(ctx, storeID, parentID.String())
+	if err != nil {
+		if err == models.ErrCategoryNotFound {
+			return nil, models.ErrInvalidCategoryParent
+		}
+		return nil, err
+	}
+
+	return parent, nil
+}
+
+func (c *categoryService) renumberCategories(ctx context.Context, storeID string, parentID uuid.NullUUID) error {
+	siblings, err := c.cr.GetChildCategories(ctx, storeID, parentID)
+	if err != nil {
+		return fmt.Errorf("get child categories: %w", err)
+	}
+
+	for i, sibling := range siblings {
+		if sibling.Position == i {
+			continue
+		}
+
+		if err := c.cr.MoveCategory(ctx, sibling.ID.String(), parentID, i); err != nil {
+			return fmt.Errorf("move category: %w", err)
+		}
 	}
 
 	return nil
@@ -0,0 +1,151 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/g-villarinho/flash-buy-api/models"
+	"github.com/g-villarinho/flash-buy-api/persistence"
+	"github.com/g-villarinho/flash-buy-api/pkgs"
+	"github.com/g-villarinho/flash-buy-api/repositories"
+)
+
+type CheckoutService interface {
+	Checkout(ctx context.Context, storeID string, identity models.CartIdentity, payload models.CheckoutPayload) (*models.CheckoutResponse, error)
+}
+
+type checkoutService struct {
+	di *pkgs.Di
+	cs CartService
+	ps PaymentService
+	is InventoryService
+	fs FlashSaleService
+	or repositories.OrderRepository
+	tr persistence.Transactor
+}
+
+func NewCheckoutService(di *pkgs.Di) (CheckoutService, error) {
+	cs, err := pkgs.Invoke[CartService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	ps, err := pkgs.Invoke[PaymentService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	is, err := pkgs.Invoke[InventoryService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	fs, err := pkgs.Invoke[FlashSaleService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	or, err := pkgs.Invoke[repositories.OrderRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	tr, err := pkgs.Invoke[persistence.Transactor](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &checkoutService{
+		di: di,
+		cs: cs,
+		ps: ps,
+		is: is,
+		fs: fs,
+		or: or,
+		tr: tr,
+	}, nil
+}
+
+func (c *checkoutService) Checkout(ctx context.Context, storeID string, identity models.CartIdentity, payload models.CheckoutPayload) (*models.CheckoutResponse, error) {
+	if !c.ps.Available() {
+		return nil, models.ErrPaymentUnavailable
+	}
+
+	cart, err := c.cs.ResolveCart(ctx, storeID, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := models.NewOrderFromCart(cart, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	// Pedidos que ficarem sem pagamento são cancelados pelo ExpirePendingOrders
+	err = c.tr.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := c.fs.ReserveOrderFlashSales(ctx, order); err != nil {
+			return err
+		}
+
+		if err := c.is.ReserveOrderStock(ctx, order); err != nil {
+			return err
+		}
+
+		event, err := models.NewStoreEvent(order.StoreID, models.WebhookEventOrderCreated, order.ToOrderResponse())
+		if err != nil {
+			return err
+		}
+
+		if err := c.or.CreateOrder(ctx, order, event); err != nil {
+			return fmt.Errorf("create order: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	payment, err := c.ps.CreatePayment(ctx, order)
+	if err != nil {
+		if cerr := c.cancelOrder(ctx, order); cerr != nil {
+			return nil, fmt.Errorf("cancel order %s after payment failure: %w", order.ID, cerr)
+		}
+		return nil, err
+	}
+
+	// O carrinho expira sozinho, a compra não depende dele
+	if err := c.cs.DeleteCart(ctx, cart.ID.String()); err != nil {
+		slog.Error("delete cart after checkout", "cartID", cart.ID, "error", err)
+	}
+
+	return &models.CheckoutResponse{
+		Order:   order.ToOrderResponse(),
+		Payment: payment,
+	}, nil
+}
+
+func (c *checkoutService) cancelOrder(ctx context.Context, order *models.Order) error {
+	from := order.Status
+	if err := order.TransitionTo(models.OrderStatusCancelled); err != nil {
+		return err
+	}
+
+	return c.tr.WithTransaction(ctx, func(ctx context.Context) error {
+		ok, err := c.or.UpdateOrderStatus(ctx, order, from)
+		if err != nil {
+			return fmt.Errorf("update order status: %w", err)
+		}
+
+		if !ok {
+			return models.ErrInvalidStatusTransition
+		}
+
+		if err := c.is.ReleaseOrderStock(ctx, order); err != nil {
+			return err
+		}
+
+		return c.fs.ReleaseOrderFlashSales(ctx, order)
+	})
+}
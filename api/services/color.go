@@ -11,8 +11,8 @@ import (
 
 type ColorService interface {
 	CreateColor(ctx context.Context, userID string, color models.Color) error
-	GetColorsPagedList(ctx context.Context, storeID string, pag models.ColorPagination) (*models.PaginatedResponse, error)
-	GetColorByID(ctx context.Context, storeID, colorID string) (*models.ColorResponse, error)
+	GetColorsPagedList(ctx context.Context, userID, storeID string, pag models.ColorPagination) (*models.PaginatedResponse, error)
+	GetColorByID(ctx context.Context, userID, storeID, colorID string) (*models.ColorResponse, error)
 	DeleteColor(ctx context.Context, storeID, userID, colorID string) error
 }
 
@@ -41,7 +41,7 @@ func NewColorService(di *pkgs.Di) (ColorService, error) {
 }
 
 func (c *colorService) CreateColor(ctx context.Context, userID string, color models.Color) error {
-	_, err := c.ss.GetStoreByStoreID(ctx, color.StoreID.String(), userID)
+	_, err := c.ss.CheckPermission(ctx, color.StoreID.String(), userID, models.PermissionEditCatalog)
 	if err != nil {
 		return err
 	}
@@ -62,7 +62,11 @@ func (c *colorService) CreateColor(ctx context.Context, userID string, color mod
 	return nil
 }
 
-func (c *colorService) GetColorsPagedList(ctx context.Context, storeID string, pag models.ColorPagination) (*models.PaginatedResponse, error) {
+func (c *colorService) GetColorsPagedList(ctx context.Context, userID, storeID string, pag models.ColorPagination) (*models.PaginatedResponse, error) {
+	if _, err := c.ss.CheckPermission(ctx, storeID, userID, models.PermissionViewStore); err != nil {
+		return nil, err
+	}
+
 	result, err := c.cr.GetColorsPagedList(ctx, storeID, pag)
 	if err != nil {
 		return nil, err
@@ -77,7 +81,11 @@ func (c *colorService) GetColorsPagedList(ctx context.Context, storeID string, p
 	}, nil
 }
 
-func (c *colorService) GetColorByID(ctx context.Context, storeID string, colorID string) (*models.ColorResponse, error) {
+func (c *colorService) GetColorByID(ctx context.Context, userID, storeID string, colorID string) (*models.ColorResponse, error) {
+	if _, err := c.ss.CheckPermission(ctx, storeID, userID, models.PermissionViewStore); err != nil {
+		return nil, err
+	}
+
 	color, err := c.cr.GetColorByID(ctx, colorID)
 	if err != nil {
 		return nil, err
@@ -95,7 +103,7 @@ func (c *colorService) GetColorByID(ctx context.Context, storeID string, colorID
 }
 
 func (c *colorService) DeleteColor(ctx context.Context, storeID, userID, colorID string) error {
-	_, err := c.ss.GetStoreByStoreID(ctx, storeID, userID)
+	_, err := c.ss.CheckPermission(ctx, storeID, userID, models.PermissionEditCatalog)
 	if err != nil {
 		return err
 	}
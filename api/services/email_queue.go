@@ -0,0 +1,120 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/g-villarinho/flash-buy-api/config"
+	"github.com/g-villarinho/flash-buy-api/models"
+	"github.com/g-villarinho/flash-buy-api/notifications"
+	"github.com/g-villarinho/flash-buy-api/pkgs"
+	"github.com/g-villarinho/flash-buy-api/repositories"
+	"github.com/g-villarinho/flash-buy-api/utils"
+	"github.com/google/uuid"
+)
+
+type EmailQueueService interface {
+	QueueVerificationEmail(ctx context.Context, email, code string) error
+	QueueMagicLinkEmail(ctx context.Context, email, link string) error
+	QueueStoreInvitationEmail(ctx context.Context, invitationID uuid.UUID, email string, data models.StoreInvitationEmailData) error
+}
+
+type emailQueueService struct {
+	di  *pkgs.Di
+	jq  JobQueue
+	en  notifications.EmailNotification
+	sir repositories.StoreInvitationRepository
+}
+
+func NewEmailQueueService(di *pkgs.Di) (EmailQueueService, error) {
+	jq, err := pkgs.Invoke[JobQueue](di)
+	if err != nil {
+		return nil, err
+	}
+
+	en, err := pkgs.Invoke[notifications.EmailNotification](di)
+	if err != nil {
+		return nil, err
+	}
+
+	sir, err := pkgs.Invoke[repositories.StoreInvitationRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &emailQueueService{
+		di:  di,
+		jq:  jq,
+		en:  en,
+		sir: sir,
+	}
+
+	RegisterJobHandler(jq, models.JobTypeVerificationEmail, e.sendVerificationEmail)
+	RegisterJobHandler(jq, models.JobTypeMagicLinkEmail, e.sendMagicLinkEmail)
+	RegisterJobHandler(jq, models.JobTypeStoreInvitationEmail, e.sendStoreInvitationEmail)
+
+	return e, nil
+}
+
+func (e *emailQueueService) QueueVerificationEmail(ctx context.Context, email, code string) error {
+	return e.jq.Enqueue(ctx, models.JobTypeVerificationEmail, models.VerificationEmailJob{
+		Email: email,
+		Code:  code,
+	})
+}
+
+func (e *emailQueueService) QueueMagicLinkEmail(ctx context.Context, email, link string) error {
+	return e.jq.Enqueue(ctx, models.JobTypeMagicLinkEmail, models.MagicLinkEmailJob{
+		Email: email,
+		Link:  link,
+	})
+}
+
+func (e *emailQueueService) QueueStoreInvitationEmail(ctx context.Context, invitationID uuid.UUID, email string, data models.StoreInvitationEmailData) error {
+	return e.jq.Enqueue(ctx, models.JobTypeStoreInvitationEmail, models.StoreInvitationEmailJob{
+		InvitationID: invitationID,
+		Email:        email,
+		Data:         data,
+	})
+}
+
+func (e *emailQueueService) sendVerificationEmail(ctx context.Context, job models.VerificationEmailJob) error {
+	return e.en.SendVerificationEmail(ctx, job.Email, job.Code)
+}
+
+func (e *emailQueueService) sendMagicLinkEmail(ctx context.Context, job models.MagicLinkEmailJob) error {
+	return e.en.SendMagicLinkEmail(ctx, job.Email, job.Link)
+}
+
+// Só o link do último e-mail enviado aceita o convite
+func (e *emailQueueService) sendStoreInvitationEmail(ctx context.Context, job models.StoreInvitationEmailJob) error {
+	invitation, err := e.sir.GetStoreInvitationByID(ctx, job.InvitationID.String())
+	if err != nil {
+		return fmt.Errorf("get store invitation by id %s: %w", job.InvitationID, err)
+	}
+
+	if invitation == nil || !invitation.IsPending() || invitation.IsExpired() {
+		return nil
+	}
+
+	token, err := utils.GenerateRandomToken(storeInvitationTokenSize)
+	if err != nil {
+		return fmt.Errorf("generate invitation token: %w", err)
+	}
+
+	ok, err := e.sir.UpdateStoreInvitationTokenHash(ctx, invitation.ID.String(), utils.HashToken(token))
+	if err != nil {
+		return fmt.Errorf("update store invitation token hash: %w", err)
+	}
+
+	if !ok {
+		return nil
+	}
+
+	data := job.Data
+	data.Link = fmt.Sprintf("%s/invitations/accept?token=%s", strings.TrimRight(config.Env.Frontend.URL, "/"), url.QueryEscape(token))
+
+	return e.en.SendStoreInvitationEmail(ctx, job.Email, data)
+}
@@ -0,0 +1,303 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/g-villarinho/flash-buy-api/models"
+	"github.com/g-villarinho/flash-buy-api/persistence"
+	"github.com/g-villarinho/flash-buy-api/pkgs"
+	"github.com/g-villarinho/flash-buy-api/repositories"
+	"github.com/google/uuid"
+)
+
+type FlashSaleService interface {
+	CreateFlashSale(ctx context.Context, userID, storeID string, payload models.CreateFlashSalePayload) (*models.FlashSaleResponse, error)
+	GetFlashSalesPagedList(ctx context.Context, userID, storeID string, pag models.FlashSalePagination) (*models.PaginatedResponse, error)
+	GetFlashSaleByID(ctx context.Context, userID, storeID, flashSaleID string) (*models.FlashSaleResponse, error)
+	DeleteFlashSale(ctx context.Context, userID, storeID, flashSaleID string) error
+	GetPublicFlashSales(ctx context.Context, storeID string) ([]models.PublicFlashSaleResponse, error)
+	GetPublicFlashSaleByID(ctx context.Context, storeID, flashSaleID string) (*models.PublicFlashSaleResponse, error)
+	ReserveOrderFlashSales(ctx context.Context, order *models.Order) error
+	ReleaseOrderFlashSales(ctx context.Context, order *models.Order) error
+}
+
+type flashSaleService struct {
+	di  *pkgs.Di
+	ss  StoreService
+	pr  repositories.ProductRepository
+	pvr repositories.ProductVariantRepository
+	fsr repositories.FlashSaleRepository
+	tr  persistence.Transactor
+}
+
+func NewFlashSaleService(di *pkgs.Di) (FlashSaleService, error) {
+	ss, err := pkgs.Invoke[StoreService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	pr, err := pkgs.Invoke[repositories.ProductRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	pvr, err := pkgs.Invoke[repositories.ProductVariantRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	fsr, err := pkgs.Invoke[repositories.FlashSaleRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	tr, err := pkgs.Invoke[persistence.Transactor](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &flashSaleService{
+		di:  di,
+		ss:  ss,
+		pr:  pr,
+		pvr: pvr,
+		fsr: fsr,
+		tr:  tr,
+	}, nil
+}
+
+func (f *flashSaleService) CreateFlashSale(ctx context.Context, userID, storeID string, payload models.CreateFlashSalePayload) (*models.FlashSaleResponse, error) {
+	if _, err := f.ss.CheckPermission(ctx, storeID, userID, models.PermissionEditCatalog); err != nil {
+		return nil, err
+	}
+
+	sale, err := payload.ToFlashSale(storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range sale.Items {
+		product, err := f.pr.GetProductByID(ctx, item.ProductID.String())
+		if err != nil {
+			return nil, fmt.Errorf("get product by id %s: %w", item.ProductID, err)
+		}
+
+		if product == nil || product.StoreID.String() != storeID {
+			return nil, models.ErrProductNotFound
+		}
+
+		if product.IsArchived {
+			return nil, models.ErrProductArchived
+		}
+
+		if item.SalePriceInCents >= product.PriceInCents {
+			return nil, models.ErrInvalidFlashSale
+		}
+
+		variants, err := f.pvr.GetProductVariantsByProductID(ctx, product.ID.String())
+		if err != nil {
+			return nil, fmt.Errorf("get product variants: %w", err)
+		}
+
+		for _, variant := range variants {
+			if item.SalePriceInCents >= variant.EffectivePriceInCents(product) {
+				return nil, models.ErrInvalidFlashSale
+			}
+		}
+	}
+
+	if err := f.fsr.CreateFlashSale(ctx, sale); err != nil {
+		return nil, fmt.Errorf("create flash sale: %w", err)
+	}
+
+	sale, err = f.getStoreFlashSale(ctx, storeID, sale.ID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return sale.ToFlashSaleResponse(), nil
+}
+
+func (f *flashSaleService) GetFlashSalesPagedList(ctx context.Context, userID, storeID string, pag models.FlashSalePagination) (*models.PaginatedResponse, error) {
+	if _, err := f.ss.CheckPermission(ctx, storeID, userID, models.PermissionViewStore); err != nil {
+		return nil, err
+	}
+
+	result, err := f.fsr.GetFlashSalesPagedList(ctx, storeID, pag)
+	if err != nil {
+		return nil, fmt.Errorf("get flash sales paged list: %w", err)
+	}
+
+	sales := *result.Data.(*[]models.FlashSale)
+	responses := make([]models.FlashSaleResponse, len(sales))
+	for i, sale := range sales {
+		responses[i] = *sale.ToFlashSaleResponse()
+	}
+
+	return &models.PaginatedResponse{
+		Data:       responses,
+		Total:      result.Total,
+		TotalPages: result.TotalPages,
+		Page:       result.Page,
+		Limit:      result.Limit,
+	}, nil
+}
+
+func (f *flashSaleService) GetFlashSaleByID(ctx context.Context, userID, storeID, flashSaleID string) (*models.FlashSaleResponse, error) {
+	if _, err := f.ss.CheckPermission(ctx, storeID, userID, models.PermissionViewStore); err != nil {
+		return nil, err
+	}
+
+	sale, err := f.getStoreFlashSale(ctx, storeID, flashSaleID)
+	if err != nil {
+		return nil, err
+	}
+
+	return sale.ToFlashSaleResponse(), nil
+}
+
+func (f *flashSaleService) DeleteFlashSale(ctx context.Context, userID, storeID, flashSaleID string) error {
+	if _, err := f.ss.CheckPermission(ctx, storeID, userID, models.PermissionEditCatalog); err != nil {
+		return err
+	}
+
+	sale, err := f.getStoreFlashSale(ctx, storeID, flashSaleID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if !now.Before(sale.StartsAt) && now.Before(sale.EndsAt) {
+		return models.ErrFlashSaleAlreadyStarted
+	}
+
+	if err := f.fsr.DeleteFlashSale(ctx, flashSaleID); err != nil {
+		return fmt.Errorf("delete flash sale: %w", err)
+	}
+
+	return nil
+}
+
+func (f *flashSaleService) GetPublicFlashSales(ctx context.Context, storeID string) ([]models.PublicFlashSaleResponse, error) {
+	sales, err := f.fsr.GetUpcomingFlashSalesByStoreID(ctx, storeID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("get upcoming flash sales: %w", err)
+	}
+
+	responses := make([]models.PublicFlashSaleResponse, len(sales))
+	for i, sale := range sales {
+		responses[i] = sale.ToPublicFlashSaleResponse()
+	}
+
+	return responses, nil
+}
+
+func (f *flashSaleService) GetPublicFlashSaleByID(ctx context.Context, storeID, flashSaleID string) (*models.PublicFlashSaleResponse, error) {
+	sale, err := f.getStoreFlashSale(ctx, storeID, flashSaleID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := sale.ToPublicFlashSaleResponse()
+	return &resp, nil
+}
+
+func (f *flashSaleService) ReserveOrderFlashSales(ctx context.Context, order *models.Order) error {
+	now := time.Now()
+
+	productIDs := make([]uuid.UUID, len(order.Items))
+	for i, item := range order.Items {
+		productIDs[i] = item.ProductID
+	}
+
+	saleItems, err := f.fsr.GetActiveFlashSaleItems(ctx, order.StoreID.String(), productIDs, now)
+	if err != nil {
+		return fmt.Errorf("get active flash sale items: %w", err)
+	}
+
+	if len(saleItems) == 0 {
+		return nil
+	}
+
+	// Em mais de uma campanha ativa vale o menor preço
+	best := make(map[uuid.UUID]models.FlashSaleItem)
+	sales := make(map[uuid.UUID]models.FlashSale)
+	for _, item := range saleItems {
+		current, ok := best[item.ProductID]
+		if !ok || item.SalePriceInCents < current.SalePriceInCents {
+			best[item.ProductID] = item
+		}
+		sales[item.FlashSaleID] = item.FlashSale
+	}
+
+	order.ApplyFlashSale(best)
+
+	quantities := order.FlashSaleQuantities()
+	if len(quantities) == 0 {
+		return nil
+	}
+
+	if !order.CustomerID.Valid {
+		return models.ErrFlashSaleLoginRequired
+	}
+
+	customerKey := models.FlashSaleCustomerKey(order)
+
+	return f.tr.WithTransaction(ctx, func(ctx context.Context) error {
+		for saleID, quantity := range quantities {
+			ok, err := f.fsr.IncrementSoldQuantity(ctx, saleID, quantity, now)
+			if err != nil {
+				return fmt.Errorf("increment flash sale sold quantity: %w", err)
+			}
+
+			if !ok {
+				return models.ErrFlashSaleUnavailable
+			}
+
+			ok, err = f.fsr.IncrementCustomerPurchase(ctx, saleID, customerKey, quantity, sales[saleID].PerCustomerLimit)
+			if err != nil {
+				return fmt.Errorf("increment flash sale customer purchase: %w", err)
+			}
+
+			if !ok {
+				return models.ErrFlashSaleCustomerLimit
+			}
+		}
+
+		return nil
+	})
+}
+
+// Deve rodar na mesma transação que cancela o pedido
+func (f *flashSaleService) ReleaseOrderFlashSales(ctx context.Context, order *models.Order) error {
+	customerKey := models.FlashSaleCustomerKey(order)
+
+	return f.tr.WithTransaction(ctx, func(ctx context.Context) error {
+		for saleID, quantity := range order.FlashSaleQuantities() {
+			if err := f.fsr.DecrementSoldQuantity(ctx, saleID, quantity); err != nil {
+				return fmt.Errorf("release flash sale sold quantity: %w", err)
+			}
+
+			if err := f.fsr.DecrementCustomerPurchase(ctx, saleID, customerKey, quantity); err != nil {
+				return fmt.Errorf("release flash sale customer purchase: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func (f *flashSaleService) getStoreFlashSale(ctx context.Context, storeID, flashSaleID string) (*models.FlashSale, error) {
+	sale, err := f.fsr.GetFlashSaleByID(ctx, flashSaleID)
+	if err != nil {
+		return nil, fmt.Errorf("get flash sale by id %s: %w", flashSaleID, err)
+	}
+
+	if sale == nil || sale.StoreID.String() != storeID {
+		return nil, models.ErrFlashSaleNotFound
+	}
+
+	return sale, nil
+}
@@ -0,0 +1,100 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/g-villarinho/flash-buy-api/models"
+	"github.com/g-villarinho/flash-buy-api/repositories"
+	"github.com/google/uuid"
+)
+
+type fakeFlashSaleRepository struct {
+	repositories.FlashSaleRepository
+	items []models.FlashSaleItem
+	sold  map[uuid.UUID]int64
+}
+
+func (f *fakeFlashSaleRepository) GetActiveFlashSaleItems(ctx context.Context, storeID string, productIDs []uuid.UUID, now time.Time) ([]models.FlashSaleItem, error) {
+	return f.items, nil
+}
+
+func (f *fakeFlashSaleRepository) IncrementSoldQuantity(ctx context.Context, ID uuid.UUID, quantity int64, now time.Time) (bool, error) {
+	f.sold[ID] += quantity
+	return true, nil
+}
+
+func (f *fakeFlashSaleRepository) IncrementCustomerPurchase(ctx context.Context, ID uuid.UUID, customerKey string, quantity, limit int64) (bool, error) {
+	return true, nil
+}
+
+func newTestFlashSale(productID uuid.UUID, salePriceInCents int64) (*flashSaleService, *fakeFlashSaleRepository) {
+	sale := models.FlashSale{ID: uuid.New(), QuantityLimit: 100, PerCustomerLimit: 10}
+	sales := &fakeFlashSaleRepository{
+		items: []models.FlashSaleItem{{
+			ID:               uuid.New(),
+			SalePriceInCents: salePriceInCents,
+			FlashSaleID:      sale.ID,
+			FlashSale:        sale,
+			ProductID:        productID,
+		}},
+		sold: make(map[uuid.UUID]int64),
+	}
+
+	return &flashSaleService{fsr: sales, tr: fakeTransactor{}}, sales
+}
+
+func TestReserveOrderFlashSalesKeepsCheaperVariantPrice(t *testing.T) {
+	productID := uuid.New()
+	service, sales := newTestFlashSale(productID, 4000)
+
+	order := &models.Order{
+		CustomerID: uuid.NullUUID{UUID: uuid.New(), Valid: true},
+		Items: []models.OrderItem{
+			{ProductID: productID, UnitPriceInCents: 5000, Quantity: 2},
+			{ProductID: productID, UnitPriceInCents: 3500, Quantity: 1, VariantID: uuid.NullUUID{UUID: uuid.New(), Valid: true}},
+		},
+	}
+
+	if err := service.ReserveOrderFlashSales(context.Background(), order); err != nil {
+		t.Fatal(err)
+	}
+
+	if order.Items[0].UnitPriceInCents != 4000 || !order.Items[0].FlashSaleID.Valid {
+		t.Errorf("product line = %d, want sale price 4000", order.Items[0].UnitPriceInCents)
+	}
+
+	if order.Items[1].UnitPriceInCents != 3500 || order.Items[1].FlashSaleID.Valid {
+		t.Errorf("cheaper variant line = %d, want its own price 3500 outside the sale", order.Items[1].UnitPriceInCents)
+	}
+
+	if order.TotalInCents != 2*4000+3500 {
+		t.Errorf("TotalInCents = %d, want %d", order.TotalInCents, 2*4000+3500)
+	}
+
+	for _, quantity := range sales.sold {
+		if quantity != 2 {
+			t.Errorf("reserved %d sale units, want 2", quantity)
+		}
+	}
+}
+
+func TestReserveOrderFlashSalesLoginRequired(t *testing.T) {
+	productID := uuid.New()
+	service, sales := newTestFlashSale(productID, 4000)
+
+	discounted := &models.Order{Items: []models.OrderItem{{ProductID: productID, UnitPriceInCents: 5000, Quantity: 1}}}
+	if err := service.ReserveOrderFlashSales(context.Background(), discounted); err != models.ErrFlashSaleLoginRequired {
+		t.Errorf("anonymous order with sale price = %v, want %v", err, models.ErrFlashSaleLoginRequired)
+	}
+
+	undiscounted := &models.Order{Items: []models.OrderItem{{ProductID: productID, UnitPriceInCents: 3000, Quantity: 1}}}
+	if err := service.ReserveOrderFlashSales(context.Background(), undiscounted); err != nil {
+		t.Errorf("anonymous order without sale price = %v, want nil", err)
+	}
+
+	if len(sales.sold) != 0 {
+		t.Errorf("reserved sale units for anonymous orders: %v", sales.sold)
+	}
+}
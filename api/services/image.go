@@ -1,45 +1,222 @@
 package services
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"log/slog"
 	"mime/multipart"
+	"net/http"
 
-	"github.com/g-villarinho/flash-buy-api/clients"
+	_ "image/gif"
+	_ "image/png"
+
+	_ "golang.org/x/image/webp"
+
+	"github.com/chai2010/webp"
+	"github.com/g-villarinho/flash-buy-api/config"
+	"github.com/g-villarinho/flash-buy-api/models"
 	"github.com/g-villarinho/flash-buy-api/pkgs"
+	"github.com/g-villarinho/flash-buy-api/storages"
 	"github.com/g-villarinho/flash-buy-api/utils"
+	"github.com/google/uuid"
+)
+
+const imageKeyPrefix = "images"
+
+const (
+	ImageFormatWebP = "webp"
+	ImageFormatJPEG = "jpeg"
+)
+
+const (
+	imageThumbnailSize = 200
+	imageCardSize      = 600
+	imageFullSize      = 1600
 )
 
+// Identificados pelo conteúdo, nunca pela extensão ou pelo Content-Type do cliente
+var allowedImageTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+	"image/webp": true,
+}
+
 type ImageService interface {
-	UploadImage(ctx context.Context, file *multipart.FileHeader, filename string) (string, error)
+	ReadImage(file *multipart.FileHeader) ([]byte, error)
+	UploadImage(ctx context.Context, content []byte) (*models.ImageRenditions, error)
+	DeleteImage(ctx context.Context, renditions models.ImageRenditions)
 }
 
 type imageService struct {
 	di *pkgs.Di
-	cc clients.CloudflareClient
+	bs storages.BlobStorage
 }
 
 func NewImageService(di *pkgs.Di) (ImageService, error) {
-	cc, err := pkgs.Invoke[clients.CloudflareClient](di)
+	switch config.Env.Images.Format {
+	case ImageFormatWebP, ImageFormatJPEG:
+	default:
+		return nil, fmt.Errorf("unsupported image format: %s", config.Env.Images.Format)
+	}
+
+	if config.Env.Images.Quality < 1 || config.Env.Images.Quality > 100 {
+		return nil, fmt.Errorf("image quality must be between 1 and 100: %d", config.Env.Images.Quality)
+	}
+
+	bs, err := pkgs.Invoke[storages.BlobStorage](di)
 	if err != nil {
 		return nil, err
 	}
 	return &imageService{
 		di: di,
-		cc: cc,
+		bs: bs,
 	}, nil
 }
 
-func (i *imageService) UploadImage(ctx context.Context, file *multipart.FileHeader, filename string) (string, error) {
-	imageBytes, err := utils.ConvertImageToBytes(file)
+func (i *imageService) ReadImage(file *multipart.FileHeader) ([]byte, error) {
+	if file.Size > config.Env.Images.MaxFileSize {
+		return nil, models.ErrImageTooLarge
+	}
+
+	content, err := utils.ConvertImageToBytes(file)
+	if err != nil {
+		return nil, fmt.Errorf("convert image to bytes: %w", err)
+	}
+
+	if _, err := validateImage(content); err != nil {
+		return nil, err
+	}
+
+	return content, nil
+}
+
+// A recodificação descarta o EXIF e outros metadados do original
+func (i *imageService) UploadImage(ctx context.Context, content []byte) (*models.ImageRenditions, error) {
+	contentType, err := validateImage(content)
 	if err != nil {
-		return "", fmt.Errorf("convert image to bytes: %w", err)
+		return nil, err
 	}
 
-	imageURL, err := i.cc.UploadImage(ctx, imageBytes, filename)
+	src, _, err := image.Decode(bytes.NewReader(content))
 	if err != nil {
-		return "", fmt.Errorf("upload image: %w", err)
+		return nil, models.ErrInvalidImage
+	}
+
+	orientation := 1
+	if contentType == "image/jpeg" {
+		orientation = utils.JPEGOrientation(content)
+	}
+
+	// A orientação é aplicada depois de reduzir porque as caixas são quadradas
+	full := utils.ResizeToFit(src, imageFullSize)
+	card := utils.ResizeToFit(full, imageCardSize)
+	thumbnail := utils.ResizeToFit(card, imageThumbnailSize)
+
+	prefix := fmt.Sprintf("%s/%s", imageKeyPrefix, uuid.New())
+
+	var renditions models.ImageRenditions
+	versions := []struct {
+		name string
+		img  image.Image
+		url  *string
+	}{
+		{"full", full, &renditions.Full},
+		{"card", card, &renditions.Card},
+		{"thumbnail", thumbnail, &renditions.Thumbnail},
 	}
 
-	return imageURL, nil
+	var uploaded []string
+	for _, version := range versions {
+		key, err := i.uploadRendition(ctx, prefix+"/"+version.name, utils.ApplyOrientation(version.img, orientation))
+		if err != nil {
+			for _, key := range uploaded {
+				_ = i.bs.Delete(context.WithoutCancel(ctx), key)
+			}
+			return nil, fmt.Errorf("upload %s image: %w", version.name, err)
+		}
+
+		uploaded = append(uploaded, key)
+		*version.url = i.bs.URL(key)
+	}
+
+	return &renditions, nil
+}
+
+func (i *imageService) DeleteImage(ctx context.Context, renditions models.ImageRenditions) {
+	for _, url := range []string{renditions.Full, renditions.Card, renditions.Thumbnail} {
+		key, ok := storages.KeyFromURL(i.bs, url)
+		if !ok {
+			continue
+		}
+
+		if err := i.bs.Delete(ctx, key); err != nil {
+			slog.Error("delete image", "key", key, "error", err)
+		}
+	}
+}
+
+func (i *imageService) uploadRendition(ctx context.Context, name string, img image.Image) (string, error) {
+	content, contentType, ext, err := encodeImage(img)
+	if err != nil {
+		return "", fmt.Errorf("encode image: %w", err)
+	}
+
+	key := name + ext
+	if err := i.bs.Put(ctx, key, content, contentType); err != nil {
+		return "", err
+	}
+
+	return key, nil
+}
+
+// Lê apenas o cabeçalho, MaxPixels também protege contra bombas de descompressão
+func validateImage(content []byte) (string, error) {
+	if int64(len(content)) > config.Env.Images.MaxFileSize {
+		return "", models.ErrImageTooLarge
+	}
+
+	contentType := http.DetectContentType(content)
+	if !allowedImageTypes[contentType] {
+		return "", models.ErrUnsupportedImageType
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(content))
+	if err != nil || cfg.Width <= 0 || cfg.Height <= 0 {
+		return "", models.ErrInvalidImage
+	}
+
+	if cfg.Width*cfg.Height > config.Env.Images.MaxPixels {
+		return "", models.ErrImageTooLarge
+	}
+
+	return contentType, nil
+}
+
+func encodeImage(img image.Image) ([]byte, string, string, error) {
+	var buf bytes.Buffer
+
+	switch config.Env.Images.Format {
+	case ImageFormatWebP:
+		if err := webp.Encode(&buf, img, &webp.Options{Quality: float32(config.Env.Images.Quality)}); err != nil {
+			return nil, "", "", err
+		}
+		return buf.Bytes(), "image/webp", ".webp", nil
+	case ImageFormatJPEG:
+		flat := image.NewRGBA(img.Bounds())
+		draw.Draw(flat, flat.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+		draw.Draw(flat, flat.Bounds(), img, img.Bounds().Min, draw.Over)
+
+		if err := jpeg.Encode(&buf, flat, &jpeg.Options{Quality: config.Env.Images.Quality}); err != nil {
+			return nil, "", "", err
+		}
+		return buf.Bytes(), "image/jpeg", ".jpg", nil
+	default:
+		return nil, "", "", fmt.Errorf("unsupported image format: %s", config.Env.Images.Format)
+	}
 }
@@ -0,0 +1,53 @@
+package services
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/g-villarinho/flash-buy-api/config"
+)
+
+func TestEncodeImageWebPHonoursQuality(t *testing.T) {
+	original := config.Env
+	t.Cleanup(func() { config.Env = original })
+
+	img := image.NewRGBA(image.Rect(0, 0, 256, 256))
+	for y := 0; y < 256; y++ {
+		for x := 0; x < 256; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: uint8(x ^ y), A: 255})
+		}
+	}
+
+	config.Env.Images.Format = ImageFormatWebP
+	sizes := map[int]int{}
+	for _, quality := range []int{20, 95} {
+		config.Env.Images.Quality = quality
+
+		content, contentType, ext, err := encodeImage(img)
+		if err != nil {
+			t.Fatalf("encodeImage() error = %v", err)
+		}
+
+		if contentType != "image/webp" || ext != ".webp" || string(content[8:12]) != "WEBP" {
+			t.Fatalf("encodeImage() = %s %s, want webp", contentType, ext)
+		}
+		sizes[quality] = len(content)
+	}
+
+	if sizes[20] >= sizes[95] {
+		t.Fatalf("quality 20 produced %d bytes, quality 95 produced %d", sizes[20], sizes[95])
+	}
+}
+
+func TestNewImageServiceRejectsUnknownFormat(t *testing.T) {
+	original := config.Env
+	t.Cleanup(func() { config.Env = original })
+
+	config.Env.Images.Format = "avif"
+	config.Env.Images.Quality = 85
+
+	if _, err := NewImageService(nil); err == nil {
+		t.Fatal("expected an error for an unsupported image format")
+	}
+}
@@ -0,0 +1,288 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/g-villarinho/flash-buy-api/models"
+	"github.com/g-villarinho/flash-buy-api/persistence"
+	"github.com/g-villarinho/flash-buy-api/pkgs"
+	"github.com/g-villarinho/flash-buy-api/repositories"
+	"github.com/google/uuid"
+)
+
+type InventoryService interface {
+	AdjustStock(ctx context.Context, userID, storeID, productID string, payload models.AdjustStockPayload) (*models.StockAdjustmentResponse, error)
+	GetStockAdjustments(ctx context.Context, userID, storeID, productID string, pag models.Pagination) (*models.PaginatedResponse, error)
+	ReserveOrderStock(ctx context.Context, order *models.Order) error
+	ReleaseOrderStock(ctx context.Context, order *models.Order) error
+	RecordInitialStock(ctx context.Context, userID string, variants []models.ProductVariant) error
+}
+
+type inventoryService struct {
+	di  *pkgs.Di
+	ss  StoreService
+	pr  repositories.ProductRepository
+	pvr repositories.ProductVariantRepository
+	sar repositories.StockAdjustmentRepository
+	tr  persistence.Transactor
+}
+
+func NewInventoryService(di *pkgs.Di) (InventoryService, error) {
+	ss, err := pkgs.Invoke[StoreService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	pr, err := pkgs.Invoke[repositories.ProductRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	pvr, err := pkgs.Invoke[repositories.ProductVariantRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	sar, err := pkgs.Invoke[repositories.StockAdjustmentRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	tr, err := pkgs.Invoke[persistence.Transactor](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &inventoryService{
+		di:  di,
+		ss:  ss,
+		pr:  pr,
+		pvr: pvr,
+		sar: sar,
+		tr:  tr,
+	}, nil
+}
+
+func (i *inventoryService) AdjustStock(ctx context.Context, userID, storeID, productID string, payload models.AdjustStockPayload) (*models.StockAdjustmentResponse, error) {
+	if err := payload.Validate(); err != nil {
+		return nil, err
+	}
+
+	product, err := i.getStoreProduct(ctx, userID, storeID, productID, models.PermissionEditCatalog)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp models.StockAdjustmentResponse
+
+	// A soma dos ajustes sempre corresponde ao estoque atual
+	err = i.tr.WithTransaction(ctx, func(ctx context.Context) error {
+		var adjustment *models.StockAdjustment
+		var err error
+		if payload.VariantID != "" {
+			adjustment, err = i.adjustVariantStock(ctx, product, payload)
+		} else {
+			adjustment, err = i.adjustProductStock(ctx, product, payload)
+		}
+		if err != nil {
+			return err
+		}
+
+		adjustment.UserID = uuid.NullUUID{UUID: uuid.MustParse(userID), Valid: true}
+
+		resp = adjustment.ToStockAdjustmentResponse()
+
+		event, err := models.NewStoreEvent(product.StoreID, models.WebhookEventInventoryAdjusted, resp)
+		if err != nil {
+			return err
+		}
+
+		if err := i.sar.CreateStockAdjustment(ctx, adjustment, event); err != nil {
+			return fmt.Errorf("create stock adjustment: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (i *inventoryService) adjustProductStock(ctx context.Context, product *models.Product, payload models.AdjustStockPayload) (*models.StockAdjustment, error) {
+	variants, err := i.pvr.GetProductVariantsByProductID(ctx, product.ID.String())
+	if err != nil {
+		return nil, fmt.Errorf("get product variants: %w", err)
+	}
+
+	if len(variants) > 0 {
+		return nil, models.ErrProductVariantRequired
+	}
+
+	ok, err := i.pr.AdjustStock(ctx, product, payload.Delta)
+	if err != nil {
+		return nil, fmt.Errorf("adjust stock of product %s: %w", product.ID, err)
+	}
+
+	if !ok {
+		return nil, models.ErrInsufficientStock
+	}
+
+	return models.NewStockAdjustment(product, models.StockAdjustmentManual, payload.Delta, payload.Reason), nil
+}
+
+func (i *inventoryService) adjustVariantStock(ctx context.Context, product *models.Product, payload models.AdjustStockPayload) (*models.StockAdjustment, error) {
+	if _, err := uuid.Parse(payload.VariantID); err != nil {
+		return nil, models.ErrProductVariantNotFound
+	}
+
+	variant, err := i.pvr.GetProductVariantByID(ctx, payload.VariantID)
+	if err != nil {
+		return nil, fmt.Errorf("get product variant by id %s: %w", payload.VariantID, err)
+	}
+
+	if variant == nil || variant.ProductID != product.ID {
+		return nil, models.ErrProductVariantNotFound
+	}
+
+	ok, err := i.pvr.AdjustStock(ctx, variant, payload.Delta)
+	if err != nil {
+		return nil, fmt.Errorf("adjust stock of variant %s: %w", variant.ID, err)
+	}
+
+	if !ok {
+		return nil, models.ErrInsufficientStock
+	}
+
+	return models.NewVariantStockAdjustment(variant, models.StockAdjustmentManual, payload.Delta, payload.Reason), nil
+}
+
+func (i *inventoryService) GetStockAdjustments(ctx context.Context, userID, storeID, productID string, pag models.Pagination) (*models.PaginatedResponse, error) {
+	if _, err := i.getStoreProduct(ctx, userID, storeID, productID, models.PermissionViewStore); err != nil {
+		return nil, err
+	}
+
+	result, err := i.sar.GetStockAdjustmentsPagedList(ctx, productID, pag)
+	if err != nil {
+		return nil, fmt.Errorf("get stock adjustments paged list: %w", err)
+	}
+
+	adjustments := *result.Data.(*[]models.StockAdjustment)
+	responses := make([]models.StockAdjustmentResponse, len(adjustments))
+	for idx, adjustment := range adjustments {
+		responses[idx] = adjustment.ToStockAdjustmentResponse()
+	}
+
+	return &models.PaginatedResponse{
+		Data:       responses,
+		Total:      result.Total,
+		TotalPages: result.TotalPages,
+		Page:       result.Page,
+		Limit:      result.Limit,
+	}, nil
+}
+
+func (i *inventoryService) ReserveOrderStock(ctx context.Context, order *models.Order) error {
+	return i.tr.WithTransaction(ctx, func(ctx context.Context) error {
+		for _, item := range order.Items {
+			adjustment, ok, err := i.adjustItemStock(ctx, order, item, models.StockAdjustmentSale, -int64(item.Quantity))
+			if err != nil {
+				return fmt.Errorf("decrement stock of product %s: %w", item.ProductID, err)
+			}
+
+			if !ok {
+				return models.ErrInsufficientStock
+			}
+
+			if err := i.sar.CreateStockAdjustment(ctx, adjustment); err != nil {
+				return fmt.Errorf("create stock adjustment: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// Deve rodar na mesma transação que cancela o pedido
+func (i *inventoryService) ReleaseOrderStock(ctx context.Context, order *models.Order) error {
+	return i.tr.WithTransaction(ctx, func(ctx context.Context) error {
+		for _, item := range order.Items {
+			adjustment, _, err := i.adjustItemStock(ctx, order, item, models.StockAdjustmentCancel, int64(item.Quantity))
+			if err != nil {
+				return fmt.Errorf("release stock of product %s: %w", item.ProductID, err)
+			}
+
+			if err := i.sar.CreateStockAdjustment(ctx, adjustment); err != nil {
+				return fmt.Errorf("create stock adjustment: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// Deve rodar na mesma transação que cria as variantes
+func (i *inventoryService) RecordInitialStock(ctx context.Context, userID string, variants []models.ProductVariant) error {
+	for idx := range variants {
+		if variants[idx].Stock == 0 {
+			continue
+		}
+
+		adjustment := models.NewVariantStockAdjustment(&variants[idx], models.StockAdjustmentInitial, variants[idx].Stock, "initial stock")
+		adjustment.UserID = uuid.NullUUID{UUID: uuid.MustParse(userID), Valid: true}
+
+		if err := i.sar.CreateStockAdjustment(ctx, adjustment); err != nil {
+			return fmt.Errorf("create stock adjustment: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (i *inventoryService) adjustItemStock(ctx context.Context, order *models.Order, item models.OrderItem, adjustmentType models.StockAdjustmentType, delta int64) (*models.StockAdjustment, bool, error) {
+	reason := fmt.Sprintf("order %s", order.ID)
+
+	var adjustment *models.StockAdjustment
+	if item.VariantID.Valid {
+		variant := &models.ProductVariant{ID: item.VariantID.UUID, ProductID: item.ProductID, StoreID: order.StoreID}
+
+		ok, err := i.pvr.AdjustStock(ctx, variant, delta)
+		if err != nil || !ok {
+			return nil, ok, err
+		}
+
+		adjustment = models.NewVariantStockAdjustment(variant, adjustmentType, delta, reason)
+	} else {
+		product := &models.Product{ID: item.ProductID, StoreID: order.StoreID}
+
+		ok, err := i.pr.AdjustStock(ctx, product, delta)
+		if err != nil || !ok {
+			return nil, ok, err
+		}
+
+		adjustment = models.NewStockAdjustment(product, adjustmentType, delta, reason)
+	}
+
+	adjustment.OrderID = uuid.NullUUID{UUID: order.ID, Valid: true}
+
+	return adjustment, true, nil
+}
+
+func (i *inventoryService) getStoreProduct(ctx context.Context, userID, storeID, productID string, permission models.StorePermission) (*models.Product, error) {
+	if _, err := i.ss.CheckPermission(ctx, storeID, userID, permission); err != nil {
+		return nil, err
+	}
+
+	product, err := i.pr.GetProductByID(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("get product by id %s: %w", productID, err)
+	}
+
+	if product == nil || product.StoreID.String() != storeID {
+		return nil, models.ErrProductNotFound
+	}
+
+	return product, nil
+}
@@ -0,0 +1,334 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/g-villarinho/flash-buy-api/config"
+	"github.com/g-villarinho/flash-buy-api/models"
+	"github.com/g-villarinho/flash-buy-api/pkgs"
+	"github.com/g-villarinho/flash-buy-api/repositories"
+)
+
+const (
+	jobBaseBackoff = 10 * time.Second
+	jobMaxBackoff  = time.Hour
+
+	// Cobre a gravação do resultado antes que outro worker assuma o job
+	jobLeaseMargin = 30 * time.Second
+
+	jobMaxErrorSize = 500
+)
+
+type JobHandler func(ctx context.Context, payload json.RawMessage) error
+
+type DeadLetterHandler func(ctx context.Context, job models.Job) error
+
+// Start só deve ser chamado depois que todos os serviços registraram seus handlers
+type JobQueue interface {
+	Enqueue(ctx context.Context, jobType models.JobType, payload any) error
+	Register(jobType models.JobType, handler JobHandler)
+	OnDeadLetter(jobType models.JobType, handler DeadLetterHandler)
+	Requeue(ctx context.Context, ID string) error
+	Start()
+	Shutdown(ctx context.Context) error
+}
+
+type jobQueue struct {
+	di *pkgs.Di
+	jr repositories.JobRepository
+
+	mu          sync.RWMutex
+	handlers    map[models.JobType]JobHandler
+	deadLetters map[models.JobType]DeadLetterHandler
+
+	startOnce  sync.Once
+	stopOnce   sync.Once
+	started    chan struct{}
+	stop       chan struct{}
+	stopped    chan struct{}
+	inFlight   sync.WaitGroup
+	workCtx    context.Context
+	cancelWork context.CancelFunc
+}
+
+func NewJobQueue(di *pkgs.Di) (JobQueue, error) {
+	jr, err := pkgs.Invoke[repositories.JobRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	workCtx, cancelWork := context.WithCancel(context.Background())
+
+	return &jobQueue{
+		di:          di,
+		jr:          jr,
+		handlers:    make(map[models.JobType]JobHandler),
+		deadLetters: make(map[models.JobType]DeadLetterHandler),
+		started:     make(chan struct{}),
+		stop:        make(chan struct{}),
+		stopped:     make(chan struct{}),
+		workCtx:     workCtx,
+		cancelWork:  cancelWork,
+	}, nil
+}
+
+// Um payload inválido vai direto para a fila morta
+func RegisterJobHandler[T any](q JobQueue, jobType models.JobType, handle func(ctx context.Context, payload T) error) {
+	q.Register(jobType, func(ctx context.Context, raw json.RawMessage) error {
+		var payload T
+		if err := json.Unmarshal(raw, &payload); err != nil {
+			return fmt.Errorf("%w: decode payload: %v", models.ErrJobPermanent, err)
+		}
+
+		return handle(ctx, payload)
+	})
+}
+
+func (q *jobQueue) Enqueue(ctx context.Context, jobType models.JobType, payload any) error {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal job payload: %w", err)
+	}
+
+	job := models.NewJob(jobType, raw, config.Env.Jobs.MaxAttempts, time.Now())
+	if err := q.jr.CreateJob(ctx, job); err != nil {
+		return fmt.Errorf("create job: %w", err)
+	}
+
+	return nil
+}
+
+func (q *jobQueue) Register(jobType models.JobType, handler JobHandler) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.handlers[jobType] = handler
+}
+
+func (q *jobQueue) OnDeadLetter(jobType models.JobType, handler DeadLetterHandler) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.deadLetters[jobType] = handler
+}
+
+func (q *jobQueue) Requeue(ctx context.Context, ID string) error {
+	requeued, err := q.jr.RequeueDeadJob(ctx, ID, time.Now())
+	if err != nil {
+		return fmt.Errorf("requeue dead job: %w", err)
+	}
+
+	if !requeued {
+		return models.ErrJobNotFound
+	}
+
+	return nil
+}
+
+func (q *jobQueue) Start() {
+	q.startOnce.Do(func() {
+		close(q.started)
+		go q.poll()
+	})
+}
+
+// Jobs interrompidos voltam para a fila quando a reserva expira
+func (q *jobQueue) Shutdown(ctx context.Context) error {
+	q.stopOnce.Do(func() { close(q.stop) })
+
+	select {
+	case <-q.started:
+		<-q.stopped
+	default:
+	}
+
+	done := make(chan struct{})
+	go func() {
+		q.inFlight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		q.cancelWork()
+		return nil
+	case <-ctx.Done():
+		q.cancelWork()
+		return ctx.Err()
+	}
+}
+
+func (q *jobQueue) poll() {
+	defer close(q.stopped)
+
+	slots := make(chan struct{}, config.Env.Jobs.Concurrency)
+
+	ticker := time.NewTicker(config.Env.Jobs.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		free := cap(slots) - len(slots)
+
+		if free > 0 && q.claimAndRun(free, slots) == free {
+			select {
+			case <-q.stop:
+				return
+			default:
+				continue
+			}
+		}
+
+		select {
+		case <-q.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (q *jobQueue) claimAndRun(limit int, slots chan struct{}) int {
+	types := q.registeredTypes()
+	if len(types) == 0 {
+		return 0
+	}
+
+	now := time.Now()
+	jobs, err := q.jr.ClaimJobs(context.Background(), types, limit, now, now.Add(config.Env.Jobs.Timeout+jobLeaseMargin))
+	if err != nil {
+		slog.Error("claim jobs", "error", err)
+		return 0
+	}
+
+	for i := range jobs {
+		job := jobs[i]
+
+		slots <- struct{}{}
+		q.inFlight.Add(1)
+
+		go func() {
+			defer func() {
+				<-slots
+				q.inFlight.Done()
+			}()
+
+			q.run(&job)
+		}()
+	}
+
+	return len(jobs)
+}
+
+func (q *jobQueue) run(job *models.Job) {
+	logger := slog.With(
+		"service", "job_queue",
+		"jobID", job.ID,
+		"jobType", job.Type,
+		"attempt", job.Attempts,
+	)
+
+	// Grava o resultado mesmo durante o shutdown
+	ctx := context.Background()
+
+	// Um job que derruba o worker volta pela reserva vencida sem registrar erro
+	if job.Attempts > job.MaxAttempts {
+		logger.Error("job exceeded max attempts")
+		q.deadLetter(ctx, logger, job, "max attempts exceeded")
+		return
+	}
+
+	err := q.execute(job)
+	if err == nil {
+		if err := q.jr.CompleteJob(ctx, job.ID.String(), job.Attempts); err != nil {
+			logger.Error("complete job", "error", err)
+		}
+		return
+	}
+
+	lastError := err.Error()
+	if len(lastError) > jobMaxErrorSize {
+		lastError = lastError[:jobMaxErrorSize]
+	}
+
+	if errors.Is(err, models.ErrJobPermanent) || job.Attempts >= job.MaxAttempts {
+		logger.Error("job moved to dead letter", "error", err)
+		q.deadLetter(ctx, logger, job, lastError)
+		return
+	}
+
+	backoff := jobBaseBackoff << (job.Attempts - 1)
+	if backoff > jobMaxBackoff || backoff <= 0 {
+		backoff = jobMaxBackoff
+	}
+
+	logger.Warn("job failed, retrying", "error", err, "backoff", backoff)
+	if err := q.jr.RetryJob(ctx, job.ID.String(), job.Attempts, time.Now().Add(backoff), lastError); err != nil {
+		logger.Error("retry job", "error", err)
+	}
+}
+
+func (q *jobQueue) deadLetter(ctx context.Context, logger *slog.Logger, job *models.Job, lastError string) {
+	dead, err := q.jr.DeadLetterJob(ctx, job.ID.String(), job.Attempts, lastError)
+	if err != nil {
+		logger.Error("dead letter job", "error", err)
+		return
+	}
+
+	if !dead {
+		logger.Warn("job lease lost before dead letter")
+		return
+	}
+
+	q.mu.RLock()
+	handler, ok := q.deadLetters[job.Type]
+	q.mu.RUnlock()
+
+	if !ok {
+		return
+	}
+
+	job.Status = models.JobStatusDead
+	job.LastError = lastError
+
+	if err := handler(ctx, *job); err != nil {
+		logger.Error("dead letter handler", "error", err)
+	}
+}
+
+func (q *jobQueue) execute(job *models.Job) (err error) {
+	q.mu.RLock()
+	handler, ok := q.handlers[job.Type]
+	q.mu.RUnlock()
+
+	if !ok {
+		return fmt.Errorf("%w: no handler registered", models.ErrJobPermanent)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panic: %v", r)
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(q.workCtx, config.Env.Jobs.Timeout)
+	defer cancel()
+
+	return handler(ctx, job.Payload)
+}
+
+func (q *jobQueue) registeredTypes() []models.JobType {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	types := make([]models.JobType, 0, len(q.handlers))
+	for jobType := range q.handlers {
+		types = append(types, jobType)
+	}
+
+	return types
+}
@@ -0,0 +1,230 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/g-villarinho/flash-buy-api/config"
+	"github.com/g-villarinho/flash-buy-api/models"
+	"github.com/g-villarinho/flash-buy-api/persistence"
+	"github.com/g-villarinho/flash-buy-api/pkgs"
+	"github.com/g-villarinho/flash-buy-api/repositories"
+)
+
+type OrderService interface {
+	GetOrdersPagedList(ctx context.Context, userID, storeID string, pag models.OrderPagination) (*models.PaginatedResponse, error)
+	GetOrderByID(ctx context.Context, userID, storeID, orderID string) (*models.OrderResponse, error)
+	UpdateOrderStatus(ctx context.Context, userID, storeID, orderID string, status models.OrderStatus) error
+	ExpirePendingOrders(ctx context.Context) (int, error)
+}
+
+type orderService struct {
+	di *pkgs.Di
+	ss StoreService
+	is InventoryService
+	fs FlashSaleService
+	or repositories.OrderRepository
+	tr persistence.Transactor
+}
+
+func NewOrderService(di *pkgs.Di) (OrderService, error) {
+	ss, err := pkgs.Invoke[StoreService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	is, err := pkgs.Invoke[InventoryService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	fs, err := pkgs.Invoke[FlashSaleService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	or, err := pkgs.Invoke[repositories.OrderRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	tr, err := pkgs.Invoke[persistence.Transactor](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &orderService{
+		di: di,
+		ss: ss,
+		is: is,
+		fs: fs,
+		or: or,
+		tr: tr,
+	}, nil
+}
+
+func (o *orderService) GetOrdersPagedList(ctx context.Context, userID, storeID string, pag models.OrderPagination) (*models.PaginatedResponse, error) {
+	if _, err := o.ss.CheckPermission(ctx, storeID, userID, models.PermissionViewStore); err != nil {
+		return nil, err
+	}
+
+	if pag.Status != nil && !models.OrderStatus(*pag.Status).IsValid() {
+		return nil, models.ErrInvalidOrderStatus
+	}
+
+	result, err := o.or.GetOrdersPagedList(ctx, storeID, pag)
+	if err != nil {
+		return nil, fmt.Errorf("get orders paged list: %w", err)
+	}
+
+	return &models.PaginatedResponse{
+		Data:       toOrderResponseList(*result.Data.(*[]models.Order)),
+		Total:      result.Total,
+		TotalPages: result.TotalPages,
+		Page:       result.Page,
+		Limit:      result.Limit,
+	}, nil
+}
+
+func (o *orderService) GetOrderByID(ctx context.Context, userID, storeID, orderID string) (*models.OrderResponse, error) {
+	order, err := o.getStoreOrder(ctx, userID, storeID, orderID, models.PermissionViewStore)
+	if err != nil {
+		return nil, err
+	}
+
+	return order.ToOrderResponse(), nil
+}
+
+func (o *orderService) UpdateOrderStatus(ctx context.Context, userID, storeID, orderID string, status models.OrderStatus) error {
+	if status.IsPaymentManaged() {
+		return models.ErrStatusManagedByPayment
+	}
+
+	order, err := o.getStoreOrder(ctx, userID, storeID, orderID, models.PermissionManageOrders)
+	if err != nil {
+		return err
+	}
+
+	from := order.Status
+	if err := order.TransitionTo(status); err != nil {
+		return err
+	}
+
+	event, err := models.NewStoreEvent(order.StoreID, models.WebhookEventOrderStatusChanged, order.ToOrderResponse())
+	if err != nil {
+		return err
+	}
+
+	return o.tr.WithTransaction(ctx, func(ctx context.Context) error {
+		ok, err := o.or.UpdateOrderStatus(ctx, order, from, event)
+		if err != nil {
+			return fmt.Errorf("update order status: %w", err)
+		}
+
+		if !ok {
+			return models.ErrInvalidStatusTransition
+		}
+
+		if status != models.OrderStatusCancelled {
+			return nil
+		}
+
+		if err := o.is.ReleaseOrderStock(ctx, order); err != nil {
+			return err
+		}
+
+		return o.fs.ReleaseOrderFlashSales(ctx, order)
+	})
+}
+
+func (o *orderService) ExpirePendingOrders(ctx context.Context) (int, error) {
+	before := time.Now().Add(-config.Env.Orders.PendingTTL)
+
+	orders, err := o.or.GetPendingOrdersCreatedBefore(ctx, before, config.Env.Orders.BatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("get pending orders created before %s: %w", before, err)
+	}
+
+	expired := 0
+	for i := range orders {
+		ok, err := o.expireOrder(ctx, &orders[i])
+		if err != nil {
+			slog.Error("expire pending order", "orderID", orders[i].ID, "error", err)
+			continue
+		}
+
+		if ok {
+			expired++
+		}
+	}
+
+	return expired, nil
+}
+
+// Um pagamento confirmado no meio do caminho vence a expiração
+func (o *orderService) expireOrder(ctx context.Context, order *models.Order) (bool, error) {
+	from := order.Status
+	if err := order.TransitionTo(models.OrderStatusCancelled); err != nil {
+		return false, err
+	}
+
+	event, err := models.NewStoreEvent(order.StoreID, models.WebhookEventOrderStatusChanged, order.ToOrderResponse())
+	if err != nil {
+		return false, err
+	}
+
+	expired := false
+	err = o.tr.WithTransaction(ctx, func(ctx context.Context) error {
+		ok, err := o.or.UpdateOrderStatus(ctx, order, from, event)
+		if err != nil {
+			return fmt.Errorf("update order status: %w", err)
+		}
+
+		if !ok {
+			return nil
+		}
+
+		if err := o.is.ReleaseOrderStock(ctx, order); err != nil {
+			return err
+		}
+
+		if err := o.fs.ReleaseOrderFlashSales(ctx, order); err != nil {
+			return err
+		}
+
+		expired = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return expired, nil
+}
+
+func (o *orderService) getStoreOrder(ctx context.Context, userID, storeID, orderID string, permission models.StorePermission) (*models.Order, error) {
+	if _, err := o.ss.CheckPermission(ctx, storeID, userID, permission); err != nil {
+		return nil, err
+	}
+
+	order, err := o.or.GetOrderByID(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("get order by id %s: %w", orderID, err)
+	}
+
+	if order == nil || order.StoreID.String() != storeID {
+		return nil, models.ErrOrderNotFound
+	}
+
+	return order, nil
+}
+
+func toOrderResponseList(orders []models.Order) []models.OrderResponse {
+	responses := make([]models.OrderResponse, len(orders))
+	for i, order := range orders {
+		responses[i] = *order.ToOrderResponse()
+	}
+	return responses
+}
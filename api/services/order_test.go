@@ -0,0 +1,271 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/g-villarinho/flash-buy-api/config"
+	"github.com/g-villarinho/flash-buy-api/models"
+	"github.com/g-villarinho/flash-buy-api/repositories"
+	"github.com/google/uuid"
+)
+
+// beforeUpdate simula uma requisição concorrente entre a leitura e a escrita
+type fakeOrderRepository struct {
+	repositories.OrderRepository
+	orders       map[uuid.UUID]models.Order
+	events       int
+	beforeUpdate func(orders map[uuid.UUID]models.Order)
+}
+
+func (f *fakeOrderRepository) GetOrderByID(ctx context.Context, ID string) (*models.Order, error) {
+	order, ok := f.orders[uuid.MustParse(ID)]
+	if !ok {
+		return nil, nil
+	}
+
+	return &order, nil
+}
+
+func (f *fakeOrderRepository) UpdateOrderStatus(ctx context.Context, order *models.Order, from models.OrderStatus, events ...*models.DomainEvent) (bool, error) {
+	if f.beforeUpdate != nil {
+		f.beforeUpdate(f.orders)
+	}
+
+	stored, ok := f.orders[order.ID]
+	if !ok || stored.Status != from {
+		return false, nil
+	}
+
+	stored.Status = order.Status
+	f.orders[order.ID] = stored
+	f.events += len(events)
+
+	return true, nil
+}
+
+func (f *fakeOrderRepository) GetPendingOrdersCreatedBefore(ctx context.Context, before time.Time, limit int) ([]models.Order, error) {
+	var orders []models.Order
+	for _, order := range f.orders {
+		if order.Status == models.OrderStatusPending && order.CreatedAt.Before(before) && len(orders) < limit {
+			orders = append(orders, order)
+		}
+	}
+	return orders, nil
+}
+
+type fakeStoreService struct {
+	StoreService
+	err error
+}
+
+func (f *fakeStoreService) CheckPermission(ctx context.Context, storeID, userID string, permission models.StorePermission) (*models.StoreResponse, error) {
+	return nil, f.err
+}
+
+type fakeInventoryService struct {
+	InventoryService
+	released []uuid.UUID
+}
+
+func (f *fakeInventoryService) ReleaseOrderStock(ctx context.Context, order *models.Order) error {
+	f.released = append(f.released, order.ID)
+	return nil
+}
+
+type fakeFlashSaleService struct {
+	FlashSaleService
+	released []uuid.UUID
+}
+
+func (f *fakeFlashSaleService) ReleaseOrderFlashSales(ctx context.Context, order *models.Order) error {
+	f.released = append(f.released, order.ID)
+	return nil
+}
+
+type orderFixture struct {
+	service   *orderService
+	orders    *fakeOrderRepository
+	inventory *fakeInventoryService
+	flashSale *fakeFlashSaleService
+	storeID   uuid.UUID
+}
+
+func newOrderFixture() *orderFixture {
+	orders := &fakeOrderRepository{orders: make(map[uuid.UUID]models.Order)}
+	inventory := &fakeInventoryService{}
+	flashSale := &fakeFlashSaleService{}
+
+	return &orderFixture{
+		service: &orderService{
+			ss: &fakeStoreService{},
+			is: inventory,
+			fs: flashSale,
+			or: orders,
+			tr: fakeTransactor{},
+		},
+		orders:    orders,
+		inventory: inventory,
+		flashSale: flashSale,
+		storeID:   uuid.New(),
+	}
+}
+
+func (f *orderFixture) addOrder(status models.OrderStatus, createdAt time.Time) uuid.UUID {
+	order := models.Order{ID: uuid.New(), Status: status, StoreID: f.storeID, CreatedAt: createdAt}
+	f.orders.orders[order.ID] = order
+	return order.ID
+}
+
+func (f *orderFixture) updateStatus(orderID uuid.UUID, status models.OrderStatus) error {
+	return f.service.UpdateOrderStatus(context.Background(), uuid.NewString(), f.storeID.String(), orderID.String(), status)
+}
+
+func TestUpdateOrderStatusTransitions(t *testing.T) {
+	tests := []struct {
+		from models.OrderStatus
+		to   models.OrderStatus
+		want error
+	}{
+		{from: models.OrderStatusPending, to: models.OrderStatusCancelled},
+		{from: models.OrderStatusPaid, to: models.OrderStatusFulfilled},
+		{from: models.OrderStatusFulfilled, to: models.OrderStatusDelivered},
+		{from: models.OrderStatusPending, to: models.OrderStatusFulfilled, want: models.ErrInvalidStatusTransition},
+		{from: models.OrderStatusPaid, to: models.OrderStatusCancelled, want: models.ErrInvalidStatusTransition},
+		{from: models.OrderStatusDelivered, to: models.OrderStatusFulfilled, want: models.ErrInvalidStatusTransition},
+		{from: models.OrderStatusCancelled, to: models.OrderStatusPending, want: models.ErrInvalidStatusTransition},
+		{from: models.OrderStatusPending, to: models.OrderStatusPaid, want: models.ErrStatusManagedByPayment},
+		{from: models.OrderStatusDelivered, to: models.OrderStatusRefunded, want: models.ErrStatusManagedByPayment},
+		{from: models.OrderStatusPending, to: models.OrderStatus("shipped"), want: models.ErrInvalidOrderStatus},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
+			f := newOrderFixture()
+			orderID := f.addOrder(tt.from, time.Now())
+
+			if err := f.updateStatus(orderID, tt.to); err != tt.want {
+				t.Fatalf("UpdateOrderStatus() = %v, want %v", err, tt.want)
+			}
+
+			want := tt.from
+			if tt.want == nil {
+				want = tt.to
+			}
+
+			if got := f.orders.orders[orderID].Status; got != want {
+				t.Errorf("stored status = %s, want %s", got, want)
+			}
+		})
+	}
+}
+
+func TestUpdateOrderStatusCancelReleasesUnits(t *testing.T) {
+	f := newOrderFixture()
+	cancelled := f.addOrder(models.OrderStatusPending, time.Now())
+	fulfilled := f.addOrder(models.OrderStatusPaid, time.Now())
+
+	if err := f.updateStatus(cancelled, models.OrderStatusCancelled); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := f.updateStatus(fulfilled, models.OrderStatusFulfilled); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(f.inventory.released) != 1 || f.inventory.released[0] != cancelled {
+		t.Errorf("released stock = %v, want only %s", f.inventory.released, cancelled)
+	}
+
+	if len(f.flashSale.released) != 1 || f.flashSale.released[0] != cancelled {
+		t.Errorf("released flash sales = %v, want only %s", f.flashSale.released, cancelled)
+	}
+
+	if f.orders.events != 2 {
+		t.Errorf("events = %d, want 2", f.orders.events)
+	}
+}
+
+func TestUpdateOrderStatusConcurrentChange(t *testing.T) {
+	f := newOrderFixture()
+	orderID := f.addOrder(models.OrderStatusPending, time.Now())
+
+	// o pagamento é confirmado entre a leitura e a escrita do cancelamento
+	f.orders.beforeUpdate = func(orders map[uuid.UUID]models.Order) {
+		order := orders[orderID]
+		order.Status = models.OrderStatusPaid
+		orders[orderID] = order
+	}
+
+	if err := f.updateStatus(orderID, models.OrderStatusCancelled); err != models.ErrInvalidStatusTransition {
+		t.Fatalf("UpdateOrderStatus() = %v, want %v", err, models.ErrInvalidStatusTransition)
+	}
+
+	if got := f.orders.orders[orderID].Status; got != models.OrderStatusPaid {
+		t.Errorf("stored status = %s, want %s", got, models.OrderStatusPaid)
+	}
+
+	if len(f.inventory.released) != 0 || len(f.flashSale.released) != 0 {
+		t.Errorf("released units of an order that was not cancelled")
+	}
+}
+
+func TestUpdateOrderStatusOfAnotherStore(t *testing.T) {
+	f := newOrderFixture()
+	orderID := f.addOrder(models.OrderStatusPending, time.Now())
+
+	err := f.service.UpdateOrderStatus(context.Background(), uuid.NewString(), uuid.NewString(), orderID.String(), models.OrderStatusCancelled)
+	if err != models.ErrOrderNotFound {
+		t.Fatalf("UpdateOrderStatus() = %v, want %v", err, models.ErrOrderNotFound)
+	}
+}
+
+func TestExpirePendingOrders(t *testing.T) {
+	previous := config.Env.Orders
+	t.Cleanup(func() { config.Env.Orders = previous })
+	config.Env.Orders.PendingTTL = 30 * time.Minute
+	config.Env.Orders.BatchSize = 10
+
+	f := newOrderFixture()
+	stale := f.addOrder(models.OrderStatusPending, time.Now().Add(-time.Hour))
+	paidMeanwhile := f.addOrder(models.OrderStatusPending, time.Now().Add(-time.Hour))
+	recent := f.addOrder(models.OrderStatusPending, time.Now())
+	paid := f.addOrder(models.OrderStatusPaid, time.Now().Add(-time.Hour))
+
+	// o webhook de pagamento vence a expiração de um dos pedidos
+	f.orders.beforeUpdate = func(orders map[uuid.UUID]models.Order) {
+		order := orders[paidMeanwhile]
+		order.Status = models.OrderStatusPaid
+		orders[paidMeanwhile] = order
+	}
+
+	expired, err := f.service.ExpirePendingOrders(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expired != 1 {
+		t.Errorf("ExpirePendingOrders() = %d, want 1", expired)
+	}
+
+	want := map[uuid.UUID]models.OrderStatus{
+		stale:         models.OrderStatusCancelled,
+		paidMeanwhile: models.OrderStatusPaid,
+		recent:        models.OrderStatusPending,
+		paid:          models.OrderStatusPaid,
+	}
+	for id, status := range want {
+		if got := f.orders.orders[id].Status; got != status {
+			t.Errorf("order %s status = %s, want %s", id, got, status)
+		}
+	}
+
+	if len(f.inventory.released) != 1 || f.inventory.released[0] != stale {
+		t.Errorf("released stock = %v, want only %s", f.inventory.released, stale)
+	}
+
+	if len(f.flashSale.released) != 1 || f.flashSale.released[0] != stale {
+		t.Errorf("released flash sales = %v, want only %s", f.flashSale.released, stale)
+	}
+}
@@ -3,12 +3,15 @@ package services
 import (
 	"context"
 	"crypto/rand"
+	"crypto/subtle"
 	"fmt"
+	"log/slog"
 	"math/big"
 	"strings"
 	"time"
 
 	"github.com/g-villarinho/flash-buy-api/models"
+	"github.com/g-villarinho/flash-buy-api/persistence"
 	"github.com/g-villarinho/flash-buy-api/pkgs"
 	"github.com/g-villarinho/flash-buy-api/repositories"
 )
@@ -17,17 +20,25 @@ const (
 	alphanumericCharset = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
 	alphanumericLength  = 6
 	otpExpiration       = 5 * 60 // 5 minutes
+
+	// No máximo 50 palpites diários contra 32^6 combinações
+	otpMaxFailedAttempts = 5
+	otpResendCooldown    = time.Minute
+	otpDailySendLimit    = 10
 )
 
 type OTPService interface {
-	GeneratOTP(ctx context.Context, userID string, flow models.OTPFlow, token string) (string, error)
-	VerifyOTP(ctx context.Context, code, verificationToken string) error
-	UpdateCode(ctx context.Context, verificationToken string) (string, error)
+	GeneratOTP(ctx context.Context, userID string, flow models.OTPFlow, token string, ssi models.SessionSecurityInfo) (*models.OTP, error)
+	VerifyOTP(ctx context.Context, code, verificationToken string, ssi models.SessionSecurityInfo) error
+	VerifyMagicLink(ctx context.Context, otpID, code string, ssi models.SessionSecurityInfo) (*models.OTP, error)
+	UpdateCode(ctx context.Context, verificationToken string, ssi models.SessionSecurityInfo) (*models.OTP, error)
 }
 
 type otpService struct {
-	di *pkgs.Di
-	or repositories.OTPRepository
+	di  *pkgs.Di
+	or  repositories.OTPRepository
+	oar repositories.OTPAttemptRepository
+	tr  persistence.Transactor
 }
 
 func NewOTPService(di *pkgs.Di) (OTPService, error) {
@@ -35,32 +46,51 @@ func NewOTPService(di *pkgs.Di) (OTPService, error) {
 	if err != nil {
 		return nil, fmt.Errorf("invoke otp repository: %w", err)
 	}
+
+	oar, err := pkgs.Invoke[repositories.OTPAttemptRepository](di)
+	if err != nil {
+		return nil, fmt.Errorf("invoke otp attempt repository: %w", err)
+	}
+
+	tr, err := pkgs.Invoke[persistence.Transactor](di)
+	if err != nil {
+		return nil, fmt.Errorf("invoke transactor: %w", err)
+	}
+
 	return &otpService{
-		di: di,
-		or: or,
+		di:  di,
+		or:  or,
+		oar: oar,
+		tr:  tr,
 	}, nil
 }
 
-func (o *otpService) GeneratOTP(ctx context.Context, userID string, flow models.OTPFlow, token string) (string, error) {
+func (o *otpService) GeneratOTP(ctx context.Context, userID string, flow models.OTPFlow, token string, ssi models.SessionSecurityInfo) (*models.OTP, error) {
+	if err := o.checkDailySendLimit(ctx, userID); err != nil {
+		return nil, err
+	}
+
 	code, err := generateCode()
 	if err != nil {
-		return "", fmt.Errorf("generate otp code: %w", err)
+		return nil, fmt.Errorf("generate otp code: %w", err)
 	}
 	expirateAt := time.Now().Add(time.Duration(otpExpiration) * time.Second)
 
 	otp, err := models.NewOTP(userID, code, flow, expirateAt, token)
 	if err != nil {
-		return "", fmt.Errorf("new otp: %w", err)
+		return nil, fmt.Errorf("new otp: %w", err)
 	}
 
 	if err := o.or.CreateOTP(ctx, *otp); err != nil {
-		return "", fmt.Errorf("create otp code: %w", err)
+		return nil, fmt.Errorf("create otp code: %w", err)
 	}
 
-	return code, nil
+	o.recordAttempt(ctx, otp, models.OTPAttemptSent, ssi)
+
+	return otp, nil
 }
 
-func (o *otpService) VerifyOTP(ctx context.Context, code, token string) error {
+func (o *otpService) VerifyOTP(ctx context.Context, code, token string, ssi models.SessionSecurityInfo) error {
 	codeLower := strings.ToUpper(code)
 
 	otp, err := o.or.GetOTPByVerificationToken(ctx, token)
@@ -72,46 +102,133 @@ func (o *otpService) VerifyOTP(ctx context.Context, code, token string) error {
 		return models.ErrOTPNotFound
 	}
 
+	return o.consume(ctx, otp, codeLower, ssi)
+}
+
+func (o *otpService) VerifyMagicLink(ctx context.Context, otpID, code string, ssi models.SessionSecurityInfo) (*models.OTP, error) {
+	otp, err := o.or.GetOTPByID(ctx, otpID)
+	if err != nil {
+		return nil, fmt.Errorf("get otp by id %s: %w", otpID, err)
+	}
+
+	if otp == nil || otp.Flow != models.MagicLinkFlow {
+		return nil, models.ErrOTPNotFound
+	}
+
+	if err := o.consume(ctx, otp, code, ssi); err != nil {
+		return nil, err
+	}
+
+	return otp, nil
+}
+
+func (o *otpService) consume(ctx context.Context, otp *models.OTP, code string, ssi models.SessionSecurityInfo) error {
 	if otp.IsExpired() {
 		return models.ErrOTPExpired
 	}
 
-	if otp.Code != codeLower {
-		return models.ErrOTPInvalid
+	if otp.IsLocked(otpMaxFailedAttempts) {
+		o.recordAttempt(ctx, otp, models.OTPAttemptLocked, ssi)
+		return models.ErrOTPLocked
 	}
 
-	if err := o.or.DeleteOTP(ctx, otp.ID.String()); err != nil {
+	if subtle.ConstantTimeCompare([]byte(otp.Code), []byte(code)) != 1 {
+		return o.registerFailure(ctx, otp, ssi)
+	}
+
+	consumed, err := o.or.ConsumeOTP(ctx, otp.ID.String())
+	if err != nil {
 		return fmt.Errorf("delete otp: %w", err)
 	}
 
+	if !consumed {
+		return models.ErrOTPNotFound
+	}
+
+	o.recordAttempt(ctx, otp, models.OTPAttemptVerified, ssi)
+
 	return nil
 }
 
-func (o *otpService) UpdateCode(ctx context.Context, verificationToken string) (string, error) {
+func (o *otpService) UpdateCode(ctx context.Context, verificationToken string, ssi models.SessionSecurityInfo) (*models.OTP, error) {
+	otp, err := o.or.GetOTPByVerificationToken(ctx, verificationToken)
+	if err != nil {
+		return nil, fmt.Errorf("get otp by verification token: %w", err)
+	}
+
+	if otp == nil {
+		return nil, models.ErrOTPNotFound
+	}
+
+	if err := o.checkDailySendLimit(ctx, otp.UserID.String()); err != nil {
+		o.recordAttempt(ctx, otp, models.OTPAttemptResendThrottle, ssi)
+		return nil, err
+	}
+
 	code, err := generateCode()
 	if err != nil {
-		return "", fmt.Errorf("generate otp code: %w", err)
+		return nil, fmt.Errorf("generate otp code: %w", err)
 	}
 
-	expirateAt := time.Now().Add(time.Duration(otpExpiration) * time.Second)
+	now := time.Now()
+	expirateAt := now.Add(time.Duration(otpExpiration) * time.Second)
 
-	otp, err := o.or.GetOTPByVerificationToken(ctx, verificationToken)
+	ok, err := o.or.ResendOTP(ctx, otp.ID.String(), code, expirateAt, now, now.Add(-otpResendCooldown))
 	if err != nil {
-		return "", fmt.Errorf("get otp by verification token: %w", err)
+		return nil, fmt.Errorf("update otp code: %w", err)
 	}
 
-	if otp == nil {
-		return "", models.ErrOTPNotFound
+	if !ok {
+		o.recordAttempt(ctx, otp, models.OTPAttemptResendThrottle, ssi)
+		return nil, models.ErrOTPResendCooldown
 	}
 
 	otp.Code = code
 	otp.ExpiresAt = expirateAt
+	otp.LastSentAt = now
+	otp.FailedAttempts = 0
+
+	o.recordAttempt(ctx, otp, models.OTPAttemptSent, ssi)
+
+	return otp, nil
+}
+
+func (o *otpService) registerFailure(ctx context.Context, otp *models.OTP, ssi models.SessionSecurityInfo) error {
+	attempts, err := o.or.IncrementFailedAttempts(ctx, otp.ID.String(), otpMaxFailedAttempts)
+	if err != nil {
+		return fmt.Errorf("increment otp failed attempts: %w", err)
+	}
+
+	o.recordAttempt(ctx, otp, models.OTPAttemptFailed, ssi)
+
+	if attempts == 0 || attempts >= otpMaxFailedAttempts {
+		return models.ErrOTPLocked
+	}
+
+	return models.ErrOTPInvalid
+}
 
-	if err := o.or.UpdateOTP(ctx, *otp); err != nil {
-		return "", fmt.Errorf("update otp code: %w", err)
+func (o *otpService) checkDailySendLimit(ctx context.Context, userID string) error {
+	sent, err := o.oar.CountOTPAttemptsSince(ctx, userID, models.OTPAttemptSent, time.Now().Add(-24*time.Hour))
+	if err != nil {
+		return fmt.Errorf("count otp sends: %w", err)
 	}
 
-	return code, nil
+	if sent >= otpDailySendLimit {
+		return models.ErrOTPSendLimit
+	}
+
+	return nil
+}
+
+// Em um savepoint a falha do registro não aborta a operação que o chamou
+func (o *otpService) recordAttempt(ctx context.Context, otp *models.OTP, attemptType models.OTPAttemptType, ssi models.SessionSecurityInfo) {
+	err := o.tr.WithTransaction(ctx, func(ctx context.Context) error {
+		return o.oar.CreateOTPAttempt(ctx, models.NewOTPAttempt(otp, attemptType, ssi))
+	})
+	if err != nil {
+		slog.Error("create otp attempt", "otpID", otp.ID, "type", attemptType, "error", err)
+	}
 }
 
 func generateCode() (string, error) {
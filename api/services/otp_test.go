@@ -0,0 +1,231 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/g-villarinho/flash-buy-api/models"
+	"github.com/google/uuid"
+)
+
+type fakeOTPRepository struct {
+	otps map[uuid.UUID]*models.OTP
+}
+
+func newFakeOTPRepository() *fakeOTPRepository {
+	return &fakeOTPRepository{otps: make(map[uuid.UUID]*models.OTP)}
+}
+
+func (f *fakeOTPRepository) CreateOTP(ctx context.Context, otp models.OTP) error {
+	f.otps[otp.ID] = &otp
+	return nil
+}
+
+func (f *fakeOTPRepository) GetOTPByUserIDAndFlow(ctx context.Context, userID string, flow models.OTPFlow) (*models.OTP, error) {
+	for _, otp := range f.otps {
+		if otp.UserID.String() == userID && otp.Flow == flow {
+			copied := *otp
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeOTPRepository) DeleteOTP(ctx context.Context, ID string) error {
+	delete(f.otps, uuid.MustParse(ID))
+	return nil
+}
+
+func (f *fakeOTPRepository) GetOTPByVerificationToken(ctx context.Context, token string) (*models.OTP, error) {
+	for _, otp := range f.otps {
+		if otp.VerificationToken == token {
+			copied := *otp
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeOTPRepository) UpdateOTP(ctx context.Context, otp models.OTP) error {
+	f.otps[otp.ID] = &otp
+	return nil
+}
+
+func (f *fakeOTPRepository) GetOTPByID(ctx context.Context, ID string) (*models.OTP, error) {
+	otp, ok := f.otps[uuid.MustParse(ID)]
+	if !ok {
+		return nil, nil
+	}
+
+	copied := *otp
+	return &copied, nil
+}
+
+func (f *fakeOTPRepository) ConsumeOTP(ctx context.Context, ID string) (bool, error) {
+	id := uuid.MustParse(ID)
+	if _, ok := f.otps[id]; !ok {
+		return false, nil
+	}
+
+	delete(f.otps, id)
+	return true, nil
+}
+
+func (f *fakeOTPRepository) IncrementFailedAttempts(ctx context.Context, ID string, maxAttempts int) (int, error) {
+	otp := f.otps[uuid.MustParse(ID)]
+	if otp == nil || otp.FailedAttempts >= maxAttempts {
+		return 0, nil
+	}
+
+	otp.FailedAttempts++
+	return otp.FailedAttempts, nil
+}
+
+func (f *fakeOTPRepository) ResendOTP(ctx context.Context, ID, code string, expiresAt, sentAt, cooldownCutoff time.Time) (bool, error) {
+	otp := f.otps[uuid.MustParse(ID)]
+	if otp == nil || otp.LastSentAt.After(cooldownCutoff) {
+		return false, nil
+	}
+
+	otp.Code = code
+	otp.ExpiresAt = expiresAt
+	otp.LastSentAt = sentAt
+	otp.FailedAttempts = 0
+	return true, nil
+}
+
+type fakeOTPAttemptRepository struct {
+	attempts []models.OTPAttempt
+}
+
+func (f *fakeOTPAttemptRepository) CreateOTPAttempt(ctx context.Context, attempt *models.OTPAttempt) error {
+	f.attempts = append(f.attempts, *attempt)
+	return nil
+}
+
+func (f *fakeOTPAttemptRepository) CountOTPAttemptsSince(ctx context.Context, userID string, attemptType models.OTPAttemptType, since time.Time) (int64, error) {
+	var count int64
+	for _, attempt := range f.attempts {
+		if attempt.UserID.String() == userID && attempt.Type == attemptType && !attempt.CreatedAt.Before(since) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func newTestOTPService() (*otpService, *fakeOTPRepository, *fakeOTPAttemptRepository) {
+	otps := newFakeOTPRepository()
+	attempts := &fakeOTPAttemptRepository{}
+
+	return &otpService{or: otps, oar: attempts, tr: fakeTransactor{}}, otps, attempts
+}
+
+func TestOTPLocksAfterMaxFailedAttempts(t *testing.T) {
+	ctx := context.Background()
+	service, _, _ := newTestOTPService()
+
+	otp, err := service.GeneratOTP(ctx, uuid.NewString(), models.UserVerificationFLow, "token", models.SessionSecurityInfo{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wrong := "222222"
+	if otp.Code == wrong {
+		wrong = "333333"
+	}
+
+	for i := 1; i < otpMaxFailedAttempts; i++ {
+		if err := service.VerifyOTP(ctx, wrong, "token", models.SessionSecurityInfo{}); err != models.ErrOTPInvalid {
+			t.Fatalf("VerifyOTP() attempt %d = %v, want %v", i, err, models.ErrOTPInvalid)
+		}
+	}
+
+	if err := service.VerifyOTP(ctx, wrong, "token", models.SessionSecurityInfo{}); err != models.ErrOTPLocked {
+		t.Fatalf("VerifyOTP() last attempt = %v, want %v", err, models.ErrOTPLocked)
+	}
+
+	// Depois do bloqueio nem o código certo é aceito
+	if err := service.VerifyOTP(ctx, otp.Code, "token", models.SessionSecurityInfo{}); err != models.ErrOTPLocked {
+		t.Errorf("VerifyOTP() with right code after lock = %v, want %v", err, models.ErrOTPLocked)
+	}
+}
+
+func TestOTPIsSingleUse(t *testing.T) {
+	ctx := context.Background()
+	service, _, _ := newTestOTPService()
+
+	otp, err := service.GeneratOTP(ctx, uuid.NewString(), models.UserVerificationFLow, "token", models.SessionSecurityInfo{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := service.VerifyOTP(ctx, otp.Code, "token", models.SessionSecurityInfo{}); err != nil {
+		t.Fatalf("VerifyOTP() error = %v", err)
+	}
+
+	if err := service.VerifyOTP(ctx, otp.Code, "token", models.SessionSecurityInfo{}); err != models.ErrOTPNotFound {
+		t.Errorf("VerifyOTP() twice = %v, want %v", err, models.ErrOTPNotFound)
+	}
+}
+
+func TestOTPResendCooldown(t *testing.T) {
+	ctx := context.Background()
+	service, otps, _ := newTestOTPService()
+
+	otp, err := service.GeneratOTP(ctx, uuid.NewString(), models.UserVerificationFLow, "token", models.SessionSecurityInfo{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := service.UpdateCode(ctx, "token", models.SessionSecurityInfo{}); err != models.ErrOTPResendCooldown {
+		t.Fatalf("UpdateCode() inside cooldown = %v, want %v", err, models.ErrOTPResendCooldown)
+	}
+
+	stored := otps.otps[otp.ID]
+	stored.LastSentAt = time.Now().Add(-otpResendCooldown)
+	stored.FailedAttempts = otpMaxFailedAttempts - 1
+
+	resent, err := service.UpdateCode(ctx, "token", models.SessionSecurityInfo{})
+	if err != nil {
+		t.Fatalf("UpdateCode() after cooldown = %v", err)
+	}
+
+	if resent.FailedAttempts != 0 || stored.FailedAttempts != 0 {
+		t.Errorf("UpdateCode() kept %d failed attempts, want 0", stored.FailedAttempts)
+	}
+
+	if stored.Code != resent.Code {
+		t.Errorf("stored code = %s, want %s", stored.Code, resent.Code)
+	}
+}
+
+func TestOTPDailySendLimit(t *testing.T) {
+	ctx := context.Background()
+	service, otps, _ := newTestOTPService()
+	userID := uuid.NewString()
+
+	var last *models.OTP
+	for i := 0; i < otpDailySendLimit; i++ {
+		otp, err := service.GeneratOTP(ctx, userID, models.UserVerificationFLow, uuid.NewString(), models.SessionSecurityInfo{})
+		if err != nil {
+			t.Fatalf("GeneratOTP() send %d = %v", i+1, err)
+		}
+		last = otp
+	}
+
+	if _, err := service.GeneratOTP(ctx, userID, models.UserVerificationFLow, uuid.NewString(), models.SessionSecurityInfo{}); err != models.ErrOTPSendLimit {
+		t.Fatalf("GeneratOTP() above daily limit = %v, want %v", err, models.ErrOTPSendLimit)
+	}
+
+	// O reenvio conta para o mesmo limite, mesmo fora do cooldown
+	otps.otps[last.ID].LastSentAt = time.Now().Add(-otpResendCooldown)
+	if _, err := service.UpdateCode(ctx, last.VerificationToken, models.SessionSecurityInfo{}); err != models.ErrOTPSendLimit {
+		t.Errorf("UpdateCode() above daily limit = %v, want %v", err, models.ErrOTPSendLimit)
+	}
+
+	// Outro usuário não é afetado
+	if _, err := service.GeneratOTP(ctx, uuid.NewString(), models.UserVerificationFLow, uuid.NewString(), models.SessionSecurityInfo{}); err != nil {
+		t.Errorf("GeneratOTP() for another user = %v", err)
+	}
+}
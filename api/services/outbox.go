@@ -0,0 +1,136 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/g-villarinho/flash-buy-api/config"
+	"github.com/g-villarinho/flash-buy-api/models"
+	"github.com/g-villarinho/flash-buy-api/pkgs"
+	"github.com/g-villarinho/flash-buy-api/repositories"
+)
+
+const (
+	outboxBaseBackoff  = 5 * time.Second
+	outboxMaxBackoff   = 10 * time.Minute
+	outboxMaxErrorSize = 500
+
+	// Cobre a gravação do resultado antes que outra instância reserve o evento
+	outboxLeaseMargin = 10 * time.Second
+)
+
+// Entrega pelo menos uma vez, o handler precisa tolerar repetições
+type EventHandler func(ctx context.Context, event models.DomainEvent) error
+
+type OutboxService interface {
+	Subscribe(eventType models.DomainEventType, handler EventHandler)
+	ProcessPendingEvents(ctx context.Context) (int, error)
+}
+
+type outboxService struct {
+	di  *pkgs.Di
+	obr repositories.OutboxRepository
+
+	mu          sync.RWMutex
+	subscribers map[models.DomainEventType][]EventHandler
+}
+
+func NewOutboxService(di *pkgs.Di) (OutboxService, error) {
+	obr, err := pkgs.Invoke[repositories.OutboxRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &outboxService{
+		di:          di,
+		obr:         obr,
+		subscribers: make(map[models.DomainEventType][]EventHandler),
+	}, nil
+}
+
+func (o *outboxService) Subscribe(eventType models.DomainEventType, handler EventHandler) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.subscribers[eventType] = append(o.subscribers[eventType], handler)
+}
+
+func (o *outboxService) ProcessPendingEvents(ctx context.Context) (int, error) {
+	now := time.Now()
+
+	events, err := o.obr.ClaimEvents(ctx, config.Env.Outbox.BatchSize, now, now.Add(config.Env.Outbox.Timeout+outboxLeaseMargin))
+	if err != nil {
+		return 0, fmt.Errorf("claim outbox events: %w", err)
+	}
+
+	// Em paralelo para que nenhum evento passe do prazo esperando os outros
+	var wg sync.WaitGroup
+	for i := range events {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			o.dispatch(ctx, &events[i])
+		}()
+	}
+
+	wg.Wait()
+
+	return len(events), nil
+}
+
+func (o *outboxService) dispatch(ctx context.Context, event *models.DomainEvent) {
+	logger := slog.With(
+		"service", "outbox",
+		"eventID", event.ID,
+		"eventType", event.Type,
+		"attempt", event.Attempts,
+	)
+
+	if err := o.notify(ctx, event); err != nil {
+		lastError := err.Error()
+		if len(lastError) > outboxMaxErrorSize {
+			lastError = lastError[:outboxMaxErrorSize]
+		}
+
+		backoff := outboxBaseBackoff << (event.Attempts - 1)
+		if backoff > outboxMaxBackoff || backoff <= 0 {
+			backoff = outboxMaxBackoff
+		}
+
+		logger.Warn("event dispatch failed, retrying", "error", err, "backoff", backoff)
+		if err := o.obr.RetryEvent(ctx, event.ID.String(), time.Now().Add(backoff), lastError); err != nil {
+			logger.Error("retry outbox event", "error", err)
+		}
+		return
+	}
+
+	if err := o.obr.DeleteEvent(ctx, event.ID.String()); err != nil {
+		logger.Error("delete outbox event", "error", err)
+	}
+}
+
+func (o *outboxService) notify(ctx context.Context, event *models.DomainEvent) (err error) {
+	o.mu.RLock()
+	handlers := o.subscribers[event.Type]
+	o.mu.RUnlock()
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("event handler panic: %v", r)
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(ctx, config.Env.Outbox.Timeout)
+	defer cancel()
+
+	for _, handler := range handlers {
+		if err := handler(ctx, *event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
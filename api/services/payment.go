@@ -0,0 +1,220 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/g-villarinho/flash-buy-api/clients"
+	"github.com/g-villarinho/flash-buy-api/config"
+	"github.com/g-villarinho/flash-buy-api/models"
+	"github.com/g-villarinho/flash-buy-api/pkgs"
+	"github.com/g-villarinho/flash-buy-api/repositories"
+)
+
+type PaymentService interface {
+	CreatePayment(ctx context.Context, order *models.Order) (*models.PaymentIntentResponse, error)
+	CapturePayment(ctx context.Context, userID, storeID, orderID string) error
+	RefundPayment(ctx context.Context, userID, storeID, orderID string) error
+	HandleWebhook(ctx context.Context, payload []byte, signature string) error
+	Available() bool
+}
+
+type paymentService struct {
+	di *pkgs.Di
+	ss StoreService
+	pp clients.PaymentProvider
+	or repositories.OrderRepository
+}
+
+func NewPaymentService(di *pkgs.Di) (PaymentService, error) {
+	ss, err := pkgs.Invoke[StoreService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	pp, err := pkgs.Invoke[clients.PaymentProvider](di)
+	if err != nil {
+		return nil, err
+	}
+
+	or, err := pkgs.Invoke[repositories.OrderRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &paymentService{
+		di: di,
+		ss: ss,
+		pp: pp,
+		or: or,
+	}, nil
+}
+
+func (p *paymentService) CreatePayment(ctx context.Context, order *models.Order) (*models.PaymentIntentResponse, error) {
+	intent, err := p.pp.CreatePaymentIntent(ctx, clients.PaymentIntentRequest{
+		OrderID:       order.ID.String(),
+		AmountInCents: order.TotalInCents,
+		Currency:      config.Env.Payment.Currency,
+		CustomerEmail: order.CustomerEmail,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create payment intent: %w", err)
+	}
+
+	order.PaymentID = sql.NullString{String: intent.ID, Valid: true}
+	if err := p.or.UpdateOrder(ctx, order); err != nil {
+		return nil, fmt.Errorf("update order payment: %w", err)
+	}
+
+	return &models.PaymentIntentResponse{
+		ID:           intent.ID,
+		ClientSecret: intent.ClientSecret,
+		CheckoutURL:  intent.CheckoutURL,
+	}, nil
+}
+
+func (p *paymentService) Available() bool {
+	return clients.IsPaymentAvailable(p.pp)
+}
+
+func (p *paymentService) CapturePayment(ctx context.Context, userID, storeID, orderID string) error {
+	order, err := p.getStoreOrderWithPayment(ctx, userID, storeID, orderID)
+	if err != nil {
+		return err
+	}
+
+	if order.Status != models.OrderStatusPending {
+		return models.ErrInvalidStatusTransition
+	}
+
+	if err := p.pp.CapturePayment(ctx, order.PaymentID.String); err != nil {
+		return mapPaymentProviderError(err, "capture payment")
+	}
+
+	return nil
+}
+
+// O pedido só vira refunded com o webhook assinado
+func (p *paymentService) RefundPayment(ctx context.Context, userID, storeID, orderID string) error {
+	order, err := p.getStoreOrderWithPayment(ctx, userID, storeID, orderID)
+	if err != nil {
+		return err
+	}
+
+	if !order.Status.CanTransitionTo(models.OrderStatusRefunded) {
+		return models.ErrInvalidStatusTransition
+	}
+
+	if err := p.pp.RefundPayment(ctx, order.PaymentID.String, order.TotalInCents); err != nil {
+		return mapPaymentProviderError(err, "refund payment")
+	}
+
+	return nil
+}
+
+func (p *paymentService) HandleWebhook(ctx context.Context, payload []byte, signature string) error {
+	event, err := p.pp.ParseWebhook(payload, signature)
+	if err != nil {
+		if err == clients.ErrPaymentUnavailable {
+			return models.ErrPaymentUnavailable
+		}
+		return err
+	}
+
+	logger := slog.With(
+		"service", "payment",
+		"method", "HandleWebhook",
+		"eventID", event.ID,
+		"eventType", event.Type,
+		"paymentID", event.PaymentID,
+	)
+
+	order, err := p.or.GetOrderByPaymentID(ctx, event.PaymentID)
+	if err != nil {
+		return fmt.Errorf("get order by payment id %s: %w", event.PaymentID, err)
+	}
+
+	if order == nil {
+		return models.ErrOrderNotFound
+	}
+
+	var next models.OrderStatus
+	switch event.Type {
+	case clients.PaymentEventSucceeded:
+		if event.AmountInCents != order.TotalInCents {
+			return models.ErrPaymentAmountMismatch
+		}
+		next = models.OrderStatusPaid
+	case clients.PaymentEventRefunded:
+		next = models.OrderStatusRefunded
+	case clients.PaymentEventFailed:
+		logger.Warn("payment failed", "orderID", order.ID)
+		return nil
+	default:
+		logger.Warn("unhandled payment event")
+		return nil
+	}
+
+	if order.Status == next {
+		return nil
+	}
+
+	from := order.Status
+	if err := order.TransitionTo(next); err != nil {
+		return err
+	}
+
+	statusEvent, err := models.NewStoreEvent(order.StoreID, models.WebhookEventOrderStatusChanged, order.ToOrderResponse())
+	if err != nil {
+		return err
+	}
+
+	ok, err := p.or.UpdateOrderStatus(ctx, order, from, statusEvent)
+	if err != nil {
+		return fmt.Errorf("update order status: %w", err)
+	}
+
+	if !ok {
+		logger.Info("order status changed concurrently", "orderID", order.ID)
+		return nil
+	}
+
+	return nil
+}
+
+func (p *paymentService) getStoreOrderWithPayment(ctx context.Context, userID, storeID, orderID string) (*models.Order, error) {
+	if _, err := p.ss.CheckPermission(ctx, storeID, userID, models.PermissionManageOrders); err != nil {
+		return nil, err
+	}
+
+	order, err := p.or.GetOrderByID(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("get order by id %s: %w", orderID, err)
+	}
+
+	if order == nil || order.StoreID.String() != storeID {
+		return nil, models.ErrOrderNotFound
+	}
+
+	if !order.PaymentID.Valid {
+		return nil, models.ErrOrderWithoutPayment
+	}
+
+	return order, nil
+}
+
+func mapPaymentProviderError(err error, action string) error {
+	switch {
+	case errors.Is(err, clients.ErrPaymentNotFound):
+		return models.ErrOrderWithoutPayment
+	case errors.Is(err, clients.ErrInvalidPaymentState):
+		return models.ErrInvalidStatusTransition
+	case errors.Is(err, clients.ErrPaymentUnavailable):
+		return models.ErrPaymentUnavailable
+	}
+
+	return fmt.Errorf("%s: %w", action, err)
+}
@@ -2,16 +2,24 @@ package services
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"mime/multipart"
+	"time"
 
 	"github.com/g-villarinho/flash-buy-api/models"
+	"github.com/g-villarinho/flash-buy-api/persistence"
 	"github.com/g-villarinho/flash-buy-api/pkgs"
 	"github.com/g-villarinho/flash-buy-api/repositories"
 )
 
 type ProductService interface {
 	CreateProduct(ctx context.Context, userID string, product models.Product, images []*multipart.FileHeader) error
+	GetProductsPagedList(ctx context.Context, userID, storeID string, pag models.ProductPagination) (*models.PaginatedResponse, error)
+	GetProductByID(ctx context.Context, userID, storeID, productID string) (*models.ProductResponse, error)
+	UpdateProduct(ctx context.Context, userID, storeID, productID string, payload models.UpdateProductPayload) error
+	ArchiveProduct(ctx context.Context, userID, storeID, productID string, isArchived bool) error
+	DeleteProduct(ctx context.Context, userID, storeID, productID string) error
 }
 
 type productService struct {
@@ -21,7 +29,12 @@ type productService struct {
 	crs ColorService
 	ces CategoryService
 	pis ProductImageService
+	pvs ProductVariantService
+	is  InventoryService
+	ws  WebhookService
 	pr  repositories.ProductRepository
+	pvr repositories.ProductVariantRepository
+	tr  persistence.Transactor
 }
 
 func NewProductService(di *pkgs.Di) (ProductService, error) {
@@ -50,48 +63,299 @@ func NewProductService(di *pkgs.Di) (ProductService, error) {
 		return nil, err
 	}
 
+	pvs, err := pkgs.Invoke[ProductVariantService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	is, err := pkgs.Invoke[InventoryService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	ws, err := pkgs.Invoke[WebhookService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	tr, err := pkgs.Invoke[persistence.Transactor](di)
+	if err != nil {
+		return nil, err
+	}
+
+	obs, err := pkgs.Invoke[OutboxService](di)
+	if err != nil {
+		return nil, err
+	}
+
 	pr, err := pkgs.Invoke[repositories.ProductRepository](di)
 	if err != nil {
 		return nil, err
 	}
 
-	return &productService{
+	pvr, err := pkgs.Invoke[repositories.ProductVariantRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &productService{
 		di:  di,
 		srs: srs,
 		szs: szs,
 		crs: crs,
 		ces: ces,
 		pis: pis,
+		pvs: pvs,
+		is:  is,
+		ws:  ws,
 		pr:  pr,
-	}, nil
+		pvr: pvr,
+		tr:  tr,
+	}
+
+	obs.Subscribe(models.DomainEventType(models.WebhookEventProductCreated), p.publishProduct)
+	obs.Subscribe(models.DomainEventType(models.WebhookEventProductUpdated), p.publishProduct)
+	obs.Subscribe(models.DomainEventType(models.WebhookEventProductArchived), p.publishProduct)
+
+	return p, nil
 }
 
 func (p *productService) CreateProduct(ctx context.Context, userID string, product models.Product, images []*multipart.FileHeader) error {
-	_, err := p.srs.GetStoreByStoreID(ctx, product.StoreID.String(), userID)
+	_, err := p.srs.CheckPermission(ctx, product.StoreID.String(), userID, models.PermissionEditCatalog)
+	if err != nil {
+		return err
+	}
+
+	if err := p.validateProductReferences(ctx, userID, product); err != nil {
+		return err
+	}
+
+	if err := p.pvs.PrepareProductVariants(ctx, userID, &product, product.Variants); err != nil {
+		return err
+	}
+
+	event, err := models.NewStoreEvent(product.StoreID, models.WebhookEventProductCreated, models.ProductEventData{ID: product.ID})
+	if err != nil {
+		return err
+	}
+
+	err = p.tr.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := p.pr.CreateProduct(ctx, &product, event); err != nil {
+			return fmt.Errorf("create product: %w", err)
+		}
+
+		if err := p.is.RecordInitialStock(ctx, userID, product.Variants); err != nil {
+			return err
+		}
+
+		_, err := p.pis.CreateProductImage(ctx, product.ID.String(), images)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *productService) GetProductsPagedList(ctx context.Context, userID, storeID string, pag models.ProductPagination) (*models.PaginatedResponse, error) {
+	if _, err := p.srs.CheckPermission(ctx, storeID, userID, models.PermissionViewStore); err != nil {
+		return nil, err
+	}
+
+	result, err := p.pr.GetProductsPagedList(ctx, storeID, pag)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.PaginatedResponse{
+		Data:       toProductResponseList(*result.Data.(*[]models.Product)),
+		Total:      result.Total,
+		TotalPages: result.TotalPages,
+		Page:       result.Page,
+		Limit:      result.Limit,
+	}, nil
+}
+
+func (p *productService) GetProductByID(ctx context.Context, userID, storeID, productID string) (*models.ProductResponse, error) {
+	if _, err := p.srs.CheckPermission(ctx, storeID, userID, models.PermissionViewStore); err != nil {
+		return nil, err
+	}
+
+	product, err := p.pr.GetProductDetailsByID(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("get product by id %s: %w", productID, err)
+	}
+
+	if product == nil {
+		return nil, models.ErrProductNotFound
+	}
+
+	if product.StoreID.String() != storeID {
+		return nil, models.ErrProductNotFound
+	}
+
+	return product.ToProductResponse(), nil
+}
+
+func (p *productService) UpdateProduct(ctx context.Context, userID, storeID, productID string, payload models.UpdateProductPayload) error {
+	product, err := p.getStoreProduct(ctx, userID, storeID, productID)
+	if err != nil {
+		return err
+	}
+
+	if err := payload.ApplyTo(product); err != nil {
+		return err
+	}
+
+	if product.HasOwnOptions() {
+		variants, err := p.pvr.GetProductVariantsByProductID(ctx, productID)
+		if err != nil {
+			return fmt.Errorf("get product variants: %w", err)
+		}
+
+		if len(variants) > 0 {
+			return models.ErrProductOptionsOnVariants
+		}
+	}
+
+	if err := p.validateProductReferences(ctx, userID, *product); err != nil {
+		return err
+	}
+
+	event, err := models.NewStoreEvent(product.StoreID, models.WebhookEventProductUpdated, models.ProductEventData{ID: product.ID})
+	if err != nil {
+		return err
+	}
+
+	if err := p.pr.UpdateProduct(ctx, product, event); err != nil {
+		return fmt.Errorf("update product: %w", err)
+	}
+
+	return nil
+}
+
+func (p *productService) ArchiveProduct(ctx context.Context, userID, storeID, productID string, isArchived bool) error {
+	product, err := p.getStoreProduct(ctx, userID, storeID, productID)
 	if err != nil {
 		return err
 	}
 
-	_, err = p.szs.GetSizeByID(ctx, product.SizeID.String(), product.StoreID.String())
+	product.IsArchived = isArchived
+	product.UpdatedAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+	event, err := models.NewStoreEvent(product.StoreID, models.WebhookEventProductArchived, models.ProductEventData{ID: product.ID})
 	if err != nil {
 		return err
 	}
 
-	_, err = p.crs.GetColorByID(ctx, product.StoreID.String(), product.ColorID.String())
+	if err := p.pr.UpdateProduct(ctx, product, event); err != nil {
+		return fmt.Errorf("update product: %w", err)
+	}
+
+	return nil
+}
+
+func (p *productService) DeleteProduct(ctx context.Context, userID, storeID, productID string) error {
+	product, err := p.getStoreProduct(ctx, userID, storeID, productID)
 	if err != nil {
 		return err
 	}
 
-	_, err = p.ces.GetCategoryByID(ctx, product.StoreID.String(), product.CategoryID.String())
+	event, err := models.NewStoreEvent(product.StoreID, models.WebhookEventProductDeleted, models.WebhookDeletedData{ID: product.ID})
 	if err != nil {
 		return err
 	}
 
-	if err := p.pr.CreateProduct(ctx, &product); err != nil {
-		return fmt.Errorf("create product: %w", err)
+	var productImages []models.ProductImage
+	err = p.tr.WithTransaction(ctx, func(ctx context.Context) error {
+		productImages, err = p.pis.DeleteProductImages(ctx, productID)
+		if err != nil {
+			return fmt.Errorf("delete product images: %w", err)
+		}
+
+		if err := p.pr.DeleteProduct(ctx, productID, event); err != nil {
+			return fmt.Errorf("delete product %s: %w", productID, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
-	go p.pis.CreateProductImage(ctx, product.ID.String(), images)
+	// Só depois do commit, um rollback não deixa registros sem arquivo
+	p.pis.DeleteProductImageFiles(ctx, productImages)
 
 	return nil
 }
+
+// O webhook leva o estado do produto no momento da entrega
+func (p *productService) publishProduct(ctx context.Context, event models.DomainEvent) error {
+	var data models.ProductEventData
+	if err := event.Decode(&data); err != nil {
+		return fmt.Errorf("decode product event: %w", err)
+	}
+
+	product, err := p.pr.GetProductDetailsByID(ctx, data.ID.String())
+	if err != nil {
+		return fmt.Errorf("get product details by id %s: %w", data.ID, err)
+	}
+
+	if product == nil {
+		return nil
+	}
+
+	return p.ws.Publish(ctx, event, product.ToProductResponse())
+}
+
+func (p *productService) getStoreProduct(ctx context.Context, userID, storeID, productID string) (*models.Product, error) {
+	_, err := p.srs.CheckPermission(ctx, storeID, userID, models.PermissionEditCatalog)
+	if err != nil {
+		return nil, err
+	}
+
+	product, err := p.pr.GetProductByID(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("get product by id %s: %w", productID, err)
+	}
+
+	if product == nil {
+		return nil, models.ErrProductNotFound
+	}
+
+	if product.StoreID.String() != storeID {
+		return nil, models.ErrProductNotFound
+	}
+
+	return product, nil
+}
+
+func (p *productService) validateProductReferences(ctx context.Context, userID string, product models.Product) error {
+	if product.SizeID.Valid {
+		if _, err := p.szs.GetSizeByID(ctx, userID, product.SizeID.UUID.String(), product.StoreID.String()); err != nil {
+			return err
+		}
+	}
+
+	if product.ColorID.Valid {
+		if _, err := p.crs.GetColorByID(ctx, userID, product.StoreID.String(), product.ColorID.UUID.String()); err != nil {
+			return err
+		}
+	}
+
+	_, err := p.ces.GetCategoryByID(ctx, userID, product.StoreID.String(), product.CategoryID.String())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func toProductResponseList(products []models.Product) []models.ProductResponse {
+	responses := make([]models.ProductResponse, len(products))
+	for i, product := range products {
+		responses[i] = *product.ToProductResponse()
+	}
+	return responses
+}
@@ -2,93 +2,409 @@ package services
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"log/slog"
 	"mime/multipart"
-	"time"
 
 	"github.com/g-villarinho/flash-buy-api/models"
+	"github.com/g-villarinho/flash-buy-api/persistence"
 	"github.com/g-villarinho/flash-buy-api/pkgs"
 	"github.com/g-villarinho/flash-buy-api/repositories"
+	"github.com/google/uuid"
 )
 
 type ProductImageService interface {
-	CreateProductImage(ctx context.Context, productID string, images []*multipart.FileHeader)
+	CreateProductImage(ctx context.Context, productID string, images []*multipart.FileHeader) ([]models.ProductImage, error)
+	AddProductImages(ctx context.Context, userID, storeID, productID string, images []*multipart.FileHeader) ([]models.ProductImageResponse, error)
+	GetProductImages(ctx context.Context, userID, storeID, productID string) ([]models.ProductImageResponse, error)
+	DeleteProductImage(ctx context.Context, userID, storeID, productID, imageID string) error
+	ReorderProductImages(ctx context.Context, userID, storeID, productID string, payload models.ReorderProductImagesPayload) ([]models.ProductImageResponse, error)
+	SetPrimaryProductImage(ctx context.Context, userID, storeID, productID, imageID string) error
+	RetryProductImage(ctx context.Context, userID, storeID, productID, imageID string) error
+	DeleteProductImages(ctx context.Context, productID string) ([]models.ProductImage, error)
+	DeleteProductImageFiles(ctx context.Context, productImages []models.ProductImage)
 }
 
 type productImageService struct {
-	di *pkgs.Di
-	is ImageService
-	pr repositories.ProductImageRepository
+	di  *pkgs.Di
+	ss  StoreService
+	is  ImageService
+	jq  JobQueue
+	pr  repositories.ProductImageRepository
+	pdr repositories.ProductRepository
+	tr  persistence.Transactor
 }
 
 func NewProductImageService(di *pkgs.Di) (ProductImageService, error) {
+	ss, err := pkgs.Invoke[StoreService](di)
+	if err != nil {
+		return nil, err
+	}
+
 	is, err := pkgs.Invoke[ImageService](di)
 	if err != nil {
 		return nil, err
 	}
 
+	jq, err := pkgs.Invoke[JobQueue](di)
+	if err != nil {
+		return nil, err
+	}
+
 	pr, err := pkgs.Invoke[repositories.ProductImageRepository](di)
 	if err != nil {
 		return nil, err
 	}
 
-	return &productImageService{
-		di: di,
-		is: is,
-		pr: pr,
-	}, nil
+	pdr, err := pkgs.Invoke[repositories.ProductRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	tr, err := pkgs.Invoke[persistence.Transactor](di)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &productImageService{
+		di:  di,
+		ss:  ss,
+		is:  is,
+		jq:  jq,
+		pr:  pr,
+		pdr: pdr,
+		tr:  tr,
+	}
+
+	RegisterJobHandler(jq, models.JobTypeProductImageUpload, p.uploadProductImage)
+	jq.OnDeadLetter(models.JobTypeProductImageUpload, p.markProductImageFailed)
+
+	return p, nil
+}
+
+func (p *productImageService) CreateProductImage(ctx context.Context, productID string, images []*multipart.FileHeader) ([]models.ProductImage, error) {
+	productUUID, err := uuid.Parse(productID)
+	if err != nil {
+		return nil, fmt.Errorf("parse product id: %w", err)
+	}
+
+	contents := make([][]byte, len(images))
+	for i, image := range images {
+		content, err := p.is.ReadImage(image)
+		if err != nil {
+			return nil, err
+		}
+		contents[i] = content
+	}
+
+	created := make([]models.ProductImage, 0, len(images))
+
+	err = p.tr.WithTransaction(ctx, func(ctx context.Context) error {
+		position, err := p.pr.GetNextProductImagePosition(ctx, productID)
+		if err != nil {
+			return fmt.Errorf("get next product image position: %w", err)
+		}
+
+		for i, image := range images {
+			productImage := models.NewProductImage(productUUID, position+i, position == 0 && i == 0)
+
+			if err := p.pr.CreateProductImage(ctx, productImage); err != nil {
+				return fmt.Errorf("create product image: %w", err)
+			}
+
+			job := models.ProductImageUploadJob{
+				ImageID:   productImage.ID,
+				ProductID: productUUID,
+				Filename:  image.Filename,
+				Content:   contents[i],
+			}
+
+			if err := p.jq.Enqueue(ctx, models.JobTypeProductImageUpload, job); err != nil {
+				return fmt.Errorf("queue product image upload: %w", err)
+			}
+
+			created = append(created, *productImage)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (p *productImageService) AddProductImages(ctx context.Context, userID, storeID, productID string, images []*multipart.FileHeader) ([]models.ProductImageResponse, error) {
+	if _, err := p.getStoreProduct(ctx, userID, storeID, productID, models.PermissionEditCatalog); err != nil {
+		return nil, err
+	}
+
+	productImages, err := p.CreateProductImage(ctx, productID, images)
+	if err != nil {
+		return nil, err
+	}
+
+	return toProductImageResponseList(productImages), nil
 }
 
-func (p *productImageService) CreateProductImage(ctx context.Context, productID string, images []*multipart.FileHeader) {
+func (p *productImageService) GetProductImages(ctx context.Context, userID, storeID, productID string) ([]models.ProductImageResponse, error) {
+	if _, err := p.getStoreProduct(ctx, userID, storeID, productID, models.PermissionViewStore); err != nil {
+		return nil, err
+	}
+
+	productImages, err := p.pr.GetProductImagesByProductID(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("get product images by product id %s: %w", productID, err)
+	}
+
+	return toProductImageResponseList(productImages), nil
+}
+
+func (p *productImageService) DeleteProductImage(ctx context.Context, userID, storeID, productID, imageID string) error {
+	productImage, err := p.getProductImage(ctx, userID, storeID, productID, imageID)
+	if err != nil {
+		return err
+	}
+
+	err = p.tr.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := p.pr.DeleteProductImage(ctx, imageID); err != nil {
+			return fmt.Errorf("delete product image: %w", err)
+		}
+
+		if !productImage.IsPrimary {
+			return nil
+		}
+
+		remaining, err := p.pr.GetProductImagesByProductID(ctx, productID)
+		if err != nil {
+			return fmt.Errorf("get product images by product id %s: %w", productID, err)
+		}
+
+		if len(remaining) == 0 {
+			return nil
+		}
+
+		if err := p.pr.SetPrimaryProductImage(ctx, productID, remaining[0].ID.String()); err != nil {
+			return fmt.Errorf("set primary product image: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	p.is.DeleteImage(ctx, productImage.Renditions)
+
+	return nil
+}
+
+func (p *productImageService) ReorderProductImages(ctx context.Context, userID, storeID, productID string, payload models.ReorderProductImagesPayload) ([]models.ProductImageResponse, error) {
+	if _, err := p.getStoreProduct(ctx, userID, storeID, productID, models.PermissionEditCatalog); err != nil {
+		return nil, err
+	}
+
+	var productImages []models.ProductImage
+
+	err := p.tr.WithTransaction(ctx, func(ctx context.Context) error {
+		current, err := p.pr.GetProductImagesByProductID(ctx, productID)
+		if err != nil {
+			return fmt.Errorf("get product images by product id %s: %w", productID, err)
+		}
+
+		if len(payload.ImageIDs) != len(current) {
+			return models.ErrInvalidProductImageOrder
+		}
+
+		byID := make(map[uuid.UUID]*models.ProductImage, len(current))
+		for i := range current {
+			byID[current[i].ID] = &current[i]
+		}
+
+		productImages = make([]models.ProductImage, len(payload.ImageIDs))
+		for position, imageID := range payload.ImageIDs {
+			productImage, ok := byID[imageID]
+			if !ok {
+				return models.ErrInvalidProductImageOrder
+			}
+
+			delete(byID, imageID)
+
+			if productImage.Position != position {
+				if err := p.pr.UpdateProductImagePosition(ctx, imageID.String(), position); err != nil {
+					return fmt.Errorf("update product image position: %w", err)
+				}
+				productImage.Position = position
+			}
+
+			productImages[position] = *productImage
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toProductImageResponseList(productImages), nil
+}
+
+func (p *productImageService) SetPrimaryProductImage(ctx context.Context, userID, storeID, productID, imageID string) error {
+	if _, err := p.getProductImage(ctx, userID, storeID, productID, imageID); err != nil {
+		return err
+	}
+
+	if err := p.pr.SetPrimaryProductImage(ctx, productID, imageID); err != nil {
+		return fmt.Errorf("set primary product image: %w", err)
+	}
+
+	return nil
+}
+
+func (p *productImageService) RetryProductImage(ctx context.Context, userID, storeID, productID, imageID string) error {
+	productImage, err := p.getProductImage(ctx, userID, storeID, productID, imageID)
+	if err != nil {
+		return err
+	}
+
+	if productImage.Status != models.ProductImageStatusFailed {
+		return models.ErrProductImageNotFailed
+	}
+
+	if !productImage.JobID.Valid {
+		return models.ErrProductImageNotRetryable
+	}
+
+	return p.tr.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := p.jq.Requeue(ctx, productImage.JobID.UUID.String()); err != nil {
+			if err == models.ErrJobNotFound {
+				return models.ErrProductImageNotRetryable
+			}
+			return err
+		}
+
+		if err := p.pr.MarkProductImagePending(ctx, imageID); err != nil {
+			return fmt.Errorf("mark product image pending: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// Os arquivos devolvidos são apagados com DeleteProductImageFiles depois do commit
+func (p *productImageService) DeleteProductImages(ctx context.Context, productID string) ([]models.ProductImage, error) {
+	productImages, err := p.pr.DeleteProductImagesByProductID(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("delete product images by product id %s: %w", productID, err)
+	}
+
+	return productImages, nil
+}
+
+func (p *productImageService) DeleteProductImageFiles(ctx context.Context, productImages []models.ProductImage) {
+	for _, productImage := range productImages {
+		p.is.DeleteImage(ctx, productImage.Renditions)
+	}
+}
+
+func (p *productImageService) getStoreProduct(ctx context.Context, userID, storeID, productID string, permission models.StorePermission) (*models.Product, error) {
+	if _, err := p.ss.CheckPermission(ctx, storeID, userID, permission); err != nil {
+		return nil, err
+	}
+
+	product, err := p.pdr.GetProductByID(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("get product by id %s: %w", productID, err)
+	}
+
+	if product == nil || product.StoreID.String() != storeID {
+		return nil, models.ErrProductNotFound
+	}
+
+	return product, nil
+}
+
+func (p *productImageService) getProductImage(ctx context.Context, userID, storeID, productID, imageID string) (*models.ProductImage, error) {
+	if _, err := p.getStoreProduct(ctx, userID, storeID, productID, models.PermissionEditCatalog); err != nil {
+		return nil, err
+	}
+
+	productImage, err := p.pr.GetProductImageByID(ctx, imageID)
+	if err != nil {
+		return nil, fmt.Errorf("get product image by id %s: %w", imageID, err)
+	}
+
+	if productImage == nil || productImage.ProductID.String() != productID {
+		return nil, models.ErrProductImageNotFound
+	}
+
+	return productImage, nil
+}
+
+func (p *productImageService) uploadProductImage(ctx context.Context, job models.ProductImageUploadJob) error {
 	logger := slog.With(
 		"service", "product_image",
-		"method", "CreateProductImage",
-		"productID", productID,
+		"method", "uploadProductImage",
+		"productID", job.ProductID,
+		"imageID", job.ImageID,
+		"filename", job.Filename,
 	)
 
-	for _, image := range images {
-		go func(img *multipart.FileHeader) {
-			fileLogger := logger.With(
-				"filename", img.Filename,
-				"size", img.Size,
-			)
+	productImage, err := p.pr.GetProductImageByID(ctx, job.ImageID.String())
+	if err != nil {
+		return fmt.Errorf("get product image by id %s: %w", job.ImageID, err)
+	}
 
-			const maxRetries = 3
-			var lastErr error
+	if productImage == nil {
+		logger.Warn("product image not found, discarding upload")
+		return nil
+	}
 
-			for attempt := 1; attempt <= maxRetries; attempt++ {
-				uploadCtx := context.Background()
+	renditions, err := p.is.UploadImage(ctx, job.Content)
+	if err != nil {
+		// Validado antes de entrar na fila, nova tentativa não adianta
+		if errors.Is(err, models.ErrInvalidImage) || errors.Is(err, models.ErrImageTooLarge) || errors.Is(err, models.ErrUnsupportedImageType) {
+			return fmt.Errorf("%w: %v", models.ErrJobPermanent, err)
+		}
+		return err
+	}
 
-				fileLogger.Debug("attempting image upload", "attempt", attempt)
+	updated, err := p.pr.MarkProductImageReady(ctx, job.ImageID.String(), *renditions)
+	if err != nil {
+		p.is.DeleteImage(ctx, *renditions)
+		return fmt.Errorf("mark product image ready: %w", err)
+	}
 
-				imageURL, err := p.is.UploadImage(uploadCtx, img, img.Filename)
-				if err != nil {
-					lastErr = err
-					fileLogger.Warn("upload failed", "error", err, "retry", attempt)
-					time.Sleep(time.Duration(attempt) * time.Second)
-					continue
-				}
+	if !updated {
+		logger.Warn("product image deleted during upload, discarding files")
+		p.is.DeleteImage(ctx, *renditions)
+		return nil
+	}
 
-				productImage, err := models.NewProductImage(productID, imageURL)
-				if err != nil {
-					lastErr = err
-					fileLogger.Error("failed to create product image", "error", err)
-					break
-				}
+	logger.Info("image successfully processed")
+	return nil
+}
 
-				if err := p.pr.CreateProductImage(uploadCtx, productImage); err != nil {
-					lastErr = err
-					fileLogger.Error("failed to save product image", "error", err)
-					break
-				}
+func (p *productImageService) markProductImageFailed(ctx context.Context, job models.Job) error {
+	var payload models.ProductImageUploadJob
+	if err := json.Unmarshal(job.Payload, &payload); err != nil {
+		return fmt.Errorf("decode product image upload job: %w", err)
+	}
 
-				fileLogger.Info("image successfully processed")
-				return
-			}
+	if err := p.pr.MarkProductImageFailed(ctx, payload.ImageID.String(), job.ID, job.LastError); err != nil {
+		return fmt.Errorf("mark product image failed: %w", err)
+	}
 
-			if lastErr != nil {
-				fileLogger.Error("final upload failure after retries", "error", lastErr)
-			}
-		}(image)
+	return nil
+}
+
+func toProductImageResponseList(productImages []models.ProductImage) []models.ProductImageResponse {
+	responses := make([]models.ProductImageResponse, len(productImages))
+	for i, productImage := range productImages {
+		responses[i] = productImage.ToProductImageResponse()
 	}
+	return responses
 }
@@ -0,0 +1,272 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/g-villarinho/flash-buy-api/models"
+	"github.com/g-villarinho/flash-buy-api/persistence"
+	"github.com/g-villarinho/flash-buy-api/pkgs"
+	"github.com/g-villarinho/flash-buy-api/repositories"
+	"github.com/google/uuid"
+)
+
+type ProductVariantService interface {
+	PrepareProductVariants(ctx context.Context, userID string, product *models.Product, variants []models.ProductVariant) error
+	CreateProductVariants(ctx context.Context, userID, storeID, productID string, payload models.ProductVariantMatrixPayload) ([]models.ProductVariantResponse, error)
+	GetProductVariants(ctx context.Context, userID, storeID, productID string) ([]models.ProductVariantResponse, error)
+	UpdateProductVariant(ctx context.Context, userID, storeID, productID, variantID string, payload models.UpdateProductVariantPayload) error
+	DeleteProductVariant(ctx context.Context, userID, storeID, productID, variantID string) error
+}
+
+type productVariantService struct {
+	di  *pkgs.Di
+	ss  StoreService
+	szs SizeService
+	crs ColorService
+	is  InventoryService
+	pr  repositories.ProductRepository
+	pvr repositories.ProductVariantRepository
+	tr  persistence.Transactor
+}
+
+func NewProductVariantService(di *pkgs.Di) (ProductVariantService, error) {
+	ss, err := pkgs.Invoke[StoreService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	szs, err := pkgs.Invoke[SizeService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	crs, err := pkgs.Invoke[ColorService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	is, err := pkgs.Invoke[InventoryService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	pr, err := pkgs.Invoke[repositories.ProductRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	pvr, err := pkgs.Invoke[repositories.ProductVariantRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	tr, err := pkgs.Invoke[persistence.Transactor](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &productVariantService{
+		di:  di,
+		ss:  ss,
+		szs: szs,
+		crs: crs,
+		is:  is,
+		pr:  pr,
+		pvr: pvr,
+		tr:  tr,
+	}, nil
+}
+
+func (p *productVariantService) PrepareProductVariants(ctx context.Context, userID string, product *models.Product, variants []models.ProductVariant) error {
+	storeID := product.StoreID.String()
+	skus := make(map[string]bool, len(variants))
+
+	sizes := make(map[uuid.UUID]*models.SizeResponse)
+	colors := make(map[uuid.UUID]*models.ColorResponse)
+
+	for i := range variants {
+		size, ok := sizes[variants[i].SizeID]
+		if !ok {
+			var err error
+			if size, err = p.szs.GetSizeByID(ctx, userID, variants[i].SizeID.String(), storeID); err != nil {
+				return err
+			}
+			sizes[variants[i].SizeID] = size
+		}
+
+		color, ok := colors[variants[i].ColorID]
+		if !ok {
+			var err error
+			if color, err = p.crs.GetColorByID(ctx, userID, storeID, variants[i].ColorID.String()); err != nil {
+				return err
+			}
+			colors[variants[i].ColorID] = color
+		}
+
+		if variants[i].SKU == "" {
+			variants[i].SKU = models.GenerateSKU(product.Name, size.Value, color.Name)
+		}
+
+		if skus[variants[i].SKU] {
+			return models.ErrSKUAlreadyExists
+		}
+		skus[variants[i].SKU] = true
+
+		existing, err := p.pvr.GetProductVariantBySKU(ctx, storeID, variants[i].SKU)
+		if err != nil {
+			return fmt.Errorf("get product variant by sku %s: %w", variants[i].SKU, err)
+		}
+
+		if existing != nil {
+			return models.ErrSKUAlreadyExists
+		}
+	}
+
+	return nil
+}
+
+func (p *productVariantService) CreateProductVariants(ctx context.Context, userID, storeID, productID string, payload models.ProductVariantMatrixPayload) ([]models.ProductVariantResponse, error) {
+	product, err := p.getStoreProduct(ctx, userID, storeID, productID, models.PermissionEditCatalog)
+	if err != nil {
+		return nil, err
+	}
+
+	variants, err := payload.ToProductVariants(product)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(variants) == 0 {
+		return nil, models.ErrInvalidProductVariant
+	}
+
+	current, err := p.pvr.GetProductVariantsByProductID(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("get product variants: %w", err)
+	}
+
+	for _, variant := range variants {
+		for _, existing := range current {
+			if existing.SizeID == variant.SizeID && existing.ColorID == variant.ColorID {
+				return nil, models.ErrProductVariantDuplicate
+			}
+		}
+	}
+
+	if err := p.PrepareProductVariants(ctx, userID, product, variants); err != nil {
+		return nil, err
+	}
+
+	err = p.tr.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := p.pvr.CreateProductVariants(ctx, variants); err != nil {
+			return fmt.Errorf("create product variants: %w", err)
+		}
+
+		if product.HasOwnOptions() {
+			product.ColorID = uuid.NullUUID{}
+			product.SizeID = uuid.NullUUID{}
+
+			if err := p.pr.UpdateProduct(ctx, product); err != nil {
+				return fmt.Errorf("update product: %w", err)
+			}
+		}
+
+		return p.is.RecordInitialStock(ctx, userID, variants)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return p.GetProductVariants(ctx, userID, storeID, productID)
+}
+
+func (p *productVariantService) GetProductVariants(ctx context.Context, userID, storeID, productID string) ([]models.ProductVariantResponse, error) {
+	if _, err := p.getStoreProduct(ctx, userID, storeID, productID, models.PermissionViewStore); err != nil {
+		return nil, err
+	}
+
+	variants, err := p.pvr.GetProductVariantsByProductID(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("get product variants: %w", err)
+	}
+
+	responses := make([]models.ProductVariantResponse, len(variants))
+	for i, variant := range variants {
+		responses[i] = variant.ToProductVariantResponse()
+	}
+
+	return responses, nil
+}
+
+func (p *productVariantService) UpdateProductVariant(ctx context.Context, userID, storeID, productID, variantID string, payload models.UpdateProductVariantPayload) error {
+	variant, err := p.getProductVariant(ctx, userID, storeID, productID, variantID)
+	if err != nil {
+		return err
+	}
+
+	if err := payload.ApplyTo(variant); err != nil {
+		return err
+	}
+
+	existing, err := p.pvr.GetProductVariantBySKU(ctx, storeID, variant.SKU)
+	if err != nil {
+		return fmt.Errorf("get product variant by sku %s: %w", variant.SKU, err)
+	}
+
+	if existing != nil && existing.ID != variant.ID {
+		return models.ErrSKUAlreadyExists
+	}
+
+	if err := p.pvr.UpdateProductVariant(ctx, variant); err != nil {
+		return fmt.Errorf("update product variant: %w", err)
+	}
+
+	return nil
+}
+
+func (p *productVariantService) DeleteProductVariant(ctx context.Context, userID, storeID, productID, variantID string) error {
+	if _, err := p.getProductVariant(ctx, userID, storeID, productID, variantID); err != nil {
+		return err
+	}
+
+	if err := p.pvr.DeleteProductVariant(ctx, variantID); err != nil {
+		return fmt.Errorf("delete product variant: %w", err)
+	}
+
+	return nil
+}
+
+func (p *productVariantService) getProductVariant(ctx context.Context, userID, storeID, productID, variantID string) (*models.ProductVariant, error) {
+	if _, err := p.getStoreProduct(ctx, userID, storeID, productID, models.PermissionEditCatalog); err != nil {
+		return nil, err
+	}
+
+	variant, err := p.pvr.GetProductVariantByID(ctx, variantID)
+	if err != nil {
+		return nil, fmt.Errorf("get product variant by id %s: %w", variantID, err)
+	}
+
+	if variant == nil || variant.ProductID.String() != productID {
+		return nil, models.ErrProductVariantNotFound
+	}
+
+	return variant, nil
+}
+
+func (p *productVariantService) getStoreProduct(ctx context.Context, userID, storeID, productID string, permission models.StorePermission) (*models.Product, error) {
+	if _, err := p.ss.CheckPermission(ctx, storeID, userID, permission); err != nil {
+		return nil, err
+	}
+
+	product, err := p.pr.GetProductByID(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("get product by id %s: %w", productID, err)
+	}
+
+	if product == nil || product.StoreID.String() != storeID {
+		return nil, models.ErrProductNotFound
+	}
+
+	return product, nil
+}
@@ -3,10 +3,9 @@ package services
 import (
 	"context"
 	"fmt"
-	"log/slog"
 
 	"github.com/g-villarinho/flash-buy-api/models"
-	"github.com/g-villarinho/flash-buy-api/notifications"
+	"github.com/g-villarinho/flash-buy-api/persistence"
 	"github.com/g-villarinho/flash-buy-api/pkgs"
 )
 
@@ -19,7 +18,8 @@ type registerService struct {
 	us UserService
 	ss SessionService
 	os OTPService
-	en notifications.EmailNotification
+	eq EmailQueueService
+	tr persistence.Transactor
 }
 
 func NewRegisterService(di *pkgs.Di) (RegisterService, error) {
@@ -33,9 +33,9 @@ func NewRegisterService(di *pkgs.Di) (RegisterService, error) {
 		return nil, fmt.Errorf("invoke otp service: %w", err)
 	}
 
-	emailNotification, err := pkgs.Invoke[notifications.EmailNotification](di)
+	emailQueueService, err := pkgs.Invoke[EmailQueueService](di)
 	if err != nil {
-		return nil, fmt.Errorf("invoke email notification: %w", err)
+		return nil, fmt.Errorf("invoke email queue service: %w", err)
 	}
 
 	sessionService, err := pkgs.Invoke[SessionService](di)
@@ -43,35 +43,49 @@ func NewRegisterService(di *pkgs.Di) (RegisterService, error) {
 		return nil, fmt.Errorf("invoke session service: %w", err)
 	}
 
+	transactor, err := pkgs.Invoke[persistence.Transactor](di)
+	if err != nil {
+		return nil, fmt.Errorf("invoke transactor: %w", err)
+	}
+
 	return &registerService{
 		di: di,
 		us: userService,
 		ss: sessionService,
 		os: otpService,
-		en: emailNotification,
+		eq: emailQueueService,
+		tr: transactor,
 	}, nil
 }
 
 func (r *registerService) Register(ctx context.Context, user *models.User, ssi models.SessionSecurityInfo) (*models.Session, error) {
-	if err := r.us.CreateUser(ctx, *user); err != nil {
-		return nil, err
-	}
+	var session *models.Session
 
-	session, err := r.ss.CreateSession(ctx, user, ssi)
-	if err != nil {
-		return nil, fmt.Errorf("create session: %w", err)
-	}
+	err := r.tr.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := r.us.CreateUser(ctx, *user); err != nil {
+			return err
+		}
 
-	code, err := r.os.GeneratOTP(ctx, user.ID.String(), models.UserVerificationFLow, session.Token)
+		var err error
+		session, err = r.ss.CreateSession(ctx, user, ssi)
+		if err != nil {
+			return fmt.Errorf("create session: %w", err)
+		}
+
+		otp, err := r.os.GeneratOTP(ctx, user.ID.String(), models.UserVerificationFLow, session.Token, ssi)
+		if err != nil {
+			return err
+		}
+
+		if err := r.eq.QueueVerificationEmail(ctx, user.Email, otp.Code); err != nil {
+			return fmt.Errorf("queue verification email: %w", err)
+		}
+
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	go func() {
-		if err := r.en.SendVerificationEmail(ctx, user.Email, code); err != nil {
-			slog.Error("send verification email", "error", err)
-		}
-	}()
-
 	return session, nil
 }
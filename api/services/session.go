@@ -4,6 +4,9 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
 	"time"
 
 	"github.com/g-villarinho/flash-buy-api/models"
@@ -14,13 +17,26 @@ import (
 
 type SessionService interface {
 	CreateSession(ctx context.Context, user *models.User, ssi models.SessionSecurityInfo) (*models.Session, error)
-	ValidSession(ctx context.Context, token string) (*models.Session, error)
+	ValidSession(ctx context.Context, token string, methods ...models.AuthMethod) (*models.Session, error)
+	GetPendingSession(ctx context.Context, token string) (*models.Session, error)
+	RecordAuthMethod(ctx context.Context, token string, method models.AuthMethod) (*models.Session, error)
+	StepUp(ctx context.Context, userID, sessionID string, method models.AuthMethod) (*models.Session, error)
+	IsSessionActive(ctx context.Context, sessionID, userID string) (bool, error)
+	GetSessions(ctx context.Context, userID, currentSessionID string) ([]models.SessionResponse, error)
+	RevokeSession(ctx context.Context, userID, sessionID string) error
 }
 
+// Revogações em outra instância valem após sessionCacheTTL, as locais na hora
+const (
+	sessionCacheTTL     = 30 * time.Second
+	sessionCacheMaxSize = 10000
+)
+
 type sessionService struct {
-	di *pkgs.Di
-	ts TokenService
-	sr repositories.SessionRepository
+	di    *pkgs.Di
+	ts    TokenService
+	sr    repositories.SessionRepository
+	cache *sessionCache
 }
 
 func NewSessionService(di *pkgs.Di) (SessionService, error) {
@@ -34,9 +50,10 @@ func NewSessionService(di *pkgs.Di) (SessionService, error) {
 		return nil, err
 	}
 	return &sessionService{
-		di: di,
-		ts: tokenService,
-		sr: sessionRepository,
+		di:    di,
+		ts:    tokenService,
+		sr:    sessionRepository,
+		cache: newSessionCache(sessionCacheTTL, sessionCacheMaxSize),
 	}, nil
 }
 
@@ -67,7 +84,7 @@ func (s *sessionService) CreateSession(ctx context.Context, user *models.User, s
 	return &session, nil
 }
 
-func (s *sessionService) ValidSession(ctx context.Context, authToken string) (*models.Session, error) {
+func (s *sessionService) ValidSession(ctx context.Context, authToken string, methods ...models.AuthMethod) (*models.Session, error) {
 	now := time.Now()
 
 	session, err := s.sr.GetSessionToken(ctx, authToken)
@@ -83,10 +100,23 @@ func (s *sessionService) ValidSession(ctx context.Context, authToken string) (*m
 		return nil, models.ErrSessionNotFoundOrExpired
 	}
 
+	if session.IsPendingMFA() && !slices.ContainsFunc(methods, models.AuthMethod.IsSecondFactor) {
+		return nil, models.ErrMFARequired
+	}
+
+	for _, method := range methods {
+		session.AddAuthMethod(method)
+
+		if method.IsSecondFactor() {
+			session.MFAVerifiedAt = sql.NullTime{Time: now, Valid: true}
+		}
+	}
+
 	session.VerifiedAt = sql.NullTime{Time: now, Valid: true}
+	session.LastUsedAt = sql.NullTime{Time: now, Valid: true}
 	session.ExpiresAt = now.Add(time.Hour * 24 * 7)
 
-	authToken, err = s.ts.CreateToken(ctx, session.UserID.String(), session.ID.String(), session.Email, session.CreatedAt, session.ExpiresAt)
+	authToken, err = s.ts.CreateSessionToken(ctx, *session)
 	if err != nil {
 		return nil, fmt.Errorf("create token: %w", err)
 	}
@@ -98,3 +128,179 @@ func (s *sessionService) ValidSession(ctx context.Context, authToken string) (*m
 
 	return session, nil
 }
+
+func (s *sessionService) GetPendingSession(ctx context.Context, authToken string) (*models.Session, error) {
+	session, err := s.sr.GetSessionToken(ctx, authToken)
+	if err != nil {
+		return nil, fmt.Errorf("get session by token: %w", err)
+	}
+
+	if session == nil || session.IsExpired() || session.VerifiedAt.Valid {
+		return nil, models.ErrSessionNotFoundOrExpired
+	}
+
+	return session, nil
+}
+
+func (s *sessionService) RecordAuthMethod(ctx context.Context, authToken string, method models.AuthMethod) (*models.Session, error) {
+	session, err := s.GetPendingSession(ctx, authToken)
+	if err != nil {
+		return nil, err
+	}
+
+	session.AddAuthMethod(method)
+	session.UpdatedAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+	if err := s.sr.UpsertSession(ctx, *session); err != nil {
+		return nil, fmt.Errorf("upsert session: %w", err)
+	}
+
+	return session, nil
+}
+
+func (s *sessionService) StepUp(ctx context.Context, userID, sessionID string, method models.AuthMethod) (*models.Session, error) {
+	session, err := s.sr.GetSessionByID(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("get session by id %s: %w", sessionID, err)
+	}
+
+	if session == nil || session.UserID.String() != userID || !session.VerifiedAt.Valid || session.IsExpired() {
+		return nil, models.ErrSessionNotFoundOrExpired
+	}
+
+	now := time.Now()
+	session.AddAuthMethod(method)
+	session.MFAVerifiedAt = sql.NullTime{Time: now, Valid: true}
+	session.UpdatedAt = sql.NullTime{Time: now, Valid: true}
+
+	authToken, err := s.ts.CreateSessionToken(ctx, *session)
+	if err != nil {
+		return nil, fmt.Errorf("create token: %w", err)
+	}
+
+	session.Token = authToken
+	if err := s.sr.UpsertSession(ctx, *session); err != nil {
+		return nil, fmt.Errorf("upsert session: %w", err)
+	}
+
+	return session, nil
+}
+
+func (s *sessionService) IsSessionActive(ctx context.Context, sessionID, userID string) (bool, error) {
+	key := sessionID + ":" + userID
+	if active, ok := s.cache.get(key); ok {
+		return active, nil
+	}
+
+	session, err := s.sr.GetSessionByID(ctx, sessionID)
+	if err != nil {
+		return false, fmt.Errorf("get session by id %s: %w", sessionID, err)
+	}
+
+	active := session != nil &&
+		session.UserID.String() == userID &&
+		session.VerifiedAt.Valid &&
+		!session.IsExpired()
+
+	if active {
+		if err := s.sr.TouchSession(ctx, sessionID, time.Now()); err != nil {
+			slog.Error("touch session", "sessionID", sessionID, "error", err)
+		}
+	}
+
+	s.cache.set(key, active)
+
+	return active, nil
+}
+
+func (s *sessionService) GetSessions(ctx context.Context, userID, currentSessionID string) ([]models.SessionResponse, error) {
+	sessions, err := s.sr.GetActiveSessionsByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get active sessions by user id %s: %w", userID, err)
+	}
+
+	resp := make([]models.SessionResponse, len(sessions))
+	for i, session := range sessions {
+		resp[i] = session.ToSessionResponse(currentSessionID)
+	}
+
+	return resp, nil
+}
+
+func (s *sessionService) RevokeSession(ctx context.Context, userID, sessionID string) error {
+	session, err := s.sr.GetSessionByID(ctx, sessionID)
+	if err != nil {
+		return fmt.Errorf("get session by id %s: %w", sessionID, err)
+	}
+
+	if session == nil || session.UserID.String() != userID {
+		return models.ErrSessionNotFound
+	}
+
+	if err := s.sr.DeleteSession(ctx, sessionID); err != nil {
+		return fmt.Errorf("delete session %s: %w", sessionID, err)
+	}
+
+	s.cache.delete(sessionID + ":" + userID)
+
+	return nil
+}
+
+type sessionCacheEntry struct {
+	active    bool
+	expiresAt time.Time
+}
+
+type sessionCache struct {
+	mu      sync.RWMutex
+	ttl     time.Duration
+	maxSize int
+	entries map[string]sessionCacheEntry
+}
+
+func newSessionCache(ttl time.Duration, maxSize int) *sessionCache {
+	return &sessionCache{
+		ttl:     ttl,
+		maxSize: maxSize,
+		entries: make(map[string]sessionCacheEntry),
+	}
+}
+
+func (c *sessionCache) get(key string) (bool, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, ok := c.entries[key]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return false, false
+	}
+
+	return entry.active, true
+}
+
+func (c *sessionCache) set(key string, active bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	if len(c.entries) >= c.maxSize {
+		for k, entry := range c.entries {
+			if now.After(entry.expiresAt) {
+				delete(c.entries, k)
+			}
+		}
+	}
+
+	if len(c.entries) >= c.maxSize {
+		return
+	}
+
+	c.entries[key] = sessionCacheEntry{active: active, expiresAt: now.Add(c.ttl)}
+}
+
+func (c *sessionCache) delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, key)
+}
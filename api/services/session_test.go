@@ -0,0 +1,153 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/g-villarinho/flash-buy-api/models"
+	"github.com/google/uuid"
+)
+
+type fakeSessionRepository struct {
+	sessions map[uuid.UUID]models.Session
+	lookups  int
+}
+
+func (f *fakeSessionRepository) UpsertSession(ctx context.Context, session models.Session) error {
+	f.sessions[session.ID] = session
+	return nil
+}
+
+func (f *fakeSessionRepository) GetSessionToken(ctx context.Context, token string) (*models.Session, error) {
+	for _, session := range f.sessions {
+		if session.Token == token {
+			return &session, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeSessionRepository) DeleteSession(ctx context.Context, ID string) error {
+	delete(f.sessions, uuid.MustParse(ID))
+	return nil
+}
+
+func (f *fakeSessionRepository) GetSessionByID(ctx context.Context, ID string) (*models.Session, error) {
+	f.lookups++
+
+	session, ok := f.sessions[uuid.MustParse(ID)]
+	if !ok {
+		return nil, nil
+	}
+	return &session, nil
+}
+
+func (f *fakeSessionRepository) GetActiveSessionsByUserID(ctx context.Context, userID string) ([]models.Session, error) {
+	var sessions []models.Session
+	for _, session := range f.sessions {
+		if session.UserID.String() == userID && !session.IsExpired() {
+			sessions = append(sessions, session)
+		}
+	}
+	return sessions, nil
+}
+
+func (f *fakeSessionRepository) TouchSession(ctx context.Context, ID string, usedAt time.Time) error {
+	session := f.sessions[uuid.MustParse(ID)]
+	session.LastUsedAt = sql.NullTime{Time: usedAt, Valid: true}
+	f.sessions[session.ID] = session
+	return nil
+}
+
+func newTestSessionService(sessions ...models.Session) (*sessionService, *fakeSessionRepository) {
+	repo := &fakeSessionRepository{sessions: make(map[uuid.UUID]models.Session)}
+	for _, session := range sessions {
+		repo.sessions[session.ID] = session
+	}
+
+	return &sessionService{sr: repo, cache: newSessionCache(sessionCacheTTL, sessionCacheMaxSize)}, repo
+}
+
+func newVerifiedSession(userID uuid.UUID) models.Session {
+	now := time.Now()
+	return models.Session{
+		ID:         uuid.New(),
+		UserID:     userID,
+		VerifiedAt: sql.NullTime{Time: now, Valid: true},
+		ExpiresAt:  now.Add(time.Hour),
+		CreatedAt:  now,
+	}
+}
+
+func TestRevokeSessionInvalidatesCachedSession(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	session := newVerifiedSession(userID)
+	service, repo := newTestSessionService(session)
+
+	for i := 0; i < 2; i++ {
+		active, err := service.IsSessionActive(ctx, session.ID.String(), userID.String())
+		if err != nil || !active {
+			t.Fatalf("IsSessionActive() = %v, %v, want true", active, err)
+		}
+	}
+
+	if repo.lookups != 1 {
+		t.Fatalf("IsSessionActive() hit the repository %d times, want 1 thanks to the cache", repo.lookups)
+	}
+
+	if err := service.RevokeSession(ctx, userID.String(), session.ID.String()); err != nil {
+		t.Fatalf("RevokeSession() error = %v", err)
+	}
+
+	active, err := service.IsSessionActive(ctx, session.ID.String(), userID.String())
+	if err != nil || active {
+		t.Errorf("IsSessionActive() after revoke = %v, %v, want false", active, err)
+	}
+}
+
+func TestRevokeSessionOfAnotherUser(t *testing.T) {
+	ctx := context.Background()
+	owner := uuid.New()
+	session := newVerifiedSession(owner)
+	service, repo := newTestSessionService(session)
+
+	if err := service.RevokeSession(ctx, uuid.NewString(), session.ID.String()); err != models.ErrSessionNotFound {
+		t.Fatalf("RevokeSession() by another user = %v, want %v", err, models.ErrSessionNotFound)
+	}
+
+	if _, ok := repo.sessions[session.ID]; !ok {
+		t.Error("RevokeSession() by another user deleted the session")
+	}
+
+	if err := service.RevokeSession(ctx, owner.String(), uuid.NewString()); err != models.ErrSessionNotFound {
+		t.Errorf("RevokeSession() with unknown id = %v, want %v", err, models.ErrSessionNotFound)
+	}
+}
+
+func TestIsSessionActiveRejectsUnverifiedAndExpired(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	pending := newVerifiedSession(userID)
+	pending.VerifiedAt = sql.NullTime{}
+
+	expired := newVerifiedSession(userID)
+	expired.ExpiresAt = time.Now().Add(-time.Minute)
+
+	service, _ := newTestSessionService(pending, expired)
+
+	for name, session := range map[string]models.Session{"pending": pending, "expired": expired} {
+		active, err := service.IsSessionActive(ctx, session.ID.String(), userID.String())
+		if err != nil || active {
+			t.Errorf("IsSessionActive() for %s session = %v, %v, want false", name, active, err)
+		}
+	}
+
+	active, err := service.IsSessionActive(ctx, pending.ID.String(), uuid.NewString())
+	if err != nil || active {
+		t.Errorf("IsSessionActive() for another user = %v, %v, want false", active, err)
+	}
+}
@@ -11,8 +11,8 @@ import (
 
 type SizeService interface {
 	CreateSize(ctx context.Context, userID string, size models.Size) error
-	GetSizesPagedList(ctx context.Context, storeID string, pag models.SizePagination) (*models.PaginatedResponse, error)
-	GetSizeByID(ctx context.Context, sizeID, storeID string) (*models.SizeResponse, error)
+	GetSizesPagedList(ctx context.Context, userID, storeID string, pag models.SizePagination) (*models.PaginatedResponse, error)
+	GetSizeByID(ctx context.Context, userID, sizeID, storeID string) (*models.SizeResponse, error)
 	DeleteSize(ctx context.Context, userID, storeID, sizeID string) error
 }
 
@@ -41,7 +41,7 @@ func NewSizeService(di *pkgs.Di) (SizeService, error) {
 }
 
 func (s *sizeService) CreateSize(ctx context.Context, userID string, size models.Size) error {
-	_, err := s.ss.GetStoreByStoreID(ctx, size.StoreID.String(), userID)
+	_, err := s.ss.CheckPermission(ctx, size.StoreID.String(), userID, models.PermissionEditCatalog)
 	if err != nil {
 		return err
 	}
@@ -53,7 +53,11 @@ func (s *sizeService) CreateSize(ctx context.Context, userID string, size models
 	return nil
 }
 
-func (s *sizeService) GetSizesPagedList(ctx context.Context, storeID string, pag models.SizePagination) (*models.PaginatedResponse, error) {
+func (s *sizeService) GetSizesPagedList(ctx context.Context, userID, storeID string, pag models.SizePagination) (*models.PaginatedResponse, error) {
+	if _, err := s.ss.CheckPermission(ctx, storeID, userID, models.PermissionViewStore); err != nil {
+		return nil, err
+	}
+
 	sizes, err := s.sr.GetSizesPagedList(ctx, storeID, pag)
 	if err != nil {
 		return nil, err
@@ -68,21 +72,25 @@ func (s *sizeService) GetSizesPagedList(ctx context.Context, storeID string, pag
 	}, nil
 }
 
-func (s *sizeService) GetSizeByID(ctx context.Context, sizeID, storeID string) (*models.SizeResponse, error) {
+func (s *sizeService) GetSizeByID(ctx context.Context, userID, sizeID, storeID string) (*models.SizeResponse, error) {
+	if _, err := s.ss.CheckPermission(ctx, storeID, userID, models.PermissionViewStore); err != nil {
+		return nil, err
+	}
+
 	size, err := s.sr.GetSizeByID(ctx, sizeID)
 	if err != nil {
 		return nil, err
 	}
 
-	if size.StoreID.String() != storeID {
+	if size == nil || size.StoreID.String() != storeID {
 		return nil, models.ErrSizeNotFound
 	}
 
 	return size.ToSizeResponse(), nil
 }
 
-func (s *sizeService) DeleteSize(ctx context.Context, userID string, storeID string, sizeID string) error {
-	_, err := s.ss.GetStoreByStoreID(ctx, storeID, userID)
+func (s *sizeService) DeleteSize(ctx context.Context, userID, storeID string, sizeID string) error {
+	_, err := s.ss.CheckPermission(ctx, storeID, userID, models.PermissionEditCatalog)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,224 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/g-villarinho/flash-buy-api/clients"
+	"github.com/g-villarinho/flash-buy-api/models"
+	"github.com/g-villarinho/flash-buy-api/pkgs"
+	"github.com/g-villarinho/flash-buy-api/repositories"
+	"github.com/g-villarinho/flash-buy-api/utils"
+	"github.com/google/uuid"
+)
+
+const (
+	SocialLoginStateExpiration = 10 * time.Minute
+	socialLoginTokenSize       = 32
+)
+
+type SocialLoginService interface {
+	StartLogin(ctx context.Context, provider string) (*models.SocialLoginAuthorization, error)
+	HandleCallback(ctx context.Context, provider, code, state string, ssi models.SessionSecurityInfo) (*models.Session, error)
+}
+
+type socialLoginService struct {
+	di  *pkgs.Di
+	op  clients.OIDCProviders
+	slr repositories.SocialLoginStateRepository
+	us  UserService
+	ss  SessionService
+	as  AuthService
+}
+
+func NewSocialLoginService(di *pkgs.Di) (SocialLoginService, error) {
+	op, err := pkgs.Invoke[clients.OIDCProviders](di)
+	if err != nil {
+		return nil, err
+	}
+
+	slr, err := pkgs.Invoke[repositories.SocialLoginStateRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	us, err := pkgs.Invoke[UserService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	ss, err := pkgs.Invoke[SessionService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	as, err := pkgs.Invoke[AuthService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &socialLoginService{
+		di:  di,
+		op:  op,
+		slr: slr,
+		us:  us,
+		ss:  ss,
+		as:  as,
+	}, nil
+}
+
+func (s *socialLoginService) StartLogin(ctx context.Context, provider string) (*models.SocialLoginAuthorization, error) {
+	client, err := s.getProvider(provider)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := utils.GenerateRandomToken(socialLoginTokenSize)
+	if err != nil {
+		return nil, fmt.Errorf("generate state: %w", err)
+	}
+
+	nonce, err := utils.GenerateRandomToken(socialLoginTokenSize)
+	if err != nil {
+		return nil, fmt.Errorf("generate nonce: %w", err)
+	}
+
+	codeVerifier, err := clients.NewPKCEVerifier()
+	if err != nil {
+		return nil, fmt.Errorf("generate code verifier: %w", err)
+	}
+
+	authURL, err := client.AuthCodeURL(ctx, state, nonce, codeVerifier)
+	if err != nil {
+		return nil, fmt.Errorf("build authorization url: %w", err)
+	}
+
+	loginState := models.NewSocialLoginState(provider, utils.HashToken(state), nonce, codeVerifier, time.Now().Add(SocialLoginStateExpiration))
+	if err := s.slr.CreateSocialLoginState(ctx, loginState); err != nil {
+		return nil, fmt.Errorf("create social login state: %w", err)
+	}
+
+	if err := s.slr.DeleteExpiredSocialLoginStates(ctx, time.Now()); err != nil {
+		slog.Error("delete expired social login states", "error", err)
+	}
+
+	return &models.SocialLoginAuthorization{
+		URL:   authURL,
+		State: state,
+	}, nil
+}
+
+// O state é consumido antes da troca do código para que o callback não se repita
+func (s *socialLoginService) HandleCallback(ctx context.Context, provider, code, state string, ssi models.SessionSecurityInfo) (*models.Session, error) {
+	client, err := s.getProvider(provider)
+	if err != nil {
+		return nil, err
+	}
+
+	loginState, err := s.slr.GetSocialLoginStateByHash(ctx, utils.HashToken(state))
+	if err != nil {
+		return nil, fmt.Errorf("get social login state: %w", err)
+	}
+
+	if loginState == nil || loginState.Provider != provider || loginState.IsExpired() {
+		return nil, models.ErrSocialLoginStateInvalid
+	}
+
+	consumed, err := s.slr.ConsumeSocialLoginState(ctx, loginState.ID.String())
+	if err != nil {
+		return nil, fmt.Errorf("consume social login state: %w", err)
+	}
+
+	if !consumed {
+		return nil, models.ErrSocialLoginStateInvalid
+	}
+
+	rawIDToken, err := client.Exchange(ctx, code, loginState.CodeVerifier)
+	if err != nil {
+		if errors.Is(err, clients.ErrOIDCExchangeFailed) {
+			return nil, models.ErrSocialLoginCodeInvalid
+		}
+
+		return nil, fmt.Errorf("exchange authorization code: %w", err)
+	}
+
+	identity, err := client.VerifyIDToken(ctx, rawIDToken, loginState.Nonce)
+	if err != nil {
+		if errors.Is(err, clients.ErrOIDCInvalidIDToken) {
+			slog.Warn("invalid id token", "provider", provider, "error", err)
+			return nil, models.ErrSocialLoginIDTokenInvalid
+		}
+
+		return nil, fmt.Errorf("verify id token: %w", err)
+	}
+
+	// Sem email verificado qualquer conta no provedor assumiria o usuário daquele email
+	if identity.Email == "" || !identity.EmailVerified {
+		return nil, models.ErrSocialLoginEmailNotVerified
+	}
+
+	user, err := s.findOrCreateUser(ctx, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := s.ss.CreateSession(ctx, user, ssi)
+	if err != nil {
+		return nil, fmt.Errorf("create session: %w", err)
+	}
+
+	return s.as.CompleteFirstFactor(ctx, session.Token, models.AuthMethodSSO)
+}
+
+func (s *socialLoginService) findOrCreateUser(ctx context.Context, identity *clients.OIDCIdentity) (*models.User, error) {
+	email := strings.ToLower(strings.TrimSpace(identity.Email))
+
+	user, err := s.us.GetUserByEmail(ctx, email)
+	if err == nil {
+		return user, nil
+	}
+
+	if err != models.ErrUserNotFound {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(identity.Name)
+	if name == "" {
+		name, _, _ = strings.Cut(email, "@")
+	}
+
+	newUser := models.User{
+		ID:        uuid.New(),
+		Name:      name,
+		Username:  email,
+		Email:     email,
+		CreatedAt: time.Now(),
+	}
+
+	if err := s.us.CreateUser(ctx, newUser); err != nil {
+		if existing, getErr := s.us.GetUserByEmail(ctx, email); getErr == nil {
+			return existing, nil
+		}
+
+		return nil, err
+	}
+
+	return &newUser, nil
+}
+
+func (s *socialLoginService) getProvider(provider string) (clients.OIDCClient, error) {
+	client, err := s.op.Provider(provider)
+	if err != nil {
+		if err == clients.ErrOIDCProviderNotFound {
+			return nil, models.ErrSocialLoginProviderNotFound
+		}
+
+		return nil, err
+	}
+
+	return client, nil
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/g-villarinho/flash-buy-api/models"
+	"github.com/g-villarinho/flash-buy-api/persistence"
 	"github.com/g-villarinho/flash-buy-api/pkgs"
 	"github.com/g-villarinho/flash-buy-api/repositories"
 )
@@ -16,11 +17,16 @@ type StoreService interface {
 	GetStoresByUserID(ctx context.Context, userID string) ([]models.StoreResponse, error)
 	UpdateStore(ctx context.Context, userID, storeID, name string) error
 	DeleteStore(ctx context.Context, storeID, userID string) error
+	CheckPermission(ctx context.Context, storeID, userID string, permission models.StorePermission) (*models.StoreResponse, error)
+	GetUserRole(ctx context.Context, store *models.Store, userID string) (models.StoreRole, error)
 }
 
 type storeService struct {
-	di *pkgs.Di
-	sr repositories.StoreRepository
+	di  *pkgs.Di
+	rdp pkgs.RequestDataCtx
+	sr  repositories.StoreRepository
+	smr repositories.StoreMemberRepository
+	tr  persistence.Transactor
 }
 
 func NewStoreService(di *pkgs.Di) (StoreService, error) {
@@ -28,15 +34,46 @@ func NewStoreService(di *pkgs.Di) (StoreService, error) {
 	if err != nil {
 		return nil, err
 	}
+
+	smr, err := pkgs.Invoke[repositories.StoreMemberRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	rdp, err := pkgs.Invoke[pkgs.RequestDataCtx](di)
+	if err != nil {
+		return nil, err
+	}
+
+	tr, err := pkgs.Invoke[persistence.Transactor](di)
+	if err != nil {
+		return nil, err
+	}
+
 	return &storeService{
-		di: di,
-		sr: sr,
+		di:  di,
+		rdp: rdp,
+		sr:  sr,
+		smr: smr,
+		tr:  tr,
 	}, nil
 }
 
 func (s *storeService) CreateStore(ctx context.Context, store *models.Store) (*models.CreateStoreResponse, error) {
-	if err := s.sr.CreateStore(ctx, store); err != nil {
-		return nil, fmt.Errorf("create store: %w", err)
+	err := s.tr.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := s.sr.CreateStore(ctx, store); err != nil {
+			return fmt.Errorf("create store: %w", err)
+		}
+
+		owner := models.NewStoreMember(store.ID, store.UserID, models.StoreRoleOwner)
+		if err := s.smr.CreateStoreMember(ctx, owner); err != nil {
+			return fmt.Errorf("create store owner member: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return &models.CreateStoreResponse{
@@ -54,24 +91,11 @@ func (s *storeService) GetUserFirstStore(ctx context.Context, userID string) (*m
 		return nil, models.ErrStoreNotFound
 	}
 
-	return store.ToStoreResponse(), nil
+	return s.toStoreResponseWithRole(ctx, store, userID)
 }
 
 func (s *storeService) GetStoreByStoreID(ctx context.Context, storeId string, userID string) (*models.StoreResponse, error) {
-	store, err := s.sr.GetStoreByID(ctx, storeId)
-	if err != nil {
-		return nil, fmt.Errorf("get store by id %s: %w", storeId, err)
-	}
-
-	if store == nil {
-		return nil, models.ErrStoreNotFound
-	}
-
-	if store.UserID.String() != userID {
-		return nil, models.ErrStoreNotPertenence
-	}
-
-	return store.ToStoreResponse(), nil
+	return s.CheckPermission(ctx, storeId, userID, models.PermissionViewStore)
 }
 
 func (s *storeService) GetStoresByUserID(ctx context.Context, userID string) ([]models.StoreResponse, error) {
@@ -82,13 +106,22 @@ func (s *storeService) GetStoresByUserID(ctx context.Context, userID string) ([]
 
 	var storesResponse []models.StoreResponse
 	for _, store := range stores {
-		storesResponse = append(storesResponse, *store.ToStoreResponse())
+		resp, err := s.toStoreResponseWithRole(ctx, &store, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		storesResponse = append(storesResponse, *resp)
 	}
 
 	return storesResponse, nil
 }
 
 func (s *storeService) UpdateStore(ctx context.Context, userID, storeID, name string) error {
+	if _, err := s.CheckPermission(ctx, storeID, userID, models.PermissionManageStore); err != nil {
+		return err
+	}
+
 	store, err := s.sr.GetStoreByID(ctx, storeID)
 	if err != nil {
 		return fmt.Errorf("get store by id %s: %w", storeID, err)
@@ -98,10 +131,6 @@ func (s *storeService) UpdateStore(ctx context.Context, userID, storeID, name st
 		return models.ErrStoreNotFound
 	}
 
-	if store.UserID.String() != userID {
-		return models.ErrStoreNotPertenence
-	}
-
 	store.Name = name
 
 	if err := s.sr.UpdateStore(ctx, store); err != nil {
@@ -112,22 +141,81 @@ func (s *storeService) UpdateStore(ctx context.Context, userID, storeID, name st
 }
 
 func (s *storeService) DeleteStore(ctx context.Context, storeID string, userID string) error {
+	if _, err := s.CheckPermission(ctx, storeID, userID, models.PermissionDeleteStore); err != nil {
+		return err
+	}
+
+	if err := s.sr.DeleteStore(ctx, storeID); err != nil {
+		return fmt.Errorf("delete store: %w", err)
+	}
+
+	return nil
+}
+
+func (s *storeService) CheckPermission(ctx context.Context, storeID, userID string, permission models.StorePermission) (*models.StoreResponse, error) {
 	store, err := s.sr.GetStoreByID(ctx, storeID)
 	if err != nil {
-		return fmt.Errorf("get store by id %s: %w", storeID, err)
+		return nil, fmt.Errorf("get store by id %s: %w", storeID, err)
 	}
 
 	if store == nil {
-		return models.ErrStoreNotFound
+		return nil, models.ErrStoreNotFound
 	}
 
-	if store.UserID.String() != userID {
-		return models.ErrStoreNotPertenence
+	// A chave segue os próprios escopos, não o papel de quem a criou
+	if apiKey, ok := s.rdp.GetAPIKey(ctx); ok {
+		if apiKey.StoreID != storeID {
+			return nil, models.ErrStoreNotPertenence
+		}
+
+		if !models.APIKeyScopesAllow(apiKey.Scopes, permission) {
+			return nil, models.ErrStorePermissionDenied
+		}
+
+		return store.ToStoreResponse(), nil
 	}
 
-	if err := s.sr.DeleteStore(ctx, storeID); err != nil {
-		return fmt.Errorf("delete store: %w", err)
+	role, err := s.GetUserRole(ctx, store, userID)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	if !role.Can(permission) {
+		return nil, models.ErrStorePermissionDenied
+	}
+
+	resp := store.ToStoreResponse()
+	resp.Role = role
+
+	return resp, nil
+}
+
+// Store.UserID é dono mesmo sem membro, lojas anteriores às associações
+func (s *storeService) GetUserRole(ctx context.Context, store *models.Store, userID string) (models.StoreRole, error) {
+	if store.UserID.String() == userID {
+		return models.StoreRoleOwner, nil
+	}
+
+	member, err := s.smr.GetStoreMember(ctx, store.ID.String(), userID)
+	if err != nil {
+		return "", fmt.Errorf("get store member: %w", err)
+	}
+
+	if member == nil {
+		return "", models.ErrStoreNotPertenence
+	}
+
+	return member.Role, nil
+}
+
+func (s *storeService) toStoreResponseWithRole(ctx context.Context, store *models.Store, userID string) (*models.StoreResponse, error) {
+	role, err := s.GetUserRole(ctx, store, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := store.ToStoreResponse()
+	resp.Role = role
+
+	return resp, nil
 }
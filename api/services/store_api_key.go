@@ -0,0 +1,216 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/g-villarinho/flash-buy-api/models"
+	"github.com/g-villarinho/flash-buy-api/pkgs"
+	"github.com/g-villarinho/flash-buy-api/repositories"
+	"github.com/g-villarinho/flash-buy-api/utils"
+	"github.com/google/uuid"
+)
+
+const (
+	apiKeyPrefix     = "fbk"
+	apiKeyPrefixSize = 4
+	apiKeySecretSize = 32
+
+	apiKeyTouchInterval = time.Minute
+)
+
+type StoreAPIKeyService interface {
+	CreateAPIKey(ctx context.Context, userID, storeID string, payload models.CreateStoreAPIKeyPayload) (*models.CreateStoreAPIKeyResponse, error)
+	GetAPIKeys(ctx context.Context, userID, storeID string) ([]models.StoreAPIKeyResponse, error)
+	RevokeAPIKey(ctx context.Context, userID, storeID, apiKeyID string) error
+	Authenticate(ctx context.Context, rawKey string) (*models.StoreAPIKey, error)
+}
+
+type storeAPIKeyService struct {
+	di  *pkgs.Di
+	akr repositories.StoreAPIKeyRepository
+	sr  repositories.StoreRepository
+	ss  StoreService
+}
+
+func NewStoreAPIKeyService(di *pkgs.Di) (StoreAPIKeyService, error) {
+	akr, err := pkgs.Invoke[repositories.StoreAPIKeyRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	sr, err := pkgs.Invoke[repositories.StoreRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	ss, err := pkgs.Invoke[StoreService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &storeAPIKeyService{
+		di:  di,
+		akr: akr,
+		sr:  sr,
+		ss:  ss,
+	}, nil
+}
+
+func (s *storeAPIKeyService) CreateAPIKey(ctx context.Context, userID, storeID string, payload models.CreateStoreAPIKeyPayload) (*models.CreateStoreAPIKeyResponse, error) {
+	if _, err := s.ss.CheckPermission(ctx, storeID, userID, models.PermissionManageStore); err != nil {
+		return nil, err
+	}
+
+	if len(payload.Scopes) == 0 {
+		return nil, models.ErrInvalidAPIKeyScope
+	}
+
+	for _, scope := range payload.Scopes {
+		if !scope.IsValid() {
+			return nil, models.ErrInvalidAPIKeyScope
+		}
+	}
+
+	if payload.ExpiresAt != nil && !payload.ExpiresAt.After(time.Now()) {
+		return nil, models.ErrInvalidAPIKeyExpiration
+	}
+
+	prefix, err := generateAPIKeyPrefix()
+	if err != nil {
+		return nil, fmt.Errorf("generate api key prefix: %w", err)
+	}
+
+	secret, err := utils.GenerateRandomToken(apiKeySecretSize)
+	if err != nil {
+		return nil, fmt.Errorf("generate api key secret: %w", err)
+	}
+
+	rawKey := prefix + "_" + secret
+
+	key := models.NewStoreAPIKey(
+		uuid.MustParse(storeID),
+		uuid.MustParse(userID),
+		strings.TrimSpace(payload.Name),
+		prefix,
+		utils.HashToken(rawKey),
+		payload.Scopes,
+		payload.ExpiresAt,
+	)
+
+	if err := s.akr.CreateStoreAPIKey(ctx, key); err != nil {
+		return nil, fmt.Errorf("create store api key: %w", err)
+	}
+
+	return &models.CreateStoreAPIKeyResponse{
+		StoreAPIKeyResponse: key.ToStoreAPIKeyResponse(),
+		Key:                 rawKey,
+	}, nil
+}
+
+func (s *storeAPIKeyService) GetAPIKeys(ctx context.Context, userID, storeID string) ([]models.StoreAPIKeyResponse, error) {
+	if _, err := s.ss.CheckPermission(ctx, storeID, userID, models.PermissionManageStore); err != nil {
+		return nil, err
+	}
+
+	keys, err := s.akr.GetStoreAPIKeysByStoreID(ctx, storeID)
+	if err != nil {
+		return nil, fmt.Errorf("get store api keys by store id %s: %w", storeID, err)
+	}
+
+	resp := make([]models.StoreAPIKeyResponse, len(keys))
+	for i := range keys {
+		resp[i] = keys[i].ToStoreAPIKeyResponse()
+	}
+
+	return resp, nil
+}
+
+func (s *storeAPIKeyService) RevokeAPIKey(ctx context.Context, userID, storeID, apiKeyID string) error {
+	if _, err := s.ss.CheckPermission(ctx, storeID, userID, models.PermissionManageStore); err != nil {
+		return err
+	}
+
+	key, err := s.akr.GetStoreAPIKeyByID(ctx, apiKeyID)
+	if err != nil {
+		return fmt.Errorf("get store api key by id %s: %w", apiKeyID, err)
+	}
+
+	if key == nil || key.StoreID.String() != storeID {
+		return models.ErrAPIKeyNotFound
+	}
+
+	revoked, err := s.akr.RevokeStoreAPIKey(ctx, apiKeyID, time.Now())
+	if err != nil {
+		return fmt.Errorf("revoke store api key: %w", err)
+	}
+
+	if !revoked {
+		return models.ErrAPIKeyNotFound
+	}
+
+	return nil
+}
+
+func (s *storeAPIKeyService) Authenticate(ctx context.Context, rawKey string) (*models.StoreAPIKey, error) {
+	parts := strings.SplitN(rawKey, "_", 3)
+	if len(parts) != 3 || parts[0] != apiKeyPrefix || parts[2] == "" {
+		return nil, models.ErrAPIKeyInvalid
+	}
+
+	key, err := s.akr.GetStoreAPIKeyByPrefix(ctx, parts[0]+"_"+parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("get store api key by prefix: %w", err)
+	}
+
+	if key == nil {
+		return nil, models.ErrAPIKeyInvalid
+	}
+
+	if subtle.ConstantTimeCompare([]byte(key.SecretHash), []byte(utils.HashToken(rawKey))) != 1 {
+		return nil, models.ErrAPIKeyInvalid
+	}
+
+	if !key.IsActive() {
+		return nil, models.ErrAPIKeyInvalid
+	}
+
+	// A chave deixa de valer quando quem a criou sai da loja
+	store, err := s.sr.GetStoreByID(ctx, key.StoreID.String())
+	if err != nil {
+		return nil, fmt.Errorf("get store by id %s: %w", key.StoreID, err)
+	}
+
+	if store == nil {
+		return nil, models.ErrAPIKeyInvalid
+	}
+
+	if _, err := s.ss.GetUserRole(ctx, store, key.CreatedByID.String()); err != nil {
+		if err == models.ErrStoreNotPertenence {
+			return nil, models.ErrAPIKeyInvalid
+		}
+		return nil, err
+	}
+
+	now := time.Now()
+	if err := s.akr.TouchStoreAPIKey(ctx, key.ID.String(), now, now.Add(-apiKeyTouchInterval)); err != nil {
+		slog.Error("touch store api key", "apiKeyID", key.ID, "error", err)
+	}
+
+	return key, nil
+}
+
+func generateAPIKeyPrefix() (string, error) {
+	bytes := make([]byte, apiKeyPrefixSize)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+
+	return apiKeyPrefix + "_" + hex.EncodeToString(bytes), nil
+}
@@ -0,0 +1,372 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/g-villarinho/flash-buy-api/models"
+	"github.com/g-villarinho/flash-buy-api/persistence"
+	"github.com/g-villarinho/flash-buy-api/pkgs"
+	"github.com/g-villarinho/flash-buy-api/repositories"
+	"github.com/g-villarinho/flash-buy-api/utils"
+	"github.com/google/uuid"
+)
+
+const (
+	storeInvitationExpiration = 7 * 24 * time.Hour
+	storeInvitationTokenSize  = 32
+)
+
+type StoreMemberService interface {
+	GetMembers(ctx context.Context, userID, storeID string) ([]models.StoreMemberResponse, error)
+	UpdateMemberRole(ctx context.Context, userID, storeID, memberID string, role models.StoreRole) (*models.StoreMemberResponse, error)
+	RemoveMember(ctx context.Context, userID, storeID, memberID string) error
+	InviteMember(ctx context.Context, userID, storeID string, payload models.InviteStoreMemberPayload) (*models.StoreInvitationResponse, error)
+	GetInvitations(ctx context.Context, userID, storeID string) ([]models.StoreInvitationResponse, error)
+	RevokeInvitation(ctx context.Context, userID, storeID, invitationID string) error
+	AcceptInvitation(ctx context.Context, userID, token string) (*models.StoreResponse, error)
+}
+
+type storeMemberService struct {
+	di  *pkgs.Di
+	smr repositories.StoreMemberRepository
+	sir repositories.StoreInvitationRepository
+	sr  repositories.StoreRepository
+	ss  StoreService
+	us  UserService
+	eqs EmailQueueService
+	tr  persistence.Transactor
+}
+
+func NewStoreMemberService(di *pkgs.Di) (StoreMemberService, error) {
+	smr, err := pkgs.Invoke[repositories.StoreMemberRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	sir, err := pkgs.Invoke[repositories.StoreInvitationRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	sr, err := pkgs.Invoke[repositories.StoreRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	ss, err := pkgs.Invoke[StoreService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	us, err := pkgs.Invoke[UserService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	eqs, err := pkgs.Invoke[EmailQueueService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	obs, err := pkgs.Invoke[OutboxService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	tr, err := pkgs.Invoke[persistence.Transactor](di)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &storeMemberService{
+		di:  di,
+		smr: smr,
+		sir: sir,
+		sr:  sr,
+		ss:  ss,
+		us:  us,
+		eqs: eqs,
+		tr:  tr,
+	}
+
+	obs.Subscribe(models.DomainEventStoreInvitationCreated, s.queueInvitationEmail)
+
+	return s, nil
+}
+
+func (s *storeMemberService) GetMembers(ctx context.Context, userID, storeID string) ([]models.StoreMemberResponse, error) {
+	if _, err := s.ss.CheckPermission(ctx, storeID, userID, models.PermissionViewStore); err != nil {
+		return nil, err
+	}
+
+	members, err := s.smr.GetStoreMembersByStoreID(ctx, storeID)
+	if err != nil {
+		return nil, fmt.Errorf("get store members by store id %s: %w", storeID, err)
+	}
+
+	resp := make([]models.StoreMemberResponse, len(members))
+	for i, member := range members {
+		resp[i] = member.ToStoreMemberResponse()
+	}
+
+	return resp, nil
+}
+
+func (s *storeMemberService) UpdateMemberRole(ctx context.Context, userID, storeID, memberID string, role models.StoreRole) (*models.StoreMemberResponse, error) {
+	if !role.IsValid() {
+		return nil, models.ErrInvalidStoreRole
+	}
+
+	store, err := s.ss.CheckPermission(ctx, storeID, userID, models.PermissionManageStore)
+	if err != nil {
+		return nil, err
+	}
+
+	member, err := s.getStoreMember(ctx, storeID, memberID)
+	if err != nil {
+		return nil, err
+	}
+
+	if member.Role == models.StoreRoleOwner || role == models.StoreRoleOwner {
+		return nil, models.ErrStoreOwnerImmutable
+	}
+
+	if !store.Role.CanAssign(member.Role) || !store.Role.CanAssign(role) {
+		return nil, models.ErrStorePermissionDenied
+	}
+
+	member.Role = role
+	member.UpdatedAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+	if err := s.smr.UpdateStoreMember(ctx, member); err != nil {
+		return nil, fmt.Errorf("update store member: %w", err)
+	}
+
+	resp := member.ToStoreMemberResponse()
+	return &resp, nil
+}
+
+func (s *storeMemberService) RemoveMember(ctx context.Context, userID, storeID, memberID string) error {
+	store, err := s.ss.CheckPermission(ctx, storeID, userID, models.PermissionViewStore)
+	if err != nil {
+		return err
+	}
+
+	member, err := s.getStoreMember(ctx, storeID, memberID)
+	if err != nil {
+		return err
+	}
+
+	if member.Role == models.StoreRoleOwner {
+		return models.ErrStoreOwnerImmutable
+	}
+
+	if member.UserID.String() != userID && !store.Role.CanAssign(member.Role) {
+		return models.ErrStorePermissionDenied
+	}
+
+	if err := s.smr.DeleteStoreMember(ctx, memberID); err != nil {
+		return fmt.Errorf("delete store member: %w", err)
+	}
+
+	return nil
+}
+
+func (s *storeMemberService) InviteMember(ctx context.Context, userID, storeID string, payload models.InviteStoreMemberPayload) (*models.StoreInvitationResponse, error) {
+	if !payload.Role.IsValid() {
+		return nil, models.ErrInvalidStoreRole
+	}
+
+	store, err := s.ss.CheckPermission(ctx, storeID, userID, models.PermissionManageStore)
+	if err != nil {
+		return nil, err
+	}
+
+	if !store.Role.CanAssign(payload.Role) {
+		return nil, models.ErrStorePermissionDenied
+	}
+
+	invitee, err := s.us.GetUserByEmail(ctx, strings.ToLower(strings.TrimSpace(payload.Email)))
+	if err != nil && err != models.ErrUserNotFound {
+		return nil, err
+	}
+
+	if invitee != nil {
+		member, err := s.smr.GetStoreMember(ctx, storeID, invitee.ID.String())
+		if err != nil {
+			return nil, fmt.Errorf("get store member: %w", err)
+		}
+
+		if member != nil {
+			return nil, models.ErrStoreMemberAlreadyExists
+		}
+	}
+
+	// Só reserva o hash até o job de e-mail emitir o token do link
+	token, err := utils.GenerateRandomToken(storeInvitationTokenSize)
+	if err != nil {
+		return nil, fmt.Errorf("generate invitation token: %w", err)
+	}
+
+	invitation := models.NewStoreInvitation(
+		store.ID,
+		uuid.MustParse(userID),
+		payload.Email,
+		payload.Role,
+		utils.HashToken(token),
+		time.Now().Add(storeInvitationExpiration),
+	)
+
+	event, err := models.NewDomainEvent(models.DomainEventStoreInvitationCreated, models.StoreInvitationEmailJob{
+		InvitationID: invitation.ID,
+		Email:        invitation.Email,
+		Data: models.StoreInvitationEmailData{
+			StoreName: store.Name,
+			Role:      invitation.Role,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.sir.CreateStoreInvitation(ctx, invitation, event); err != nil {
+		return nil, fmt.Errorf("create store invitation: %w", err)
+	}
+
+	resp := invitation.ToStoreInvitationResponse()
+	return &resp, nil
+}
+
+func (s *storeMemberService) GetInvitations(ctx context.Context, userID, storeID string) ([]models.StoreInvitationResponse, error) {
+	if _, err := s.ss.CheckPermission(ctx, storeID, userID, models.PermissionManageStore); err != nil {
+		return nil, err
+	}
+
+	invitations, err := s.sir.GetPendingInvitationsByStoreID(ctx, storeID)
+	if err != nil {
+		return nil, fmt.Errorf("get pending invitations by store id %s: %w", storeID, err)
+	}
+
+	resp := make([]models.StoreInvitationResponse, len(invitations))
+	for i, invitation := range invitations {
+		resp[i] = invitation.ToStoreInvitationResponse()
+	}
+
+	return resp, nil
+}
+
+func (s *storeMemberService) RevokeInvitation(ctx context.Context, userID, storeID, invitationID string) error {
+	if _, err := s.ss.CheckPermission(ctx, storeID, userID, models.PermissionManageStore); err != nil {
+		return err
+	}
+
+	invitation, err := s.sir.GetStoreInvitationByID(ctx, invitationID)
+	if err != nil {
+		return fmt.Errorf("get store invitation by id %s: %w", invitationID, err)
+	}
+
+	if invitation == nil || invitation.StoreID.String() != storeID || !invitation.IsPending() {
+		return models.ErrInvitationNotFound
+	}
+
+	invitation.RevokedAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+	if err := s.sir.UpdateStoreInvitation(ctx, invitation); err != nil {
+		return fmt.Errorf("update store invitation: %w", err)
+	}
+
+	return nil
+}
+
+// Um link encaminhado não dá acesso a outra conta
+func (s *storeMemberService) AcceptInvitation(ctx context.Context, userID, token string) (*models.StoreResponse, error) {
+	invitation, err := s.sir.GetStoreInvitationByTokenHash(ctx, utils.HashToken(token))
+	if err != nil {
+		return nil, fmt.Errorf("get store invitation by token: %w", err)
+	}
+
+	if invitation == nil || !invitation.IsPending() {
+		return nil, models.ErrInvitationNotFound
+	}
+
+	if invitation.IsExpired() {
+		return nil, models.ErrInvitationExpired
+	}
+
+	user, err := s.us.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.EqualFold(user.Email, invitation.Email) {
+		return nil, models.ErrInvitationEmailMismatch
+	}
+
+	store, err := s.sr.GetStoreByID(ctx, invitation.StoreID.String())
+	if err != nil {
+		return nil, fmt.Errorf("get store by id %s: %w", invitation.StoreID, err)
+	}
+
+	if store == nil {
+		return nil, models.ErrStoreNotFound
+	}
+
+	if _, err := s.ss.GetUserRole(ctx, store, userID); err == nil {
+		return nil, models.ErrStoreMemberAlreadyExists
+	} else if err != models.ErrStoreNotPertenence {
+		return nil, err
+	}
+
+	member := models.NewStoreMember(invitation.StoreID, user.ID, invitation.Role)
+	invitation.AcceptedAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+	err = s.tr.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := s.smr.CreateStoreMember(ctx, member); err != nil {
+			return fmt.Errorf("create store member: %w", err)
+		}
+
+		if err := s.sir.UpdateStoreInvitation(ctx, invitation); err != nil {
+			return fmt.Errorf("update store invitation: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := store.ToStoreResponse()
+	resp.Role = member.Role
+
+	return resp, nil
+}
+
+func (s *storeMemberService) getStoreMember(ctx context.Context, storeID, memberID string) (*models.StoreMember, error) {
+	member, err := s.smr.GetStoreMemberByID(ctx, memberID)
+	if err != nil {
+		return nil, fmt.Errorf("get store member by id %s: %w", memberID, err)
+	}
+
+	if member == nil || member.StoreID.String() != storeID {
+		return nil, models.ErrStoreMemberNotFound
+	}
+
+	return member, nil
+}
+
+func (s *storeMemberService) queueInvitationEmail(ctx context.Context, event models.DomainEvent) error {
+	var job models.StoreInvitationEmailJob
+	if err := event.Decode(&job); err != nil {
+		return fmt.Errorf("decode store invitation event: %w", err)
+	}
+
+	if err := s.eqs.QueueStoreInvitationEmail(ctx, job.InvitationID, job.Email, job.Data); err != nil {
+		return fmt.Errorf("queue store invitation email: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,211 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/g-villarinho/flash-buy-api/models"
+	"github.com/g-villarinho/flash-buy-api/pkgs"
+	"github.com/g-villarinho/flash-buy-api/repositories"
+)
+
+type StorefrontService interface {
+	GetStore(ctx context.Context, storeID string) (*models.PublicStoreResponse, error)
+	GetBillboards(ctx context.Context, storeID string) ([]models.PublicBillboardResponse, error)
+	GetActiveBillboard(ctx context.Context, storeID, categoryID string) (*models.PublicBillboardResponse, error)
+	GetCategories(ctx context.Context, storeID string) ([]models.PublicCategoryResponse, error)
+	GetCategoryTree(ctx context.Context, storeID string) ([]models.CategoryTreeResponse, error)
+	GetProductsPagedList(ctx context.Context, storeID string, pag models.ProductPagination) (*models.PaginatedResponse, error)
+	GetProductByID(ctx context.Context, storeID, productID string) (*models.PublicProductResponse, error)
+}
+
+type storefrontService struct {
+	di *pkgs.Di
+	sr repositories.StoreRepository
+	br repositories.BillboardRepository
+	cr repositories.CategoryRepository
+	pr repositories.ProductRepository
+}
+
+func NewStorefrontService(di *pkgs.Di) (StorefrontService, error) {
+	sr, err := pkgs.Invoke[repositories.StoreRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	br, err := pkgs.Invoke[repositories.BillboardRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	cr, err := pkgs.Invoke[repositories.CategoryRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	pr, err := pkgs.Invoke[repositories.ProductRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &storefrontService{
+		di: di,
+		sr: sr,
+		br: br,
+		cr: cr,
+		pr: pr,
+	}, nil
+}
+
+func (s *storefrontService) GetStore(ctx context.Context, storeID string) (*models.PublicStoreResponse, error) {
+	store, err := s.getStore(ctx, storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := store.ToPublicStoreResponse()
+
+	return &response, nil
+}
+
+func (s *storefrontService) GetBillboards(ctx context.Context, storeID string) ([]models.PublicBillboardResponse, error) {
+	if _, err := s.getStore(ctx, storeID); err != nil {
+		return nil, err
+	}
+
+	billboards, err := s.br.GetActiveByStoreID(ctx, storeID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("get active billboards by store id %s: %w", storeID, err)
+	}
+
+	responses := make([]models.PublicBillboardResponse, len(billboards))
+	for i, billboard := range billboards {
+		responses[i] = billboard.ToPublicBillboardResponse()
+	}
+
+	return responses, nil
+}
+
+func (s *storefrontService) GetActiveBillboard(ctx context.Context, storeID, categoryID string) (*models.PublicBillboardResponse, error) {
+	if _, err := s.getStore(ctx, storeID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	if categoryID != "" {
+		category, err := s.cr.GetCategoryByID(ctx, categoryID)
+		if err != nil {
+			return nil, fmt.Errorf("get category by id %s: %w", categoryID, err)
+		}
+
+		if category == nil || category.StoreID.String() != storeID {
+			return nil, models.ErrCategoryNotFound
+		}
+
+		if category.Billboard.IsActiveAt(now) {
+			resp := category.Billboard.ToPublicBillboardResponse()
+			return &resp, nil
+		}
+	}
+
+	billboards, err := s.br.GetActiveByStoreID(ctx, storeID, now)
+	if err != nil {
+		return nil, fmt.Errorf("get active billboards by store id %s: %w", storeID, err)
+	}
+
+	if len(billboards) == 0 {
+		return nil, models.ErrBillboardNotFound
+	}
+
+	resp := billboards[0].ToPublicBillboardResponse()
+	return &resp, nil
+}
+
+func (s *storefrontService) GetCategories(ctx context.Context, storeID string) ([]models.PublicCategoryResponse, error) {
+	if _, err := s.getStore(ctx, storeID); err != nil {
+		return nil, err
+	}
+
+	categories, err := s.cr.GetAllByStoreID(ctx, storeID)
+	if err != nil {
+		return nil, fmt.Errorf("get categories by store id %s: %w", storeID, err)
+	}
+
+	responses := make([]models.PublicCategoryResponse, len(categories))
+	for i, category := range categories {
+		responses[i] = category.ToPublicCategoryResponse()
+	}
+
+	return responses, nil
+}
+
+func (s *storefrontService) GetCategoryTree(ctx context.Context, storeID string) ([]models.CategoryTreeResponse, error) {
+	if _, err := s.getStore(ctx, storeID); err != nil {
+		return nil, err
+	}
+
+	categories, err := s.cr.GetCategoryTree(ctx, storeID)
+	if err != nil {
+		return nil, fmt.Errorf("get category tree by store id %s: %w", storeID, err)
+	}
+
+	return models.BuildCategoryTree(categories), nil
+}
+
+func (s *storefrontService) GetProductsPagedList(ctx context.Context, storeID string, pag models.ProductPagination) (*models.PaginatedResponse, error) {
+	if _, err := s.getStore(ctx, storeID); err != nil {
+		return nil, err
+	}
+
+	isArchived := false
+	pag.IsArchived = &isArchived
+
+	result, err := s.pr.GetProductsPagedList(ctx, storeID, pag)
+	if err != nil {
+		return nil, fmt.Errorf("get products paged list: %w", err)
+	}
+
+	products := *result.Data.(*[]models.Product)
+	responses := make([]models.PublicProductResponse, len(products))
+	for i, product := range products {
+		responses[i] = product.ToPublicProductResponse()
+	}
+
+	return &models.PaginatedResponse{
+		Data:       responses,
+		Total:      result.Total,
+		TotalPages: result.TotalPages,
+		Page:       result.Page,
+		Limit:      result.Limit,
+	}, nil
+}
+
+func (s *storefrontService) GetProductByID(ctx context.Context, storeID, productID string) (*models.PublicProductResponse, error) {
+	product, err := s.pr.GetProductDetailsByID(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("get product by id %s: %w", productID, err)
+	}
+
+	if product == nil || product.StoreID.String() != storeID || product.IsArchived {
+		return nil, models.ErrProductNotFound
+	}
+
+	response := product.ToPublicProductResponse()
+
+	return &response, nil
+}
+
+func (s *storefrontService) getStore(ctx context.Context, storeID string) (*models.Store, error) {
+	store, err := s.sr.GetStoreByID(ctx, storeID)
+	if err != nil {
+		return nil, fmt.Errorf("get store by id %s: %w", storeID, err)
+	}
+
+	if store == nil {
+		return nil, models.ErrStoreNotFound
+	}
+
+	return store, nil
+}
@@ -5,37 +5,37 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/g-villarinho/flash-buy-api/config"
+	"github.com/g-villarinho/flash-buy-api/models"
 	"github.com/g-villarinho/flash-buy-api/pkgs"
 	"github.com/golang-jwt/jwt/v5"
 )
 
 type TokenService interface {
 	CreateToken(ctx context.Context, userID, sessionID, email string, iat, exp time.Time) (string, error)
+	CreateSessionToken(ctx context.Context, session models.Session) (string, error)
+	CreateMagicLinkToken(ctx context.Context, otp *models.OTP) (string, error)
+	ParseMagicLinkToken(ctx context.Context, token string) (*models.MagicLinkClaims, error)
 }
 
 type tokenService struct {
 	di *pkgs.Di
-	kp pkgs.EcdsaKeyPair
+	kr pkgs.KeyRing
 }
 
 func NewTokenService(di *pkgs.Di) (TokenService, error) {
-	ecdsaKeyPair, err := pkgs.Invoke[pkgs.EcdsaKeyPair](di)
+	keyRing, err := pkgs.Invoke[pkgs.KeyRing](di)
 	if err != nil {
-		return nil, fmt.Errorf("invoke ecdsa key pair: %w", err)
+		return nil, fmt.Errorf("invoke key ring: %w", err)
 	}
 
 	return &tokenService{
 		di: di,
-		kp: ecdsaKeyPair,
+		kr: keyRing,
 	}, nil
 }
 
 func (t *tokenService) CreateToken(ctx context.Context, userID string, sessionID string, email string, iat time.Time, exp time.Time) (string, error) {
-	privateKey, err := t.kp.ParseECDSAPrivateKey(config.Env.Key.PrivateKey)
-	if err != nil {
-		return "", err
-	}
+	signingKey := t.kr.ActiveKey()
 
 	claims := jwt.MapClaims{
 		"iss":   "xp-life-app",
@@ -53,10 +53,84 @@ func (t *tokenService) CreateToken(ctx context.Context, userID string, sessionID
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodES256, claims)
-	signedToken, err := token.SignedString(privateKey)
+	token.Header["kid"] = signingKey.ID
+
+	signedToken, err := token.SignedString(signingKey.PrivateKey)
+	if err != nil {
+		return "", fmt.Errorf("sign token: %w", err)
+	}
+
+	return signedToken, nil
+}
+
+func (t *tokenService) CreateSessionToken(ctx context.Context, session models.Session) (string, error) {
+	signingKey := t.kr.ActiveKey()
+
+	claims := jwt.MapClaims{
+		"iss":   "xp-life-app",
+		"sub":   session.UserID.String(),
+		"sid":   session.ID.String(),
+		"email": session.Email,
+		"amr":   session.AuthMethods,
+		"iat":   session.CreatedAt.Unix(),
+		"exp":   session.ExpiresAt.Unix(),
+	}
+
+	if session.MFAVerifiedAt.Valid {
+		claims["mfa_at"] = session.MFAVerifiedAt.Time.Unix()
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, claims)
+	token.Header["kid"] = signingKey.ID
+
+	signedToken, err := token.SignedString(signingKey.PrivateKey)
 	if err != nil {
 		return "", fmt.Errorf("sign token: %w", err)
 	}
 
 	return signedToken, nil
 }
+
+// Um reenvio troca o código e invalida os links anteriores
+func (t *tokenService) CreateMagicLinkToken(ctx context.Context, otp *models.OTP) (string, error) {
+	signingKey := t.kr.ActiveKey()
+
+	claims := models.MagicLinkClaims{
+		OTPID: otp.ID.String(),
+		Code:  otp.Code,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    "xp-life-app",
+			Audience:  jwt.ClaimStrings{models.MagicLinkAudience},
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ExpiresAt: jwt.NewNumericDate(otp.ExpiresAt),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, claims)
+	token.Header["kid"] = signingKey.ID
+
+	signedToken, err := token.SignedString(signingKey.PrivateKey)
+	if err != nil {
+		return "", fmt.Errorf("sign magic link token: %w", err)
+	}
+
+	return signedToken, nil
+}
+
+func (t *tokenService) ParseMagicLinkToken(ctx context.Context, tokenString string) (*models.MagicLinkClaims, error) {
+	claims := &models.MagicLinkClaims{}
+
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+		return t.kr.PublicKey(kid)
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodES256.Alg()}),
+		jwt.WithAudience(models.MagicLinkAudience),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, models.ErrMagicLinkInvalid
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,338 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/g-villarinho/flash-buy-api/models"
+	"github.com/g-villarinho/flash-buy-api/persistence"
+	"github.com/g-villarinho/flash-buy-api/pkgs"
+	"github.com/g-villarinho/flash-buy-api/repositories"
+	"github.com/g-villarinho/flash-buy-api/utils"
+	"github.com/google/uuid"
+)
+
+const (
+	totpIssuer = "XP Life"
+
+	totpSkew = 1
+
+	totpMaxFailedAttempts = 5
+	totpLockDuration      = 15 * time.Minute
+
+	recoveryCodeCount  = 10
+	recoveryCodeLength = 10
+)
+
+type TwoFactorService interface {
+	GetStatus(ctx context.Context, userID string) (*models.TwoFactorStatusResponse, error)
+	StartEnrollment(ctx context.Context, userID string) (*models.TOTPEnrollmentResponse, error)
+	ConfirmEnrollment(ctx context.Context, userID, code string) (*models.RecoveryCodesResponse, error)
+	Disable(ctx context.Context, userID string) error
+	RegenerateRecoveryCodes(ctx context.Context, userID string) (*models.RecoveryCodesResponse, error)
+	IsEnabled(ctx context.Context, userID string) (bool, error)
+	Verify(ctx context.Context, userID string, payload models.VerifySecondFactorPayload) (models.AuthMethod, error)
+}
+
+type twoFactorService struct {
+	di  *pkgs.Di
+	utr repositories.UserTOTPRepository
+	rcr repositories.RecoveryCodeRepository
+	us  UserService
+	tr  persistence.Transactor
+}
+
+func NewTwoFactorService(di *pkgs.Di) (TwoFactorService, error) {
+	utr, err := pkgs.Invoke[repositories.UserTOTPRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	rcr, err := pkgs.Invoke[repositories.RecoveryCodeRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	us, err := pkgs.Invoke[UserService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	tr, err := pkgs.Invoke[persistence.Transactor](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &twoFactorService{
+		di:  di,
+		utr: utr,
+		rcr: rcr,
+		us:  us,
+		tr:  tr,
+	}, nil
+}
+
+func (t *twoFactorService) GetStatus(ctx context.Context, userID string) (*models.TwoFactorStatusResponse, error) {
+	totp, err := t.utr.GetUserTOTPByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get user totp by user id %s: %w", userID, err)
+	}
+
+	if totp == nil || !totp.IsEnabled() {
+		return &models.TwoFactorStatusResponse{}, nil
+	}
+
+	remaining, err := t.rcr.CountUnusedRecoveryCodes(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("count unused recovery codes: %w", err)
+	}
+
+	return &models.TwoFactorStatusResponse{
+		Enabled:                true,
+		ConfirmedAt:            &totp.ConfirmedAt.Time,
+		RemainingRecoveryCodes: remaining,
+	}, nil
+}
+
+func (t *twoFactorService) StartEnrollment(ctx context.Context, userID string) (*models.TOTPEnrollmentResponse, error) {
+	user, err := t.us.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := t.utr.GetUserTOTPByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get user totp by user id %s: %w", userID, err)
+	}
+
+	if current != nil {
+		if current.IsEnabled() {
+			return nil, models.ErrTOTPAlreadyEnabled
+		}
+
+		if err := t.utr.DeleteUserTOTPByUserID(ctx, userID); err != nil {
+			return nil, fmt.Errorf("delete pending user totp: %w", err)
+		}
+	}
+
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		return nil, fmt.Errorf("generate totp secret: %w", err)
+	}
+
+	if err := t.utr.CreateUserTOTP(ctx, models.NewUserTOTP(user.ID, secret)); err != nil {
+		return nil, fmt.Errorf("create user totp: %w", err)
+	}
+
+	return &models.TOTPEnrollmentResponse{
+		Secret:          secret,
+		ProvisioningURI: utils.TOTPProvisioningURI(totpIssuer, user.Email, secret),
+	}, nil
+}
+
+func (t *twoFactorService) ConfirmEnrollment(ctx context.Context, userID, code string) (*models.RecoveryCodesResponse, error) {
+	totp, err := t.utr.GetUserTOTPByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get user totp by user id %s: %w", userID, err)
+	}
+
+	if totp == nil {
+		return nil, models.ErrTOTPNotEnabled
+	}
+
+	if totp.IsEnabled() {
+		return nil, models.ErrTOTPAlreadyEnabled
+	}
+
+	if totp.IsLocked() {
+		return nil, models.ErrTOTPLocked
+	}
+
+	step, ok := utils.ValidateTOTP(totp.Secret, strings.TrimSpace(code), time.Now(), totpSkew)
+	if !ok {
+		return nil, t.registerFailure(ctx, totp)
+	}
+
+	var resp *models.RecoveryCodesResponse
+	err = t.tr.WithTransaction(ctx, func(ctx context.Context) error {
+		confirmed, err := t.utr.ConfirmUserTOTP(ctx, totp.ID.String(), step, time.Now())
+		if err != nil {
+			return fmt.Errorf("confirm user totp: %w", err)
+		}
+
+		if !confirmed {
+			return models.ErrTOTPAlreadyEnabled
+		}
+
+		resp, err = t.replaceRecoveryCodes(ctx, totp.UserID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (t *twoFactorService) Disable(ctx context.Context, userID string) error {
+	totp, err := t.utr.GetUserTOTPByUserID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("get user totp by user id %s: %w", userID, err)
+	}
+
+	if totp == nil || !totp.IsEnabled() {
+		return models.ErrTOTPNotEnabled
+	}
+
+	return t.tr.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := t.utr.DeleteUserTOTPByUserID(ctx, userID); err != nil {
+			return fmt.Errorf("delete user totp: %w", err)
+		}
+
+		if err := t.rcr.DeleteRecoveryCodesByUserID(ctx, userID); err != nil {
+			return fmt.Errorf("delete recovery codes: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (t *twoFactorService) RegenerateRecoveryCodes(ctx context.Context, userID string) (*models.RecoveryCodesResponse, error) {
+	totp, err := t.utr.GetUserTOTPByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get user totp by user id %s: %w", userID, err)
+	}
+
+	if totp == nil || !totp.IsEnabled() {
+		return nil, models.ErrTOTPNotEnabled
+	}
+
+	return t.replaceRecoveryCodes(ctx, totp.UserID)
+}
+
+func (t *twoFactorService) IsEnabled(ctx context.Context, userID string) (bool, error) {
+	totp, err := t.utr.GetUserTOTPByUserID(ctx, userID)
+	if err != nil {
+		return false, fmt.Errorf("get user totp by user id %s: %w", userID, err)
+	}
+
+	return totp != nil && totp.IsEnabled(), nil
+}
+
+func (t *twoFactorService) Verify(ctx context.Context, userID string, payload models.VerifySecondFactorPayload) (models.AuthMethod, error) {
+	totp, err := t.utr.GetUserTOTPByUserID(ctx, userID)
+	if err != nil {
+		return "", fmt.Errorf("get user totp by user id %s: %w", userID, err)
+	}
+
+	if totp == nil || !totp.IsEnabled() {
+		return "", models.ErrTOTPNotEnabled
+	}
+
+	if totp.IsLocked() {
+		return "", models.ErrTOTPLocked
+	}
+
+	if payload.RecoveryCode != "" {
+		return t.useRecoveryCode(ctx, totp, payload.RecoveryCode)
+	}
+
+	step, ok := utils.ValidateTOTP(totp.Secret, strings.TrimSpace(payload.Code), time.Now(), totpSkew)
+	if !ok {
+		return "", t.registerFailure(ctx, totp)
+	}
+
+	used, err := t.utr.UseTOTPStep(ctx, totp.ID.String(), step)
+	if err != nil {
+		return "", fmt.Errorf("use totp step: %w", err)
+	}
+
+	if !used {
+		return "", t.registerFailure(ctx, totp)
+	}
+
+	return models.AuthMethodTOTP, nil
+}
+
+func (t *twoFactorService) useRecoveryCode(ctx context.Context, totp *models.UserTOTP, code string) (models.AuthMethod, error) {
+	used, err := t.rcr.UseRecoveryCode(ctx, totp.UserID.String(), utils.HashToken(normalizeRecoveryCode(code)), time.Now())
+	if err != nil {
+		return "", fmt.Errorf("use recovery code: %w", err)
+	}
+
+	if !used {
+		if err := t.registerFailure(ctx, totp); err == models.ErrTOTPLocked {
+			return "", err
+		}
+
+		return "", models.ErrRecoveryCodeInvalid
+	}
+
+	return models.AuthMethodRecoveryCode, nil
+}
+
+func (t *twoFactorService) registerFailure(ctx context.Context, totp *models.UserTOTP) error {
+	if err := t.utr.RegisterTOTPFailure(ctx, totp.ID.String(), totpMaxFailedAttempts, time.Now().Add(totpLockDuration)); err != nil {
+		return fmt.Errorf("register totp failure: %w", err)
+	}
+
+	if totp.FailedAttempts+1 >= totpMaxFailedAttempts {
+		return models.ErrTOTPLocked
+	}
+
+	return models.ErrTOTPInvalid
+}
+
+func (t *twoFactorService) replaceRecoveryCodes(ctx context.Context, userID uuid.UUID) (*models.RecoveryCodesResponse, error) {
+	plain := make([]string, recoveryCodeCount)
+	codes := make([]models.RecoveryCode, recoveryCodeCount)
+
+	for i := range plain {
+		code, err := generateRecoveryCode()
+		if err != nil {
+			return nil, fmt.Errorf("generate recovery code: %w", err)
+		}
+
+		plain[i] = code
+		codes[i] = models.NewRecoveryCode(userID, utils.HashToken(normalizeRecoveryCode(code)))
+	}
+
+	err := t.tr.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := t.rcr.DeleteRecoveryCodesByUserID(ctx, userID.String()); err != nil {
+			return fmt.Errorf("delete recovery codes: %w", err)
+		}
+
+		if err := t.rcr.CreateRecoveryCodes(ctx, codes); err != nil {
+			return fmt.Errorf("create recovery codes: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.RecoveryCodesResponse{RecoveryCodes: plain}, nil
+}
+
+func generateRecoveryCode() (string, error) {
+	code := make([]byte, recoveryCodeLength)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(alphanumericCharset))))
+		if err != nil {
+			return "", err
+		}
+		code[i] = alphanumericCharset[n.Int64()]
+	}
+
+	half := recoveryCodeLength / 2
+	return string(code[:half]) + "-" + string(code[half:]), nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	return strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+}
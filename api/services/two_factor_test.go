@@ -0,0 +1,219 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/g-villarinho/flash-buy-api/models"
+	"github.com/g-villarinho/flash-buy-api/utils"
+	"github.com/google/uuid"
+)
+
+type fakeUserTOTPRepository struct {
+	totp *models.UserTOTP
+}
+
+func (f *fakeUserTOTPRepository) CreateUserTOTP(ctx context.Context, totp *models.UserTOTP) error {
+	f.totp = totp
+	return nil
+}
+
+func (f *fakeUserTOTPRepository) GetUserTOTPByUserID(ctx context.Context, userID string) (*models.UserTOTP, error) {
+	if f.totp == nil || f.totp.UserID.String() != userID {
+		return nil, nil
+	}
+
+	totp := *f.totp
+	return &totp, nil
+}
+
+func (f *fakeUserTOTPRepository) ConfirmUserTOTP(ctx context.Context, ID string, step int64, confirmedAt time.Time) (bool, error) {
+	if f.totp.ConfirmedAt.Valid {
+		return false, nil
+	}
+
+	f.totp.ConfirmedAt = sql.NullTime{Time: confirmedAt, Valid: true}
+	f.totp.LastUsedStep = step
+	return true, nil
+}
+
+func (f *fakeUserTOTPRepository) UseTOTPStep(ctx context.Context, ID string, step int64) (bool, error) {
+	if f.totp.LastUsedStep >= step {
+		return false, nil
+	}
+
+	f.totp.LastUsedStep = step
+	f.totp.FailedAttempts = 0
+	return true, nil
+}
+
+func (f *fakeUserTOTPRepository) RegisterTOTPFailure(ctx context.Context, ID string, maxAttempts int, lockedUntil time.Time) error {
+	f.totp.FailedAttempts++
+	if f.totp.FailedAttempts >= maxAttempts {
+		f.totp.FailedAttempts = 0
+		f.totp.LockedUntil = sql.NullTime{Time: lockedUntil, Valid: true}
+	}
+	return nil
+}
+
+func (f *fakeUserTOTPRepository) DeleteUserTOTPByUserID(ctx context.Context, userID string) error {
+	f.totp = nil
+	return nil
+}
+
+type fakeRecoveryCodeRepository struct {
+	codes []models.RecoveryCode
+}
+
+func (f *fakeRecoveryCodeRepository) CreateRecoveryCodes(ctx context.Context, codes []models.RecoveryCode) error {
+	f.codes = append(f.codes, codes...)
+	return nil
+}
+
+func (f *fakeRecoveryCodeRepository) UseRecoveryCode(ctx context.Context, userID, codeHash string, usedAt time.Time) (bool, error) {
+	for i, code := range f.codes {
+		if code.UserID.String() == userID && code.CodeHash == codeHash && !code.UsedAt.Valid {
+			f.codes[i].UsedAt = sql.NullTime{Time: usedAt, Valid: true}
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (f *fakeRecoveryCodeRepository) CountUnusedRecoveryCodes(ctx context.Context, userID string) (int64, error) {
+	var count int64
+	for _, code := range f.codes {
+		if code.UserID.String() == userID && !code.UsedAt.Valid {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (f *fakeRecoveryCodeRepository) DeleteRecoveryCodesByUserID(ctx context.Context, userID string) error {
+	f.codes = nil
+	return nil
+}
+
+func newTestTwoFactorService(t *testing.T) (*twoFactorService, *fakeUserTOTPRepository, *fakeRecoveryCodeRepository) {
+	t.Helper()
+
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	totps := &fakeUserTOTPRepository{totp: &models.UserTOTP{
+		ID:          uuid.New(),
+		Secret:      secret,
+		ConfirmedAt: sql.NullTime{Time: time.Now(), Valid: true},
+		UserID:      uuid.New(),
+	}}
+	codes := &fakeRecoveryCodeRepository{}
+
+	return &twoFactorService{utr: totps, rcr: codes, tr: fakeTransactor{}}, totps, codes
+}
+
+func TestTwoFactorVerifyRejectsReplayedCode(t *testing.T) {
+	ctx := context.Background()
+	service, totps, _ := newTestTwoFactorService(t)
+	userID := totps.totp.UserID.String()
+
+	code, err := utils.TOTPCode(totps.totp.Secret, utils.TOTPStep(time.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	method, err := service.Verify(ctx, userID, models.VerifySecondFactorPayload{Code: code})
+	if err != nil || method != models.AuthMethodTOTP {
+		t.Fatalf("Verify() = %v, %v, want %v", method, err, models.AuthMethodTOTP)
+	}
+
+	if _, err := service.Verify(ctx, userID, models.VerifySecondFactorPayload{Code: code}); err != models.ErrTOTPInvalid {
+		t.Errorf("Verify() with replayed code = %v, want %v", err, models.ErrTOTPInvalid)
+	}
+
+	// Um código de um passo anterior, ainda dentro do skew, também é replay
+	previous, err := utils.TOTPCode(totps.totp.Secret, utils.TOTPStep(time.Now())-1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := service.Verify(ctx, userID, models.VerifySecondFactorPayload{Code: previous}); err != models.ErrTOTPInvalid {
+		t.Errorf("Verify() with older code = %v, want %v", err, models.ErrTOTPInvalid)
+	}
+}
+
+func TestTwoFactorVerifyLocksAfterFailedAttempts(t *testing.T) {
+	ctx := context.Background()
+	service, totps, _ := newTestTwoFactorService(t)
+	userID := totps.totp.UserID.String()
+
+	for i := 1; i < totpMaxFailedAttempts; i++ {
+		if _, err := service.Verify(ctx, userID, models.VerifySecondFactorPayload{Code: "abcdef"}); err != models.ErrTOTPInvalid {
+			t.Fatalf("Verify() attempt %d = %v, want %v", i, err, models.ErrTOTPInvalid)
+		}
+	}
+
+	if _, err := service.Verify(ctx, userID, models.VerifySecondFactorPayload{Code: "abcdef"}); err != models.ErrTOTPLocked {
+		t.Fatalf("Verify() last attempt = %v, want %v", err, models.ErrTOTPLocked)
+	}
+
+	code, err := utils.TOTPCode(totps.totp.Secret, utils.TOTPStep(time.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := service.Verify(ctx, userID, models.VerifySecondFactorPayload{Code: code}); err != models.ErrTOTPLocked {
+		t.Errorf("Verify() while locked = %v, want %v", err, models.ErrTOTPLocked)
+	}
+}
+
+func TestNormalizeRecoveryCode(t *testing.T) {
+	tests := []struct {
+		code string
+		want string
+	}{
+		{code: "ABCDE-FGHJK", want: "ABCDEFGHJK"},
+		{code: "abcde-fghjk", want: "ABCDEFGHJK"},
+		{code: "  abcdefghjk\n", want: "ABCDEFGHJK"},
+		{code: "ab-cde-fg-hjk", want: "ABCDEFGHJK"},
+	}
+
+	for _, tt := range tests {
+		if got := normalizeRecoveryCode(tt.code); got != tt.want {
+			t.Errorf("normalizeRecoveryCode(%q) = %q, want %q", tt.code, got, tt.want)
+		}
+	}
+}
+
+func TestTwoFactorVerifyRecoveryCodeIsSingleUse(t *testing.T) {
+	ctx := context.Background()
+	service, totps, codes := newTestTwoFactorService(t)
+	userID := totps.totp.UserID
+
+	resp, err := service.replaceRecoveryCodes(ctx, userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(resp.RecoveryCodes) != recoveryCodeCount || len(codes.codes) != recoveryCodeCount {
+		t.Fatalf("replaceRecoveryCodes() created %d codes, want %d", len(codes.codes), recoveryCodeCount)
+	}
+
+	// O usuário digita o código sem hífen e em minúsculas
+	typed := " " + strings.ToLower(normalizeRecoveryCode(resp.RecoveryCodes[0])) + " "
+
+	method, err := service.Verify(ctx, userID.String(), models.VerifySecondFactorPayload{RecoveryCode: typed})
+	if err != nil || method != models.AuthMethodRecoveryCode {
+		t.Fatalf("Verify() with recovery code = %v, %v, want %v", method, err, models.AuthMethodRecoveryCode)
+	}
+
+	if _, err := service.Verify(ctx, userID.String(), models.VerifySecondFactorPayload{RecoveryCode: resp.RecoveryCodes[0]}); err != models.ErrRecoveryCodeInvalid {
+		t.Errorf("Verify() with used recovery code = %v, want %v", err, models.ErrRecoveryCodeInvalid)
+	}
+}
@@ -0,0 +1,402 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/g-villarinho/flash-buy-api/clients"
+	"github.com/g-villarinho/flash-buy-api/config"
+	"github.com/g-villarinho/flash-buy-api/models"
+	"github.com/g-villarinho/flash-buy-api/pkgs"
+	"github.com/g-villarinho/flash-buy-api/repositories"
+	"github.com/g-villarinho/flash-buy-api/utils"
+)
+
+const (
+	webhookSecretPrefix = "whsec_"
+	webhookSecretSize   = 32
+
+	// Cerca de uma hora de indisponibilidade do endpoint
+	webhookMaxAttempts  = 8
+	webhookBaseBackoff  = 30 * time.Second
+	webhookMaxErrorSize = 500
+)
+
+type WebhookService interface {
+	CreateWebhook(ctx context.Context, userID, storeID string, payload models.CreateWebhookPayload) (*models.CreateWebhookResponse, error)
+	GetWebhooks(ctx context.Context, userID, storeID string) ([]models.WebhookResponse, error)
+	DeleteWebhook(ctx context.Context, userID, storeID, webhookID string) error
+	GetDeliveries(ctx context.Context, userID, storeID, webhookID string, pag models.Pagination) (*models.PaginatedResponse, error)
+	Redeliver(ctx context.Context, userID, storeID, webhookID, deliveryID string) (*models.WebhookDeliveryResponse, error)
+	Publish(ctx context.Context, event models.DomainEvent, data any) error
+	ProcessDueDeliveries(ctx context.Context) (int, error)
+}
+
+type webhookService struct {
+	di  *pkgs.Di
+	ss  StoreService
+	wc  clients.WebhookClient
+	wr  repositories.WebhookRepository
+	wdr repositories.WebhookDeliveryRepository
+}
+
+// Os de produto são publicados pelo ProductService com o produto completo
+var webhookForwardedEvents = []models.WebhookEventType{
+	models.WebhookEventBillboardCreated,
+	models.WebhookEventBillboardUpdated,
+	models.WebhookEventBillboardDeleted,
+	models.WebhookEventOrderCreated,
+	models.WebhookEventOrderStatusChanged,
+	models.WebhookEventInventoryAdjusted,
+	models.WebhookEventProductDeleted,
+}
+
+func NewWebhookService(di *pkgs.Di) (WebhookService, error) {
+	ss, err := pkgs.Invoke[StoreService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	wc, err := pkgs.Invoke[clients.WebhookClient](di)
+	if err != nil {
+		return nil, err
+	}
+
+	wr, err := pkgs.Invoke[repositories.WebhookRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	wdr, err := pkgs.Invoke[repositories.WebhookDeliveryRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	obs, err := pkgs.Invoke[OutboxService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &webhookService{
+		di:  di,
+		ss:  ss,
+		wc:  wc,
+		wr:  wr,
+		wdr: wdr,
+	}
+
+	for _, eventType := range webhookForwardedEvents {
+		obs.Subscribe(models.DomainEventType(eventType), w.forward)
+	}
+
+	return w, nil
+}
+
+func (w *webhookService) CreateWebhook(ctx context.Context, userID, storeID string, payload models.CreateWebhookPayload) (*models.CreateWebhookResponse, error) {
+	store, err := w.ss.CheckPermission(ctx, storeID, userID, models.PermissionManageStore)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := strings.TrimSpace(payload.URL)
+	if err := validateWebhookURL(endpoint); err != nil {
+		return nil, err
+	}
+
+	if len(payload.Events) == 0 {
+		return nil, models.ErrInvalidWebhookEvent
+	}
+
+	for _, event := range payload.Events {
+		if !event.IsValid() {
+			return nil, models.ErrInvalidWebhookEvent
+		}
+	}
+
+	secret, err := utils.GenerateRandomToken(webhookSecretSize)
+	if err != nil {
+		return nil, fmt.Errorf("generate webhook secret: %w", err)
+	}
+
+	webhook := models.NewWebhook(store.ID, endpoint, strings.TrimSpace(payload.Description), webhookSecretPrefix+secret, payload.Events)
+	if err := w.wr.CreateWebhook(ctx, webhook); err != nil {
+		return nil, fmt.Errorf("create webhook: %w", err)
+	}
+
+	return &models.CreateWebhookResponse{
+		WebhookResponse: webhook.ToWebhookResponse(),
+		Secret:          webhook.Secret,
+	}, nil
+}
+
+func (w *webhookService) GetWebhooks(ctx context.Context, userID, storeID string) ([]models.WebhookResponse, error) {
+	if _, err := w.ss.CheckPermission(ctx, storeID, userID, models.PermissionManageStore); err != nil {
+		return nil, err
+	}
+
+	webhooks, err := w.wr.GetWebhooksByStoreID(ctx, storeID)
+	if err != nil {
+		return nil, fmt.Errorf("get webhooks by store id %s: %w", storeID, err)
+	}
+
+	resp := make([]models.WebhookResponse, len(webhooks))
+	for i := range webhooks {
+		resp[i] = webhooks[i].ToWebhookResponse()
+	}
+
+	return resp, nil
+}
+
+func (w *webhookService) DeleteWebhook(ctx context.Context, userID, storeID, webhookID string) error {
+	if _, err := w.getStoreWebhook(ctx, userID, storeID, webhookID); err != nil {
+		return err
+	}
+
+	if err := w.wr.DeleteWebhook(ctx, webhookID); err != nil {
+		return fmt.Errorf("delete webhook %s: %w", webhookID, err)
+	}
+
+	return nil
+}
+
+func (w *webhookService) GetDeliveries(ctx context.Context, userID, storeID, webhookID string, pag models.Pagination) (*models.PaginatedResponse, error) {
+	if _, err := w.getStoreWebhook(ctx, userID, storeID, webhookID); err != nil {
+		return nil, err
+	}
+
+	result, err := w.wdr.GetWebhookDeliveriesPagedList(ctx, webhookID, pag)
+	if err != nil {
+		return nil, fmt.Errorf("get webhook deliveries paged list: %w", err)
+	}
+
+	deliveries := *result.Data.(*[]models.WebhookDelivery)
+	responses := make([]models.WebhookDeliveryResponse, len(deliveries))
+	for idx := range deliveries {
+		responses[idx] = deliveries[idx].ToWebhookDeliveryResponse()
+	}
+
+	return &models.PaginatedResponse{
+		Data:       responses,
+		Total:      result.Total,
+		TotalPages: result.TotalPages,
+		Page:       result.Page,
+		Limit:      result.Limit,
+	}, nil
+}
+
+// Mantém o ID do evento para o lojista descartar duplicatas
+func (w *webhookService) Redeliver(ctx context.Context, userID, storeID, webhookID, deliveryID string) (*models.WebhookDeliveryResponse, error) {
+	webhook, err := w.getStoreWebhook(ctx, userID, storeID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery, err := w.wdr.GetWebhookDeliveryByID(ctx, deliveryID)
+	if err != nil {
+		return nil, fmt.Errorf("get webhook delivery by id %s: %w", deliveryID, err)
+	}
+
+	if delivery == nil || delivery.WebhookID != webhook.ID {
+		return nil, models.ErrWebhookDeliveryNotFound
+	}
+
+	redelivery := models.NewWebhookDelivery(webhook.ID, delivery.EventID, delivery.EventType, delivery.Payload)
+	if err := w.wdr.CreateWebhookDeliveries(ctx, []models.WebhookDelivery{*redelivery}); err != nil {
+		return nil, fmt.Errorf("create webhook delivery: %w", err)
+	}
+
+	resp := redelivery.ToWebhookDeliveryResponse()
+	return &resp, nil
+}
+
+// O ID do evento vira o ID do webhook, então repetir não duplica as entregas
+func (w *webhookService) Publish(ctx context.Context, event models.DomainEvent, data any) error {
+	if !event.StoreID.Valid {
+		return fmt.Errorf("event %s has no store", event.ID)
+	}
+
+	published, err := w.wdr.HasWebhookDeliveriesForEvent(ctx, event.ID.String())
+	if err != nil {
+		return fmt.Errorf("has webhook deliveries for event: %w", err)
+	}
+
+	if published {
+		return nil
+	}
+
+	webhooks, err := w.wr.GetWebhooksByStoreID(ctx, event.StoreID.UUID.String())
+	if err != nil {
+		return fmt.Errorf("get webhooks by store id: %w", err)
+	}
+
+	eventType := models.WebhookEventType(event.Type)
+	body := models.WebhookEvent{
+		ID:        event.ID,
+		Type:      eventType,
+		StoreID:   event.StoreID.UUID,
+		CreatedAt: event.CreatedAt,
+		Data:      data,
+	}
+
+	var deliveries []models.WebhookDelivery
+	var payload json.RawMessage
+	for i := range webhooks {
+		if !webhooks[i].IsSubscribed(eventType) {
+			continue
+		}
+
+		if payload == nil {
+			if payload, err = json.Marshal(body); err != nil {
+				return fmt.Errorf("marshal webhook event: %w", err)
+			}
+		}
+
+		deliveries = append(deliveries, *models.NewWebhookDelivery(webhooks[i].ID, event.ID, eventType, payload))
+	}
+
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	if err := w.wdr.CreateWebhookDeliveries(ctx, deliveries); err != nil {
+		return fmt.Errorf("create webhook deliveries: %w", err)
+	}
+
+	return nil
+}
+
+func (w *webhookService) forward(ctx context.Context, event models.DomainEvent) error {
+	return w.Publish(ctx, event, event.Payload)
+}
+
+func (w *webhookService) ProcessDueDeliveries(ctx context.Context) (int, error) {
+	now := time.Now()
+
+	deliveries, err := w.wdr.GetDueWebhookDeliveries(ctx, now, config.Env.Webhook.BatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("get due webhook deliveries: %w", err)
+	}
+
+	// Só volta para a fila se a instância cair antes de registrar o resultado
+	leaseUntil := now.Add(config.Env.Webhook.Timeout + webhookBaseBackoff)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	attempted := 0
+
+	for i := range deliveries {
+		delivery := &deliveries[i]
+
+		claimed, err := w.wdr.ClaimWebhookDelivery(ctx, delivery.ID.String(), now, leaseUntil)
+		if err != nil {
+			slog.Error("claim webhook delivery", "deliveryID", delivery.ID, "error", err)
+			continue
+		}
+
+		if !claimed {
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.deliver(ctx, delivery)
+
+			mu.Lock()
+			attempted++
+			mu.Unlock()
+		}()
+	}
+
+	wg.Wait()
+
+	return attempted, nil
+}
+
+func (w *webhookService) deliver(ctx context.Context, delivery *models.WebhookDelivery) {
+	logger := slog.With(
+		"service", "webhook",
+		"method", "deliver",
+		"deliveryID", delivery.ID,
+		"webhookID", delivery.WebhookID,
+	)
+
+	attempt := delivery.Attempts + 1
+
+	status, err := w.wc.Send(ctx, clients.WebhookRequest{
+		URL:       delivery.Webhook.URL,
+		Secret:    delivery.Webhook.Secret,
+		EventID:   delivery.EventID.String(),
+		EventType: string(delivery.EventType),
+		Payload:   delivery.Payload,
+	})
+
+	if err == nil && status >= 200 && status < 300 {
+		if err := w.wdr.CompleteWebhookDelivery(ctx, delivery.ID.String(), status, time.Now()); err != nil {
+			logger.Error("complete webhook delivery", "error", err)
+		}
+		return
+	}
+
+	lastError := fmt.Sprintf("unexpected status code %d", status)
+	if err != nil {
+		lastError = err.Error()
+	}
+
+	if len(lastError) > webhookMaxErrorSize {
+		lastError = lastError[:webhookMaxErrorSize]
+	}
+
+	var nextAttemptAt *time.Time
+	if attempt < webhookMaxAttempts {
+		next := time.Now().Add(webhookBaseBackoff << (attempt - 1))
+		nextAttemptAt = &next
+	}
+
+	logger.Warn("webhook delivery failed", "attempt", attempt, "status", status, "error", lastError)
+
+	if err := w.wdr.FailWebhookDelivery(ctx, delivery.ID.String(), status, lastError, nextAttemptAt); err != nil {
+		logger.Error("fail webhook delivery", "error", err)
+	}
+}
+
+func (w *webhookService) getStoreWebhook(ctx context.Context, userID, storeID, webhookID string) (*models.Webhook, error) {
+	if _, err := w.ss.CheckPermission(ctx, storeID, userID, models.PermissionManageStore); err != nil {
+		return nil, err
+	}
+
+	webhook, err := w.wr.GetWebhookByID(ctx, webhookID)
+	if err != nil {
+		return nil, fmt.Errorf("get webhook by id %s: %w", webhookID, err)
+	}
+
+	if webhook == nil || webhook.StoreID.String() != storeID {
+		return nil, models.ErrWebhookNotFound
+	}
+
+	return webhook, nil
+}
+
+func validateWebhookURL(endpoint string) error {
+	parsed, err := url.Parse(endpoint)
+	if err != nil || parsed.Host == "" || parsed.User != nil {
+		return models.ErrInvalidWebhookURL
+	}
+
+	switch parsed.Scheme {
+	case "https":
+		return nil
+	case "http":
+		if config.Env.Webhook.AllowPrivateNetworks {
+			return nil
+		}
+	}
+
+	return models.ErrInvalidWebhookURL
+}
@@ -2,9 +2,15 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"log/slog"
+	"net/url"
+	"strings"
+	"time"
 
 	"github.com/g-villarinho/flash-buy-api/clients"
+	"github.com/g-villarinho/flash-buy-api/config"
 	"github.com/g-villarinho/flash-buy-api/databases"
 	"github.com/g-villarinho/flash-buy-api/handlers"
 	"github.com/g-villarinho/flash-buy-api/middlewares"
@@ -13,6 +19,7 @@ import (
 	"github.com/g-villarinho/flash-buy-api/pkgs"
 	"github.com/g-villarinho/flash-buy-api/repositories"
 	"github.com/g-villarinho/flash-buy-api/services"
+	"github.com/g-villarinho/flash-buy-api/storages"
 	"github.com/labstack/echo/v4"
 	"gorm.io/gorm"
 )
@@ -30,17 +37,24 @@ func initDeps(di *pkgs.Di) {
 
 	// Config
 	pkgs.Provide(di, pkgs.NewEcdsaKeyPair)
+	pkgs.Provide(di, pkgs.NewKeyRing)
 	pkgs.Provide(di, pkgs.NewRequestInfoCtx)
 
 	// Clients
 	pkgs.Provide(di, clients.NewSMTPClient)
-	pkgs.Provide(di, clients.NewCloudflareClient)
+	pkgs.Provide(di, clients.NewPaymentProvider)
+	pkgs.Provide(di, clients.NewOIDCProviders)
+	pkgs.Provide(di, clients.NewWebhookClient)
+
+	pkgs.Provide(di, storages.NewBlobStorage)
 
 	// Persistence
 	pkgs.Provide(di, persistence.NewPostgresRepository)
+	pkgs.Provide(di, persistence.NewTransactor)
 
 	// Repositories
 	pkgs.Provide(di, repositories.NewOTPRepository)
+	pkgs.Provide(di, repositories.NewOTPAttemptRepository)
 	pkgs.Provide(di, repositories.NewSessionRepository)
 	pkgs.Provide(di, repositories.NewUserRepository)
 	pkgs.Provide(di, repositories.NewStoreRepository)
@@ -50,8 +64,26 @@ func initDeps(di *pkgs.Di) {
 	pkgs.Provide(di, repositories.NewColorRepository)
 	pkgs.Provide(di, repositories.NewProductRepository)
 	pkgs.Provide(di, repositories.NewProductImageRepository)
+	pkgs.Provide(di, repositories.NewCartRepository)
+	pkgs.Provide(di, repositories.NewOrderRepository)
+	pkgs.Provide(di, repositories.NewStockAdjustmentRepository)
+	pkgs.Provide(di, repositories.NewFlashSaleRepository)
+	pkgs.Provide(di, repositories.NewProductVariantRepository)
+	pkgs.Provide(di, repositories.NewStoreMemberRepository)
+	pkgs.Provide(di, repositories.NewStoreInvitationRepository)
+	pkgs.Provide(di, repositories.NewSocialLoginStateRepository)
+	pkgs.Provide(di, repositories.NewUserTOTPRepository)
+	pkgs.Provide(di, repositories.NewRecoveryCodeRepository)
+	pkgs.Provide(di, repositories.NewStoreAPIKeyRepository)
+	pkgs.Provide(di, repositories.NewWebhookRepository)
+	pkgs.Provide(di, repositories.NewWebhookDeliveryRepository)
+	pkgs.Provide(di, repositories.NewJobRepository)
+	pkgs.Provide(di, repositories.NewOutboxRepository)
 
 	// Services
+	pkgs.Provide(di, services.NewJobQueue)
+	pkgs.Provide(di, services.NewEmailQueueService)
+	pkgs.Provide(di, services.NewOutboxService)
 	pkgs.Provide(di, services.NewAuthService)
 	pkgs.Provide(di, services.NewOTPService)
 	pkgs.Provide(di, services.NewSessionService)
@@ -66,9 +98,24 @@ func initDeps(di *pkgs.Di) {
 	pkgs.Provide(di, services.NewColorService)
 	pkgs.Provide(di, services.NewProductService)
 	pkgs.Provide(di, services.NewProductImageService)
+	pkgs.Provide(di, services.NewStorefrontService)
+	pkgs.Provide(di, services.NewCartService)
+	pkgs.Provide(di, services.NewCheckoutService)
+	pkgs.Provide(di, services.NewOrderService)
+	pkgs.Provide(di, services.NewPaymentService)
+	pkgs.Provide(di, services.NewInventoryService)
+	pkgs.Provide(di, services.NewFlashSaleService)
+	pkgs.Provide(di, services.NewProductVariantService)
+	pkgs.Provide(di, services.NewStoreMemberService)
+	pkgs.Provide(di, services.NewSocialLoginService)
+	pkgs.Provide(di, services.NewTwoFactorService)
+	pkgs.Provide(di, services.NewStoreAPIKeyService)
+	pkgs.Provide(di, services.NewWebhookService)
 
 	// Handlers
 	pkgs.Provide(di, handlers.NewAuthHandler)
+	pkgs.Provide(di, handlers.NewSessionHandler)
+	pkgs.Provide(di, handlers.NewJWKSHandler)
 	pkgs.Provide(di, handlers.NewRegisterHandler)
 	pkgs.Provide(di, handlers.NewConfigHandler)
 	pkgs.Provide(di, handlers.NewStoreHandler)
@@ -77,6 +124,19 @@ func initDeps(di *pkgs.Di) {
 	pkgs.Provide(di, handlers.NewSizeHandler)
 	pkgs.Provide(di, handlers.NewColorHandler)
 	pkgs.Provide(di, handlers.NewProductHandler)
+	pkgs.Provide(di, handlers.NewStorefrontHandler)
+	pkgs.Provide(di, handlers.NewCartHandler)
+	pkgs.Provide(di, handlers.NewOrderHandler)
+	pkgs.Provide(di, handlers.NewPaymentHandler)
+	pkgs.Provide(di, handlers.NewInventoryHandler)
+	pkgs.Provide(di, handlers.NewFlashSaleHandler)
+	pkgs.Provide(di, handlers.NewProductVariantHandler)
+	pkgs.Provide(di, handlers.NewProductImageHandler)
+	pkgs.Provide(di, handlers.NewStoreMemberHandler)
+	pkgs.Provide(di, handlers.NewSocialLoginHandler)
+	pkgs.Provide(di, handlers.NewTwoFactorHandler)
+	pkgs.Provide(di, handlers.NewStoreAPIKeyHandler)
+	pkgs.Provide(di, handlers.NewWebhookHandler)
 
 	//Notifications
 	pkgs.Provide(di, notifications.NewEmailNotification)
@@ -84,15 +144,51 @@ func initDeps(di *pkgs.Di) {
 }
 
 func setupRoutes(e *echo.Echo, di *pkgs.Di) {
+	setupStorageRoutes(e)
 	setupEnvironmentRoutes(e, di)
 	setupRegisterRouters(e, di)
+	setupJWKSRoutes(e, di)
 	setupAuthRoutes(e, di)
+	setupSocialLoginRoutes(e, di)
+	setupSessionRoutes(e, di)
+	setupTwoFactorRoutes(e, di)
 	setupStoreRoutes(e, di)
 	setupBillboardRoutes(e, di)
 	setupCategoryRoutes(e, di)
 	setupSizeRoutes(e, di)
 	setupColorRoutes(e, di)
 	setupProductRoutes(e, di)
+	setupStorefrontRoutes(e, di)
+	setupCartRoutes(e, di)
+	setupOrderRoutes(e, di)
+	setupPaymentRoutes(e, di)
+	setupInventoryRoutes(e, di)
+	setupFlashSaleRoutes(e, di)
+	setupStoreMemberRoutes(e, di)
+	setupStoreAPIKeyRoutes(e, di)
+	setupWebhookRoutes(e, di)
+}
+
+func setupStorageRoutes(e *echo.Echo) {
+	driver, err := storages.Driver()
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	if driver != storages.StorageDriverLocal {
+		return
+	}
+
+	publicURL, err := url.Parse(config.Env.Storage.PublicURL)
+	if err != nil {
+		e.Logger.Fatal(fmt.Sprintf("parse storage public url: %v", err))
+	}
+
+	if strings.Trim(publicURL.Path, "/") == "" {
+		e.Logger.Fatal("storage public url must include a path for the local driver")
+	}
+
+	e.Static(publicURL.Path, config.Env.Storage.LocalDir)
 }
 
 func setupEnvironmentRoutes(e *echo.Echo, di *pkgs.Di) {
@@ -129,10 +225,69 @@ func setupAuthRoutes(e *echo.Echo, di *pkgs.Di) {
 	group := e.Group("/v1")
 	group.POST("/login", ah.Login)
 	group.POST("/verify-code", ah.VerifyCode, am.AuthenticateWithoutEmailVerification)
+	group.POST("/magic-link/verify", ah.VerifyMagicLink)
+	group.POST("/verify-second-factor", ah.VerifySecondFactor, am.AuthenticateWithoutEmailVerification)
 	group.POST("/resend-code", ah.ResendCode, am.AuthenticateWithoutEmailVerification)
 	group.GET("/check-code", ah.CheckCode, am.AuthenticateWithoutEmailVerification)
 }
 
+func setupSocialLoginRoutes(e *echo.Echo, di *pkgs.Di) {
+	slh, err := pkgs.Invoke[handlers.SocialLoginHandler](di)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	group := e.Group("/v1/oauth")
+	group.GET("/:provider/start", slh.StartLogin)
+	group.GET("/:provider/callback", slh.Callback)
+}
+
+func setupJWKSRoutes(e *echo.Echo, di *pkgs.Di) {
+	jh, err := pkgs.Invoke[handlers.JWKSHandler](di)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	e.GET("/.well-known/jwks.json", jh.GetJWKS)
+}
+
+func setupSessionRoutes(e *echo.Echo, di *pkgs.Di) {
+	sh, err := pkgs.Invoke[handlers.SessionHandler](di)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	am, err := middlewares.NewAuthMiddleware(di)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	group := e.Group("/v1")
+	group.POST("/logout", sh.Logout, am.Authenticate)
+	group.GET("/me/sessions", sh.GetSessions, am.Authenticate)
+	group.DELETE("/me/sessions/:sessionId", sh.RevokeSession, am.Authenticate)
+}
+
+func setupTwoFactorRoutes(e *echo.Echo, di *pkgs.Di) {
+	th, err := pkgs.Invoke[handlers.TwoFactorHandler](di)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	am, err := middlewares.NewAuthMiddleware(di)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	group := e.Group("/v1")
+	group.GET("/me/two-factor", th.GetStatus, am.Authenticate)
+	group.POST("/me/two-factor/totp", th.StartEnrollment, am.Authenticate)
+	group.POST("/me/two-factor/totp/confirm", th.ConfirmEnrollment, am.Authenticate)
+	group.DELETE("/me/two-factor/totp", th.Disable, am.Authenticate, am.RequireRecentMFA)
+	group.POST("/me/two-factor/recovery-codes", th.RegenerateRecoveryCodes, am.Authenticate, am.RequireRecentMFA)
+	group.POST("/me/two-factor/verify", th.StepUp, am.Authenticate)
+}
+
 func setupStoreRoutes(e *echo.Echo, di *pkgs.Di) {
 	sh, err := pkgs.Invoke[handlers.StoreHandler](di)
 	if err != nil {
@@ -147,10 +302,10 @@ func setupStoreRoutes(e *echo.Echo, di *pkgs.Di) {
 	group := e.Group("/v1")
 	group.POST("/stores", sh.CreateStore, am.Authenticate)
 	group.GET("/me/stores/first", sh.GetUserFirstStore, am.Authenticate)
-	group.GET("/stores/:storeId", sh.GetStoreByID, am.Authenticate)
+	group.GET("/stores/:storeId", sh.GetStoreByID, am.AuthenticateWithAPIKey)
 	group.GET("/me/stores", sh.GetStoresByUserID, am.Authenticate)
 	group.PUT("/stores/:storeId", sh.UpdateStore, am.Authenticate)
-	group.DELETE("/stores/:storeId", sh.DeleteStore, am.Authenticate)
+	group.DELETE("/stores/:storeId", sh.DeleteStore, am.Authenticate, am.RequireRecentMFA)
 }
 
 func setupBillboardRoutes(e *echo.Echo, di *pkgs.Di) {
@@ -165,10 +320,11 @@ func setupBillboardRoutes(e *echo.Echo, di *pkgs.Di) {
 	}
 
 	group := e.Group("/v1")
-	group.POST("/stores/:storeId/billboards", bh.CreateBillboard, am.Authenticate)
-	group.GET("/stores/:storeId/billboards", bh.GetBillboards, am.Authenticate)
-	group.GET("/stores/:storeId/billboards/:billboardId", bh.GetBillboardByID, am.Authenticate)
-	group.DELETE("/stores/:storeId/billboards/:billboardId", bh.DeleteBillboard, am.Authenticate)
+	group.POST("/stores/:storeId/billboards", bh.CreateBillboard, am.AuthenticateWithAPIKey)
+	group.GET("/stores/:storeId/billboards", bh.GetBillboards, am.AuthenticateWithAPIKey)
+	group.GET("/stores/:storeId/billboards/:billboardId", bh.GetBillboardByID, am.AuthenticateWithAPIKey)
+	group.PUT("/stores/:storeId/billboards/:billboardId", bh.UpdateBillboard, am.AuthenticateWithAPIKey)
+	group.DELETE("/stores/:storeId/billboards/:billboardId", bh.DeleteBillboard, am.AuthenticateWithAPIKey)
 }
 
 func setupCategoryRoutes(e *echo.Echo, di *pkgs.Di) {
@@ -183,10 +339,13 @@ func setupCategoryRoutes(e *echo.Echo, di *pkgs.Di) {
 	}
 
 	group := e.Group("/v1")
-	group.POST("/stores/:storeId/categories", ch.CreateCategory, am.Authenticate)
-	group.GET("/stores/:storeId/categories", ch.GetCategoriesPagedList, am.Authenticate)
-	group.GET("/stores/:storeId/categories/:categoryId", ch.GetCategoryByID, am.Authenticate)
-	group.DELETE("/stores/:storeId/categories/:categoryId", ch.DeleteCategory, am.Authenticate)
+	group.POST("/stores/:storeId/categories", ch.CreateCategory, am.AuthenticateWithAPIKey)
+	group.GET("/stores/:storeId/categories", ch.GetCategoriesPagedList, am.AuthenticateWithAPIKey)
+	group.GET("/stores/:storeId/categories/tree", ch.GetCategoryTree, am.AuthenticateWithAPIKey)
+	group.PUT("/stores/:storeId/categories/order", ch.ReorderCategories, am.AuthenticateWithAPIKey)
+	group.GET("/stores/:storeId/categories/:categoryId", ch.GetCategoryByID, am.AuthenticateWithAPIKey)
+	group.PATCH("/stores/:storeId/categories/:categoryId/move", ch.MoveCategory, am.AuthenticateWithAPIKey)
+	group.DELETE("/stores/:storeId/categories/:categoryId", ch.DeleteCategory, am.AuthenticateWithAPIKey)
 }
 
 func setupSizeRoutes(e *echo.Echo, di *pkgs.Di) {
@@ -201,10 +360,10 @@ func setupSizeRoutes(e *echo.Echo, di *pkgs.Di) {
 	}
 
 	group := e.Group("/v1")
-	group.POST("/stores/:storeId/sizes", sh.CreateSize, am.Authenticate)
-	group.GET("/stores/:storeId/sizes", sh.GetSizesPagedList, am.Authenticate)
-	group.GET("/stores/:storeId/sizes/:sizeId", sh.GetSizeByID, am.Authenticate)
-	group.DELETE("/stores/:storeId/sizes/:sizeId", sh.DeleteSize, am.Authenticate)
+	group.POST("/stores/:storeId/sizes", sh.CreateSize, am.AuthenticateWithAPIKey)
+	group.GET("/stores/:storeId/sizes", sh.GetSizesPagedList, am.AuthenticateWithAPIKey)
+	group.GET("/stores/:storeId/sizes/:sizeId", sh.GetSizeByID, am.AuthenticateWithAPIKey)
+	group.DELETE("/stores/:storeId/sizes/:sizeId", sh.DeleteSize, am.AuthenticateWithAPIKey)
 }
 
 func setupColorRoutes(e *echo.Echo, di *pkgs.Di) {
@@ -219,10 +378,10 @@ func setupColorRoutes(e *echo.Echo, di *pkgs.Di) {
 	}
 
 	group := e.Group("/v1")
-	group.POST("/stores/:storeId/colors", ch.CreateColor, am.Authenticate)
-	group.GET("/stores/:storeId/colors", ch.GetColors, am.Authenticate)
-	group.GET("/stores/:storeId/colors/:colorId", ch.GetColorByID, am.Authenticate)
-	group.DELETE("/stores/:storeId/colors/:colorId", ch.DeleteColor, am.Authenticate)
+	group.POST("/stores/:storeId/colors", ch.CreateColor, am.AuthenticateWithAPIKey)
+	group.GET("/stores/:storeId/colors", ch.GetColors, am.AuthenticateWithAPIKey)
+	group.GET("/stores/:storeId/colors/:colorId", ch.GetColorByID, am.AuthenticateWithAPIKey)
+	group.DELETE("/stores/:storeId/colors/:colorId", ch.DeleteColor, am.AuthenticateWithAPIKey)
 }
 
 func setupProductRoutes(e *echo.Echo, di *pkgs.Di) {
@@ -237,5 +396,274 @@ func setupProductRoutes(e *echo.Echo, di *pkgs.Di) {
 	}
 
 	group := e.Group("/v1")
-	group.POST("/stores/:storeId/products", ph.CreateProduct, am.Authenticate)
+	group.POST("/stores/:storeId/products", ph.CreateProduct, am.AuthenticateWithAPIKey)
+	group.GET("/stores/:storeId/products", ph.GetProducts, am.AuthenticateWithAPIKey)
+	group.GET("/stores/:storeId/products/:productId", ph.GetProductByID, am.AuthenticateWithAPIKey)
+	group.PUT("/stores/:storeId/products/:productId", ph.UpdateProduct, am.AuthenticateWithAPIKey)
+	group.PATCH("/stores/:storeId/products/:productId/archive", ph.ArchiveProduct, am.AuthenticateWithAPIKey)
+	group.DELETE("/stores/:storeId/products/:productId", ph.DeleteProduct, am.AuthenticateWithAPIKey)
+
+	pvh, err := pkgs.Invoke[handlers.ProductVariantHandler](di)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	group.POST("/stores/:storeId/products/:productId/variants", pvh.CreateProductVariants, am.AuthenticateWithAPIKey)
+	group.GET("/stores/:storeId/products/:productId/variants", pvh.GetProductVariants, am.AuthenticateWithAPIKey)
+	group.PUT("/stores/:storeId/products/:productId/variants/:variantId", pvh.UpdateProductVariant, am.AuthenticateWithAPIKey)
+	group.DELETE("/stores/:storeId/products/:productId/variants/:variantId", pvh.DeleteProductVariant, am.AuthenticateWithAPIKey)
+
+	pih, err := pkgs.Invoke[handlers.ProductImageHandler](di)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	group.POST("/stores/:storeId/products/:productId/images", pih.AddProductImages, am.AuthenticateWithAPIKey)
+	group.GET("/stores/:storeId/products/:productId/images", pih.GetProductImages, am.AuthenticateWithAPIKey)
+	group.PUT("/stores/:storeId/products/:productId/images/order", pih.ReorderProductImages, am.AuthenticateWithAPIKey)
+	group.PATCH("/stores/:storeId/products/:productId/images/:imageId/primary", pih.SetPrimaryProductImage, am.AuthenticateWithAPIKey)
+	group.POST("/stores/:storeId/products/:productId/images/:imageId/retry", pih.RetryProductImage, am.AuthenticateWithAPIKey)
+	group.DELETE("/stores/:storeId/products/:productId/images/:imageId", pih.DeleteProductImage, am.AuthenticateWithAPIKey)
+}
+
+func setupStorefrontRoutes(e *echo.Echo, di *pkgs.Di) {
+	sh, err := pkgs.Invoke[handlers.StorefrontHandler](di)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	group := e.Group("/v1/public")
+	group.GET("/stores/:storeId", sh.GetStore)
+	group.GET("/stores/:storeId/billboards", sh.GetBillboards)
+	group.GET("/stores/:storeId/billboards/active", sh.GetActiveBillboard)
+	group.GET("/stores/:storeId/categories", sh.GetCategories)
+	group.GET("/stores/:storeId/categories/tree", sh.GetCategoryTree)
+	group.GET("/stores/:storeId/products", sh.GetProducts)
+	group.GET("/stores/:storeId/products/:productId", sh.GetProductByID)
+}
+
+func setupCartRoutes(e *echo.Echo, di *pkgs.Di) {
+	ch, err := pkgs.Invoke[handlers.CartHandler](di)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	am, err := middlewares.NewAuthMiddleware(di)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	group := e.Group("/v1/public")
+	group.POST("/stores/:storeId/cart", ch.CreateCart, am.AuthenticateOptional)
+	group.GET("/stores/:storeId/cart", ch.GetCart, am.AuthenticateOptional)
+	group.POST("/stores/:storeId/cart/items", ch.AddItem, am.AuthenticateOptional)
+	group.PUT("/stores/:storeId/cart/items/:itemId", ch.UpdateItem, am.AuthenticateOptional)
+	group.DELETE("/stores/:storeId/cart/items/:itemId", ch.RemoveItem, am.AuthenticateOptional)
+}
+
+func setupOrderRoutes(e *echo.Echo, di *pkgs.Di) {
+	oh, err := pkgs.Invoke[handlers.OrderHandler](di)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	am, err := middlewares.NewAuthMiddleware(di)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	public := e.Group("/v1/public")
+	public.POST("/stores/:storeId/checkout", oh.Checkout, am.AuthenticateOptional)
+
+	group := e.Group("/v1")
+	group.GET("/stores/:storeId/orders", oh.GetOrders, am.AuthenticateWithAPIKey)
+	group.GET("/stores/:storeId/orders/:orderId", oh.GetOrderByID, am.AuthenticateWithAPIKey)
+	group.PATCH("/stores/:storeId/orders/:orderId/status", oh.UpdateOrderStatus, am.AuthenticateWithAPIKey)
+}
+
+func setupPaymentRoutes(e *echo.Echo, di *pkgs.Di) {
+	ph, err := pkgs.Invoke[handlers.PaymentHandler](di)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	am, err := middlewares.NewAuthMiddleware(di)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	group := e.Group("/v1")
+	group.POST("/webhooks/payments", ph.HandleWebhook)
+	group.POST("/stores/:storeId/orders/:orderId/capture", ph.CapturePayment, am.AuthenticateWithAPIKey)
+	group.POST("/stores/:storeId/orders/:orderId/refund", ph.RefundPayment, am.AuthenticateWithAPIKey)
+}
+
+func setupInventoryRoutes(e *echo.Echo, di *pkgs.Di) {
+	ih, err := pkgs.Invoke[handlers.InventoryHandler](di)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	am, err := middlewares.NewAuthMiddleware(di)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	group := e.Group("/v1")
+	group.POST("/stores/:storeId/products/:productId/stock/adjustments", ih.AdjustStock, am.AuthenticateWithAPIKey)
+	group.GET("/stores/:storeId/products/:productId/stock/adjustments", ih.GetStockAdjustments, am.AuthenticateWithAPIKey)
+}
+
+func setupFlashSaleRoutes(e *echo.Echo, di *pkgs.Di) {
+	fh, err := pkgs.Invoke[handlers.FlashSaleHandler](di)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	am, err := middlewares.NewAuthMiddleware(di)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	public := e.Group("/v1/public")
+	public.GET("/stores/:storeId/flash-sales", fh.GetPublicFlashSales)
+	public.GET("/stores/:storeId/flash-sales/:flashSaleId", fh.GetPublicFlashSaleByID)
+
+	group := e.Group("/v1")
+	group.POST("/stores/:storeId/flash-sales", fh.CreateFlashSale, am.AuthenticateWithAPIKey)
+	group.GET("/stores/:storeId/flash-sales", fh.GetFlashSales, am.AuthenticateWithAPIKey)
+	group.GET("/stores/:storeId/flash-sales/:flashSaleId", fh.GetFlashSaleByID, am.AuthenticateWithAPIKey)
+	group.DELETE("/stores/:storeId/flash-sales/:flashSaleId", fh.DeleteFlashSale, am.AuthenticateWithAPIKey)
+}
+
+func setupStoreMemberRoutes(e *echo.Echo, di *pkgs.Di) {
+	smh, err := pkgs.Invoke[handlers.StoreMemberHandler](di)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	am, err := middlewares.NewAuthMiddleware(di)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	group := e.Group("/v1")
+	group.GET("/stores/:storeId/members", smh.GetMembers, am.Authenticate)
+	group.PATCH("/stores/:storeId/members/:memberId", smh.UpdateMemberRole, am.Authenticate)
+	group.DELETE("/stores/:storeId/members/:memberId", smh.RemoveMember, am.Authenticate)
+	group.POST("/stores/:storeId/invitations", smh.InviteMember, am.Authenticate)
+	group.GET("/stores/:storeId/invitations", smh.GetInvitations, am.Authenticate)
+	group.DELETE("/stores/:storeId/invitations/:invitationId", smh.RevokeInvitation, am.Authenticate)
+	group.POST("/invitations/accept", smh.AcceptInvitation, am.Authenticate)
+}
+
+func setupStoreAPIKeyRoutes(e *echo.Echo, di *pkgs.Di) {
+	akh, err := pkgs.Invoke[handlers.StoreAPIKeyHandler](di)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	am, err := middlewares.NewAuthMiddleware(di)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	group := e.Group("/v1")
+	group.POST("/stores/:storeId/api-keys", akh.CreateAPIKey, am.Authenticate)
+	group.GET("/stores/:storeId/api-keys", akh.GetAPIKeys, am.Authenticate)
+	group.DELETE("/stores/:storeId/api-keys/:apiKeyId", akh.RevokeAPIKey, am.Authenticate)
+}
+
+func setupWebhookRoutes(e *echo.Echo, di *pkgs.Di) {
+	wh, err := pkgs.Invoke[handlers.WebhookHandler](di)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	am, err := middlewares.NewAuthMiddleware(di)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	group := e.Group("/v1")
+	group.POST("/stores/:storeId/webhooks", wh.CreateWebhook, am.Authenticate)
+	group.GET("/stores/:storeId/webhooks", wh.GetWebhooks, am.Authenticate)
+	group.DELETE("/stores/:storeId/webhooks/:webhookId", wh.DeleteWebhook, am.Authenticate)
+	group.GET("/stores/:storeId/webhooks/:webhookId/deliveries", wh.GetDeliveries, am.Authenticate)
+	group.POST("/stores/:storeId/webhooks/:webhookId/deliveries/:deliveryId/redeliver", wh.Redeliver, am.Authenticate)
+}
+
+func startWebhookDispatcher(ctx context.Context, e *echo.Echo, di *pkgs.Di) {
+	ws, err := pkgs.Invoke[services.WebhookService](di)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	runPeriodic(ctx, e, "process webhook deliveries", config.Env.Webhook.PollInterval, func(ctx context.Context) error {
+		return drainBatches(ctx, config.Env.Webhook.BatchSize, ws.ProcessDueDeliveries)
+	})
+}
+
+// Só depois que os serviços registraram os inscritos
+func startOutboxRelay(ctx context.Context, e *echo.Echo, di *pkgs.Di) {
+	obs, err := pkgs.Invoke[services.OutboxService](di)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	runPeriodic(ctx, e, "process outbox events", config.Env.Outbox.PollInterval, func(ctx context.Context) error {
+		return drainBatches(ctx, config.Env.Outbox.BatchSize, obs.ProcessPendingEvents)
+	})
+}
+
+func startOrderExpirer(ctx context.Context, e *echo.Echo, di *pkgs.Di) {
+	ors, err := pkgs.Invoke[services.OrderService](di)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	runPeriodic(ctx, e, "expire pending orders", config.Env.Orders.PollInterval, func(ctx context.Context) error {
+		return drainBatches(ctx, config.Env.Orders.BatchSize, ors.ExpirePendingOrders)
+	})
+}
+
+func startCartCleaner(ctx context.Context, e *echo.Echo, di *pkgs.Di) {
+	cs, err := pkgs.Invoke[services.CartService](di)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	runPeriodic(ctx, e, "delete expired carts", config.Env.Carts.CleanupInterval, cs.DeleteExpiredCarts)
+}
+
+func runPeriodic(ctx context.Context, e *echo.Echo, name string, interval time.Duration, fn func(ctx context.Context) error) {
+	if interval <= 0 {
+		e.Logger.Fatal(fmt.Sprintf("%s: interval must be positive", name))
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			if err := fn(ctx); err != nil {
+				slog.Error(name, "error", err)
+			}
+		}
+	}()
+}
+
+func drainBatches(ctx context.Context, batchSize int, process func(ctx context.Context) (int, error)) error {
+	for {
+		processed, err := process(ctx)
+		if err != nil || processed < batchSize || ctx.Err() != nil {
+			return err
+		}
+	}
 }
@@ -0,0 +1,143 @@
+package storages
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/g-villarinho/flash-buy-api/config"
+)
+
+const cloudflareMaxErrorBodySize = 1 << 10
+
+type cloudflareResponse struct {
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// Imagens enviadas antes dos drivers, com IDs da Cloudflare, também são reconhecidas
+type cloudflareStorage struct {
+	cfg        config.Cloudflare
+	publicURL  string
+	httpClient *http.Client
+}
+
+func newCloudflareStorage(cfg config.Cloudflare, publicURL string, timeout time.Duration) (BlobStorage, error) {
+	if cfg.APIURL == "" || cfg.Token == "" || cfg.Variant == "" {
+		return nil, errors.New("cloudflare storage requires api url, token and variant")
+	}
+
+	return &cloudflareStorage{
+		cfg:        cfg,
+		publicURL:  publicURL,
+		httpClient: &http.Client{Timeout: timeout},
+	}, nil
+}
+
+func (c *cloudflareStorage) Put(ctx context.Context, key string, content []byte, contentType string) error {
+	if err := cleanKey(key); err != nil {
+		return err
+	}
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	if err := writer.WriteField("id", key); err != nil {
+		return fmt.Errorf("write id field: %w", err)
+	}
+
+	fileWriter, err := writer.CreateFormFile("file", key)
+	if err != nil {
+		return fmt.Errorf("create form file: %w", err)
+	}
+
+	if _, err := fileWriter.Write(content); err != nil {
+		return fmt.Errorf("write form file: %w", err)
+	}
+
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("close multipart writer: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.cfg.APIURL, body)
+	if err != nil {
+		return fmt.Errorf("create upload image request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	// Um 409 vem de um job repetido que já enviou o mesmo conteúdo
+	return c.do(req, http.StatusOK, http.StatusConflict)
+}
+
+func (c *cloudflareStorage) Delete(ctx context.Context, key string) error {
+	if key == "" {
+		return ErrInvalidKey
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, strings.TrimRight(c.cfg.APIURL, "/")+"/"+url.PathEscape(key), nil)
+	if err != nil {
+		return fmt.Errorf("create delete image request: %w", err)
+	}
+
+	return c.do(req, http.StatusOK, http.StatusNotFound)
+}
+
+func (c *cloudflareStorage) URL(key string) string {
+	return joinURL(c.publicURL, key) + "/" + c.cfg.Variant
+}
+
+func (c *cloudflareStorage) keyFromURL(rawURL string) (string, bool) {
+	prefix := strings.TrimRight(c.publicURL, "/") + "/"
+	if !strings.HasPrefix(rawURL, prefix) {
+		return "", false
+	}
+
+	rest := strings.TrimPrefix(rawURL, prefix)
+	slash := strings.LastIndex(rest, "/")
+	if slash <= 0 {
+		return "", false
+	}
+
+	key := rest[:slash]
+	if cleanKey(key) != nil {
+		return "", false
+	}
+
+	return key, true
+}
+
+func (c *cloudflareStorage) do(req *http.Request, expected ...int) error {
+	req.Header.Set("Authorization", "Bearer "+c.cfg.Token)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("send cloudflare request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	for _, status := range expected {
+		if resp.StatusCode == status {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			return nil
+		}
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, cloudflareMaxErrorBodySize))
+
+	var cfResp cloudflareResponse
+	if err := json.Unmarshal(body, &cfResp); err == nil && len(cfResp.Errors) > 0 {
+		return fmt.Errorf("cloudflare %s returned status %d: %s", req.Method, resp.StatusCode, cfResp.Errors[0].Message)
+	}
+
+	return fmt.Errorf("cloudflare %s returned status %d: %s", req.Method, resp.StatusCode, strings.TrimSpace(string(body)))
+}
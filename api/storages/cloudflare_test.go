@@ -0,0 +1,81 @@
+package storages
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/g-villarinho/flash-buy-api/config"
+)
+
+func TestCloudflareStorage(t *testing.T) {
+	var uploadedID, deletedPath string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodPost:
+			uploadedID = r.FormValue("id")
+			if _, _, err := r.FormFile("file"); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+		case http.MethodDelete:
+			deletedPath = r.URL.EscapedPath()
+		}
+	}))
+	defer server.Close()
+
+	storage, err := newCloudflareStorage(config.Cloudflare{APIURL: server.URL + "/images/v1", Token: "token", Variant: "public"}, "https://imagedelivery.net/hash", time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := storage.Put(context.Background(), "products/a.webp", []byte("image"), "image/webp"); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	if uploadedID != "products/a.webp" {
+		t.Errorf("uploaded id = %q, want %q", uploadedID, "products/a.webp")
+	}
+
+	if err := storage.Delete(context.Background(), "products/a.webp"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	if deletedPath != "/images/v1/products%2Fa.webp" {
+		t.Errorf("delete path = %q, want %q", deletedPath, "/images/v1/products%2Fa.webp")
+	}
+}
+
+func TestCloudflareKeyFromURL(t *testing.T) {
+	storage, err := newCloudflareStorage(config.Cloudflare{APIURL: "https://api.cloudflare.com/images/v1", Token: "token", Variant: "public"}, "https://imagedelivery.net/hash", time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		url  string
+		key  string
+		want bool
+	}{
+		{url: storage.URL("products/a.webp"), key: "products/a.webp", want: true},
+		{url: "https://imagedelivery.net/hash/2cdc28f0-017a-49c4-9ed7-87056c83901/public", key: "2cdc28f0-017a-49c4-9ed7-87056c83901", want: true},
+		{url: "https://imagedelivery.net/hash/public", want: false},
+		{url: "https://imagedelivery.net/other/id/public", want: false},
+		{url: "https://imagedelivery.net/hash/../secret/public", want: false},
+	}
+
+	for _, tt := range tests {
+		key, ok := KeyFromURL(storage, tt.url)
+		if ok != tt.want || key != tt.key {
+			t.Errorf("KeyFromURL(%q) = %q, %v, want %q, %v", tt.url, key, ok, tt.key, tt.want)
+		}
+	}
+}
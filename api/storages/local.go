@@ -0,0 +1,76 @@
+package storages
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+type localStorage struct {
+	dir       string
+	publicURL string
+}
+
+func newLocalStorage(dir, publicURL string) BlobStorage {
+	return &localStorage{
+		dir:       dir,
+		publicURL: publicURL,
+	}
+}
+
+func (l *localStorage) Put(ctx context.Context, key string, content []byte, contentType string) error {
+	if err := cleanKey(key); err != nil {
+		return err
+	}
+
+	target := filepath.Join(l.dir, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return fmt.Errorf("create storage directory: %w", err)
+	}
+
+	// Uma leitura concorrente nunca vê o arquivo pela metade
+	tmp, err := os.CreateTemp(filepath.Dir(target), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write temp file: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close temp file: %w", err)
+	}
+
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return fmt.Errorf("chmod temp file: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), target); err != nil {
+		return fmt.Errorf("rename temp file: %w", err)
+	}
+
+	return nil
+}
+
+func (l *localStorage) Delete(ctx context.Context, key string) error {
+	if err := cleanKey(key); err != nil {
+		return err
+	}
+
+	err := os.Remove(filepath.Join(l.dir, filepath.FromSlash(key)))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("remove file: %w", err)
+	}
+
+	return nil
+}
+
+func (l *localStorage) URL(key string) string {
+	return joinURL(l.publicURL, key)
+}
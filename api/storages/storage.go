@@ -0,0 +1,98 @@
+package storages
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/g-villarinho/flash-buy-api/config"
+	"github.com/g-villarinho/flash-buy-api/pkgs"
+)
+
+const (
+	StorageDriverLocal      = "local"
+	StorageDriverS3         = "s3"
+	StorageDriverCloudflare = "cloudflare"
+)
+
+var ErrInvalidKey = errors.New("invalid storage key")
+
+type BlobStorage interface {
+	Put(ctx context.Context, key string, content []byte, contentType string) error
+	Delete(ctx context.Context, key string) error
+	URL(key string) string
+}
+
+type keyParser interface {
+	keyFromURL(url string) (string, bool)
+}
+
+// Em produção um driver esquecido perderia as imagens no próximo deploy
+func Driver() (string, error) {
+	if config.Env.Storage.Driver != "" {
+		return config.Env.Storage.Driver, nil
+	}
+
+	if !config.Env.IsDev() {
+		return "", errors.New("STORAGE_DRIVER is required outside dev")
+	}
+
+	return StorageDriverLocal, nil
+}
+
+func NewBlobStorage(di *pkgs.Di) (BlobStorage, error) {
+	driver, err := Driver()
+	if err != nil {
+		return nil, err
+	}
+
+	switch driver {
+	case StorageDriverLocal:
+		return newLocalStorage(config.Env.Storage.LocalDir, config.Env.Storage.PublicURL), nil
+	case StorageDriverS3:
+		return newS3Storage(config.Env.Storage.S3, config.Env.Storage.PublicURL, config.Env.Storage.Timeout)
+	case StorageDriverCloudflare:
+		return newCloudflareStorage(config.Env.Storage.Cloudflare, config.Env.Storage.PublicURL, config.Env.Storage.Timeout)
+	default:
+		return nil, fmt.Errorf("unsupported storage driver: %s", driver)
+	}
+}
+
+func KeyFromURL(storage BlobStorage, url string) (string, bool) {
+	if parser, ok := storage.(keyParser); ok {
+		return parser.keyFromURL(url)
+	}
+
+	prefix := storage.URL("")
+	if !strings.HasPrefix(url, prefix) {
+		return "", false
+	}
+
+	key := strings.TrimPrefix(url, prefix)
+	if cleanKey(key) != nil {
+		return "", false
+	}
+
+	return key, true
+}
+
+// Impede que uma chave escape do diretório ou do bucket
+func cleanKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") || path.Clean(key) != key {
+		return ErrInvalidKey
+	}
+
+	for _, part := range strings.Split(key, "/") {
+		if part == ".." || part == "." {
+			return ErrInvalidKey
+		}
+	}
+
+	return nil
+}
+
+func joinURL(base, key string) string {
+	return strings.TrimRight(base, "/") + "/" + key
+}
@@ -0,0 +1,24 @@
+package storages
+
+import "testing"
+
+func TestKeyFromURL(t *testing.T) {
+	storage := newLocalStorage(t.TempDir(), "http://localhost:8080/uploads/")
+
+	tests := []struct {
+		url  string
+		key  string
+		want bool
+	}{
+		{url: "http://localhost:8080/uploads/products/a.webp", key: "products/a.webp", want: true},
+		{url: "http://localhost:8080/uploads/../secret", want: false},
+		{url: "https://imagedelivery.net/hash/id/public", want: false},
+	}
+
+	for _, tt := range tests {
+		key, ok := KeyFromURL(storage, tt.url)
+		if ok != tt.want || key != tt.key {
+			t.Errorf("KeyFromURL(%q) = %q, %v, want %q, %v", tt.url, key, ok, tt.key, tt.want)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"testing"
+)
+
+func TestJPEGOrientation(t *testing.T) {
+	var src bytes.Buffer
+	if err := jpeg.Encode(&src, image.NewRGBA(image.Rect(0, 0, 4, 2)), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// APP1 com um IFD de uma entrada: Orientation = 6
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08\x00\x01\x01\x12\x00\x03\x00\x00\x00\x01\x00\x06\x00\x00\x00\x00\x00\x00\x00\x00")
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+
+	app1 := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(app1[2:], uint16(len(segment)+2))
+	app1 = append(app1, segment...)
+
+	content := append([]byte{0xFF, 0xD8}, app1...)
+	content = append(content, src.Bytes()[2:]...)
+
+	if got := JPEGOrientation(content); got != 6 {
+		t.Errorf("JPEGOrientation() = %d, want 6", got)
+	}
+
+	if got := JPEGOrientation(src.Bytes()); got != 1 {
+		t.Errorf("JPEGOrientation() without exif = %d, want 1", got)
+	}
+}
+
+func TestApplyOrientation(t *testing.T) {
+	red := color.RGBA{R: 255, A: 255}
+
+	// Pixel vermelho no canto superior esquerdo de uma imagem 3x2
+	src := image.NewRGBA(image.Rect(0, 0, 3, 2))
+	src.Set(0, 0, red)
+
+	tests := []struct {
+		orientation int
+		size        image.Point
+		red         image.Point
+	}{
+		{orientation: 1, size: image.Pt(3, 2), red: image.Pt(0, 0)},
+		{orientation: 3, size: image.Pt(3, 2), red: image.Pt(2, 1)},
+		{orientation: 6, size: image.Pt(2, 3), red: image.Pt(1, 0)},
+		{orientation: 8, size: image.Pt(2, 3), red: image.Pt(0, 2)},
+	}
+
+	for _, tt := range tests {
+		dst := ApplyOrientation(src, tt.orientation)
+		if size := dst.Bounds().Size(); size != tt.size {
+			t.Errorf("orientation %d: size = %v, want %v", tt.orientation, size, tt.size)
+		}
+
+		if got := color.RGBAModel.Convert(dst.At(tt.red.X, tt.red.Y)); got != red {
+			t.Errorf("orientation %d: pixel at %v = %v, want red", tt.orientation, tt.red, got)
+		}
+	}
+}
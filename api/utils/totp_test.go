@@ -0,0 +1,90 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// Segredo ASCII "12345678901234567890" dos vetores SHA-1 da RFC 6238
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+// Os vetores da RFC têm 8 dígitos, os 6 finais são o código de 6 dígitos
+func TestTOTPCodeRFC6238(t *testing.T) {
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{unix: 59, want: "94287082"},
+		{unix: 1111111109, want: "07081804"},
+		{unix: 1111111111, want: "14050471"},
+		{unix: 1234567890, want: "89005924"},
+		{unix: 2000000000, want: "69279037"},
+		{unix: 20000000000, want: "65353130"},
+	}
+
+	for _, tt := range tests {
+		got, err := TOTPCode(rfc6238Secret, TOTPStep(time.Unix(tt.unix, 0)))
+		if err != nil {
+			t.Fatalf("TOTPCode(%d) error = %v", tt.unix, err)
+		}
+
+		if want := tt.want[len(tt.want)-TOTPDigits:]; got != want {
+			t.Errorf("TOTPCode(%d) = %s, want %s", tt.unix, got, want)
+		}
+	}
+}
+
+func TestTOTPCodeLowercaseSecret(t *testing.T) {
+	got, err := TOTPCode(strings.ToLower(rfc6238Secret), 1)
+	if err != nil || got != "287082" {
+		t.Errorf("TOTPCode() with lowercase secret = %s, %v, want 287082", got, err)
+	}
+}
+
+func TestValidateTOTPSkew(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	current := TOTPStep(now)
+
+	tests := []struct {
+		name   string
+		offset int64
+		skew   int64
+		want   bool
+	}{
+		{name: "current step", offset: 0, skew: 0, want: true},
+		{name: "previous step within skew", offset: -1, skew: 1, want: true},
+		{name: "next step within skew", offset: 1, skew: 1, want: true},
+		{name: "previous step without skew", offset: -1, skew: 0, want: false},
+		{name: "two steps behind", offset: -2, skew: 1, want: false},
+		{name: "two steps ahead", offset: 2, skew: 1, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := TOTPCode(rfc6238Secret, current+tt.offset)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			step, ok := ValidateTOTP(rfc6238Secret, code, now, tt.skew)
+			if ok != tt.want {
+				t.Fatalf("ValidateTOTP() ok = %v, want %v", ok, tt.want)
+			}
+
+			if ok && step != current+tt.offset {
+				t.Errorf("ValidateTOTP() step = %d, want %d", step, current+tt.offset)
+			}
+		})
+	}
+}
+
+func TestValidateTOTPRejectsMalformedCode(t *testing.T) {
+	now := time.Unix(59, 0)
+
+	for _, code := range []string{"", "28708", "2870820", "94287082"} {
+		if _, ok := ValidateTOTP(rfc6238Secret, code, now, 1); ok {
+			t.Errorf("ValidateTOTP(%q) accepted a malformed code", code)
+		}
+	}
+}